@@ -3,7 +3,7 @@ import ReactDOM from 'react-dom/client'
 import { Layout } from 'antd';
 const { Content } = Layout;
 import { BrowserRouter as Router, Routes, Route, useNavigate, useLocation } from 'react-router-dom';
-import { menuItems } from './config/menuItems';
+import { menuItems, buildMenuItems, MenuItem } from './config/menuItems';
 import 'antd/dist/reset.css'
 
 // 导入拆分出的组件
@@ -11,18 +11,24 @@ import Sidebar from './components/Sidebar';
 import HeaderContent from './components/Header';
 import ResetPasswordModal from './components/ResetPasswordModal';
 import ProtectedRoute from './components/ProtectedRoute';
+import OidcCallbackPage from './pages/auth/OidcCallbackPage';
+import ResetPasswordPage from './pages/auth/ResetPasswordPage';
 
 // 导入工具函数
 import { clearAllCache, getUserInfo, getRoleInfo, extractRouteItems } from './utility/AuthUtils';
+import { authService } from './services/authService';
 
 const LayoutContent: React.FC = () => {
   const [collapsed, setCollapsed] = useState(false);
-  const [isResetPasswordModalVisible, setIsResetPasswordModalVisible] = useState(false);
+  // 密码已过期时进入系统后立即要求修改密码
+  const mustChangePassword = localStorage.getItem('mustChangePassword') === '1';
+  const [isResetPasswordModalVisible, setIsResetPasswordModalVisible] = useState(mustChangePassword);
   const navigate = useNavigate();
   const location = useLocation();
 
   // 处理退出登录
-  const handleLogout = () => {
+  const handleLogout = async () => {
+    await authService.logout();
     clearAllCache();
     navigate('/auth/login');
   };
@@ -34,6 +40,9 @@ const LayoutContent: React.FC = () => {
 
   // 关闭重置密码弹窗
   const handleCloseResetPasswordModal = () => {
+    if (mustChangePassword) {
+      return;
+    }
     setIsResetPasswordModalVisible(false);
   };
 
@@ -63,9 +72,18 @@ const LayoutContent: React.FC = () => {
         
         // 检查token是否过期
         if (currentTime >= expireTimeDate) {
-          // token已过期，清除所有缓存并重定向到登录页面
-          clearAllCache();
-          navigate('/auth/login');
+          // token已过期，尝试使用刷新令牌换取新token，失败则清除所有缓存并重定向到登录页面
+          authService.refreshToken()
+            .then(ok => {
+              if (!ok) {
+                clearAllCache();
+                navigate('/auth/login');
+              }
+            })
+            .catch(() => {
+              clearAllCache();
+              navigate('/auth/login');
+            });
         }
       } catch (error) {
         console.error('解析过期时间失败:', error);
@@ -76,12 +94,27 @@ const LayoutContent: React.FC = () => {
     }
   }, [navigate]);
 
-  // 生成路由配置，但排除登录页面
-  const routeItems = extractRouteItems(menuItems).filter(item => item.key !== '/auth/login');
+  // 当前用户的菜单树，由接口树管理，获取失败时侧边栏使用本地配置的菜单
+  const [menus, setMenus] = useState<MenuItem[]>([]);
+  useEffect(() => {
+    if (!localStorage.getItem('token')) {
+      return;
+    }
+    authService.getMenus()
+      .then(res => setMenus(res.code === 0 ? buildMenuItems(res.data?.list || []) : []))
+      .catch(() => setMenus([]));
+  }, []);
+
+  // 生成路由配置，但排除登录页面，后端菜单中新增的页面同样生成路由
+  const localRouteItems = extractRouteItems(menuItems).filter(item => item.key !== '/auth/login');
+  const routeItems = [
+    ...localRouteItems,
+    ...extractRouteItems(menus).filter(item => !localRouteItems.some(local => local.key === item.key))
+  ];
 
   return (
     <Layout style={{ minHeight: '100vh' }}>
-      <Sidebar collapsed={collapsed} />
+      <Sidebar collapsed={collapsed} menus={menus} />
       <Layout>
         <HeaderContent 
           collapsed={collapsed}
@@ -121,6 +154,7 @@ const LayoutContent: React.FC = () => {
       <ResetPasswordModal 
         visible={isResetPasswordModalVisible} 
         onCancel={handleCloseResetPasswordModal} 
+        forced={mustChangePassword}
       />
     </Layout>
   );
@@ -138,6 +172,12 @@ const App: React.FC = () => {
         {LoginPage && (
           <Route path="/auth/login" element={<LoginPage />} />
         )}
+
+        {/* 单点登录回调页面 */}
+        <Route path="/auth/oidc/callback" element={<OidcCallbackPage />} />
+
+        {/* 找回密码页面，邮件中的重置链接也指向这里 */}
+        <Route path="/auth/reset-password" element={<ResetPasswordPage />} />
         
         {/* 其他页面路由，使用LayoutContent布局 */}
         <Route path="/*" element={<LayoutContent />} />
@@ -1,22 +1,30 @@
 import React from 'react';
 import { Modal, Form, Input, message } from 'antd';
+import { useNavigate } from 'react-router-dom';
 import { authService } from '../services/authService';
+import { clearAllCache } from '../utility/AuthUtils';
 
 interface ResetPasswordModalProps {
   visible: boolean;
   onCancel: () => void;
+  // 密码已过期时必须修改，不能关闭弹窗
+  forced?: boolean;
 }
 
-const ResetPasswordModal: React.FC<ResetPasswordModalProps> = ({ visible, onCancel }) => {
+const ResetPasswordModal: React.FC<ResetPasswordModalProps> = ({ visible, onCancel, forced = false }) => {
   const [resetPasswordForm] = Form.useForm();
+  const navigate = useNavigate();
 
   const handleOk = async () => {
     try {
       const values = await resetPasswordForm.validateFields();
-      const result = await authService.resetPassword(values.newPassword);
+      const result = await authService.resetPassword(values.oldPassword, values.newPassword);
       if (result.code === 0) {
         resetPasswordForm.resetFields();
         onCancel();
+        // 密码修改后服务端会吊销所有登录，需要重新登录
+        clearAllCache();
+        navigate('/auth/login');
       } else {
         message.error(result.message || '密码重置失败');
       }
@@ -36,24 +44,50 @@ const ResetPasswordModal: React.FC<ResetPasswordModalProps> = ({ visible, onCanc
 
   return (
     <Modal
-      title="重置密码"
+      title={forced ? '密码已过期，请修改密码' : '重置密码'}
       open={visible}
       okText="确定"
       cancelText="取消"
       onOk={handleOk}
       onCancel={onCancel}
+      closable={!forced}
+      maskClosable={!forced}
+      cancelButtonProps={{ style: forced ? { display: 'none' } : undefined }}
       afterClose={handleAfterClose}
     >
       <Form form={resetPasswordForm} layout="vertical">
+        <Form.Item
+          name="oldPassword"
+          label="原密码"
+          rules={[{ required: true, message: '请输入原密码' }]}
+        >
+          <Input.Password placeholder="请输入原密码" autoComplete="current-password" />
+        </Form.Item>
         <Form.Item
           name="newPassword"
           label="新密码"
+          extra="密码需符合系统密码策略，且不能与最近用过的密码相同"
+          rules={[{ required: true, message: '请输入新密码' }]}
+        >
+          <Input.Password placeholder="请输入新密码" autoComplete="new-password" />
+        </Form.Item>
+        <Form.Item
+          name="confirmPassword"
+          label="确认新密码"
+          dependencies={['newPassword']}
           rules={[
-            { required: true, message: '请输入新密码' },
-            { min: 6, message: '密码长度不能小于6位' }
+            { required: true, message: '请再次输入新密码' },
+            ({ getFieldValue }) => ({
+              validator(_, value) {
+                if (!value || getFieldValue('newPassword') === value) {
+                  return Promise.resolve();
+                }
+                return Promise.reject(new Error('两次输入的密码不一致'));
+              },
+            }),
           ]}
         >
-          <Input.Password placeholder="请输入新密码" />
+          <Input.Password placeholder="请再次输入新密码" autoComplete="new-password" />
         </Form.Item>
       </Form>
     </Modal>
@@ -8,9 +8,11 @@ const { Sider } = Layout;
 
 interface SidebarProps {
   collapsed: boolean;
+  // 后端返回的菜单树，为空时使用本地配置的菜单
+  menus?: MenuItem[];
 }
 
-const Sidebar: React.FC<SidebarProps> = ({ collapsed }) => {
+const Sidebar: React.FC<SidebarProps> = ({ collapsed, menus }) => {
   const navigate = useNavigate();
   const location = useLocation();
   const [openKeys, setOpenKeys] = useState<string[]>([]);
@@ -61,30 +63,42 @@ const Sidebar: React.FC<SidebarProps> = ({ collapsed }) => {
       });
   };
 
-  // 根据apiCodes和hidden属性过滤菜单项
-  const filteredMenuItems = filterMenuItemsByPermission(menuItems);
+  // 后端菜单已按权限过滤，本地菜单根据apiCodes和hidden属性过滤
+  const sourceMenuItems = menus && menus.length > 0 ? menus : menuItems;
+  const filteredMenuItems = menus && menus.length > 0 ? menus : filterMenuItemsByPermission(menuItems);
 
-  const processedMenuItems = filteredMenuItems.map(item => ({
+  const processMenuItems = (items: MenuItem[]): any[] => items.map(item => ({
     ...item,
     onClick: item.children ? undefined : () => navigate(item.key),
-    children: item.children?.map(child => ({
-      ...child,
-      onClick: () => navigate(child.key),
-    })),
+    children: item.children ? processMenuItems(item.children) : undefined,
   }));
 
+  const processedMenuItems = processMenuItems(filteredMenuItems);
+
   // 监听路由变化，自动展开父级菜单
   useEffect(() => {
     const currentPath = location.pathname;
     // 查找当前路径对应的父级菜单key
-    const parentKey = menuItems.find(item => 
-      item.children?.some(child => child.key === currentPath)
-    )?.key;
+    const findParentKeys = (items: MenuItem[], parents: string[]): string[] | undefined => {
+      for (const item of items) {
+        if (item.key === currentPath) {
+          return parents;
+        }
+        if (item.children) {
+          const found = findParentKeys(item.children, [...parents, item.key]);
+          if (found) {
+            return found;
+          }
+        }
+      }
+      return undefined;
+    };
+    const parentKeys = findParentKeys(sourceMenuItems, []);
     
-    if (parentKey) {
-      setOpenKeys([parentKey]);
+    if (parentKeys && parentKeys.length > 0) {
+      setOpenKeys(parentKeys);
     }
-  }, [location.pathname]);
+  }, [location.pathname, menus]);
 
   return (
     <Sider trigger={null} collapsible collapsed={collapsed}>
@@ -24,6 +24,7 @@ import CategoryList from '../pages/cms/category/index';
 import ArticleList from '../pages/cms/article/index';
 import DemoPage from '../pages/upload/DemoPage';
 import SiteSettingList from '../pages/cms/site-setting/index';
+import type { AuthMenu } from '../services/authService';
 
 export interface MenuItem {
   key: string;
@@ -130,4 +131,49 @@ export const menuItems: MenuItem[] = [
     // 通常登录页面不需要显示在菜单中
     hidden: true
   }
-];
\ No newline at end of file
+];
+
+// 接口树中菜单的图标名称对应的图标组件
+export const menuIcons: Record<string, React.ComponentType> = {
+  UserOutlined,
+  VideoCameraOutlined,
+  ApiOutlined,
+  HomeOutlined,
+  TeamOutlined,
+  SafetyOutlined,
+  UserAddOutlined,
+  SettingOutlined,
+  AppstoreOutlined,
+  UploadOutlined,
+  FileTextOutlined,
+  EnvironmentOutlined
+};
+
+// 接口树中菜单的组件路径对应的页面组件，新增页面时在这里注册
+export const menuComponents: Record<string, React.ComponentType> = {
+  'Welcome': Welcome,
+  'permission/user/index': UserManagement,
+  'permission/role/index': RoleManagement,
+  'permission/api/index': ApiManagement,
+  'permission/department/index': DepartmentList,
+  'PermissionExample': PermissionExample,
+  'cms/category/index': CategoryList,
+  'cms/article/index': ArticleList,
+  'cms/site-setting/index': SiteSettingList,
+  'upload/DemoPage': DemoPage
+};
+
+// 将后端返回的菜单树转换为侧边栏菜单，后端已按权限过滤和排序
+export const buildMenuItems = (menus: AuthMenu[]): MenuItem[] => {
+  return menus.map(menu => {
+    const icon = menuIcons[menu.icon];
+    return {
+      key: menu.route || `menu-${menu.id}`,
+      icon: icon ? React.createElement(icon) : undefined,
+      label: menu.name,
+      permission: menu.permissionCode,
+      component: menuComponents[menu.component],
+      children: menu.children && menu.children.length > 0 ? buildMenuItems(menu.children) : undefined
+    };
+  });
+};
\ No newline at end of file
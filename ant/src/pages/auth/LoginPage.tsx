@@ -1,17 +1,25 @@
 import React, { useState, useEffect } from 'react';
-import { Form, Input, message, Card, Space, Button } from 'antd';
-import { UserOutlined, LockOutlined, ReloadOutlined } from '@ant-design/icons';
-import { authService, CaptchaResponse } from '../../services/authService';
+import { Form, Input, message, Card, Space, Button, Modal, Typography } from 'antd';
+import { UserOutlined, LockOutlined, ReloadOutlined, SafetyOutlined } from '@ant-design/icons';
+import { useLocation } from 'react-router-dom';
+import { authService, CaptchaResponse, LoginRes, OidcConfigRes } from '../../services/authService';
 
 const LoginPage: React.FC = () => {
   const [form] = Form.useForm();
   const [captcha, setCaptcha] = useState<CaptchaResponse | null>(null);
   const [loading, setLoading] = useState(false);
   const [captchaLoading, setCaptchaLoading] = useState(false);
+  const location = useLocation();
+  // 两步验证登录挑战，单点登录需要两步验证时由回调页面传入
+  const [mfa, setMfa] = useState<LoginRes | null>((location.state as { mfa?: LoginRes } | null)?.mfa ?? null);
+  const [mfaForm] = Form.useForm();
+  // 单点登录配置
+  const [oidc, setOidc] = useState<OidcConfigRes | null>(null);
 
-  // 初始化时获取验证码
+  // 初始化时获取验证码和单点登录配置
   useEffect(() => {
     fetchCaptcha();
+    authService.getOidcConfig().then(setOidc).catch(() => setOidc(null));
   }, []);
 
   // 刷新验证码
@@ -47,7 +55,10 @@ const LoginPage: React.FC = () => {
         captchaCode: values.captcha,
       };
       const result = await authService.login(loginData);
-      if (result.code === 0) {
+      if (result.code === 0 && result.data?.mfaRequired) {
+        // 密码正确，需要输入两步验证码
+        setMfa(result.data);
+      } else if (result.code === 0) {
 
         // 登录成功后，页面会被重定向到主页
         window.location.href = '/';
@@ -64,6 +75,65 @@ const LoginPage: React.FC = () => {
     }
   };
 
+  // 两步验证提交处理
+  const handleLoginMfa = async (values: any) => {
+    if (!mfa?.mfaToken) {
+      return;
+    }
+
+    try {
+      setLoading(true);
+
+      const result = await authService.loginMfa({
+        mfaToken: mfa.mfaToken,
+        code: values.code,
+        recoveryCode: values.recoveryCode,
+      });
+      if (result.code === 0 && result.data) {
+        // 首次绑定时显示恢复码，恢复码只显示一次
+        if (result.data.recoveryCodes?.length) {
+          Modal.info({
+            title: '请妥善保存恢复码',
+            content: (
+              <Typography.Paragraph copyable>{result.data.recoveryCodes.join('\n')}</Typography.Paragraph>
+            ),
+            onOk: () => {
+              window.location.href = '/';
+            },
+          });
+          return;
+        }
+        window.location.href = '/';
+      } else if (result.code === 10) {
+        // 挑战已过期，重新输入密码
+        setMfa(null);
+        form.resetFields(['captcha']);
+        await fetchCaptcha();
+      } else {
+        mfaForm.resetFields();
+      }
+    } catch (error) {
+      console.error('两步验证发生错误:', error);
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  // 跳转到身份提供方登录
+  const handleOidcLogin = async () => {
+    try {
+      setLoading(true);
+      const result = await authService.oidcAuthorize();
+      if (result.code === 0 && result.data?.url) {
+        window.location.href = result.data.url;
+        return;
+      }
+    } catch (error) {
+      console.error('单点登录发生错误:', error);
+    }
+    setLoading(false);
+  };
+
   // 验证码图片点击事件
   const handleCaptchaClick = () => {
     if (!captchaLoading) {
@@ -80,6 +150,42 @@ const LoginPage: React.FC = () => {
       backgroundColor: '#f0f2f5',
     }}>
       <Card title="管理系统登录" style={{ width: 400, borderRadius: 8 }}>
+        {mfa ? (
+        <Form form={mfaForm} layout="vertical" onFinish={handleLoginMfa}>
+          {mfa.mfaSetup && (
+            <Form.Item label="绑定验证器">
+              <Typography.Paragraph type="secondary">
+                账号所属角色要求开启两步验证，请在验证器应用中添加以下密钥，或使用绑定链接生成二维码扫码添加
+              </Typography.Paragraph>
+              <Typography.Paragraph copyable code>{mfa.mfaSetup.secret}</Typography.Paragraph>
+              <Typography.Paragraph copyable={{ text: mfa.mfaSetup.uri }} type="secondary">复制绑定链接</Typography.Paragraph>
+            </Form.Item>
+          )}
+          <Form.Item
+            name="code"
+            label="验证码"
+            extra="请输入验证器应用中的6位验证码"
+          >
+            <Input
+              prefix={<SafetyOutlined className="site-form-item-icon" />}
+              placeholder="请输入验证码"
+              maxLength={6}
+              inputMode="numeric"
+              autoComplete="one-time-code"
+            />
+          </Form.Item>
+          {!mfa.mfaSetup && (
+            <Form.Item name="recoveryCode" label="恢复码" extra="无法使用验证器应用时，可输入恢复码代替验证码">
+              <Input placeholder="请输入恢复码" />
+            </Form.Item>
+          )}
+          <Form.Item>
+            <Button type="primary" htmlType="submit" style={{ width: '100%' }} loading={loading}>
+              验证
+            </Button>
+          </Form.Item>
+        </Form>
+        ) : (
         <Form
           form={form}
           layout="vertical"
@@ -204,7 +310,20 @@ const LoginPage: React.FC = () => {
               登录
             </Button>
           </Form.Item>
+
+          <div style={{ textAlign: 'right', marginTop: -12, marginBottom: 12 }}>
+            <Typography.Link href="/auth/reset-password">忘记密码？</Typography.Link>
+          </div>
+
+          {oidc?.enabled && (
+            <Form.Item>
+              <Button style={{ width: '100%' }} loading={loading} onClick={handleOidcLogin}>
+                {oidc.name || '单点登录'}
+              </Button>
+            </Form.Item>
+          )}
         </Form>
+        )}
       </Card>
     </div>
   );
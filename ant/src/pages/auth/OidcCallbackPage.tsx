@@ -0,0 +1,73 @@
+import React, { useEffect, useRef, useState } from 'react';
+import { Card, Result, Button, Spin } from 'antd';
+import { useNavigate } from 'react-router-dom';
+import { authService } from '../../services/authService';
+
+// 单点登录回调页面：身份提供方登录后跳转到这里，使用授权码完成登录
+const OidcCallbackPage: React.FC = () => {
+  const navigate = useNavigate();
+  const [error, setError] = useState<string | null>(null);
+  // 授权码只能使用一次，避免 StrictMode 下重复提交
+  const submitted = useRef(false);
+
+  useEffect(() => {
+    if (submitted.current) {
+      return;
+    }
+    submitted.current = true;
+
+    const params = new URLSearchParams(window.location.search);
+    const code = params.get('code');
+    const state = params.get('state');
+
+    // 身份提供方返回错误，如用户取消授权
+    if (params.get('error')) {
+      setError(params.get('error_description') || params.get('error'));
+      return;
+    }
+    if (!code || !state) {
+      setError('缺少授权码');
+      return;
+    }
+
+    authService.loginOidc(code, state)
+      .then(result => {
+        if (result.code === 0 && result.data?.mfaRequired) {
+          // 需要两步验证，回到登录页输入验证码
+          navigate('/auth/login', { replace: true, state: { mfa: result.data } });
+        } else if (result.code === 0) {
+          window.location.href = '/';
+        } else {
+          setError(result.message || '单点登录失败');
+        }
+      })
+      .catch(() => setError('单点登录失败'));
+  }, []);
+
+  return (
+    <div style={{
+      minHeight: '100vh',
+      display: 'flex',
+      alignItems: 'center',
+      justifyContent: 'center',
+      backgroundColor: '#f0f2f5',
+    }}>
+      <Card style={{ width: 400, borderRadius: 8 }}>
+        {error ? (
+          <Result
+            status="error"
+            title="单点登录失败"
+            subTitle={error}
+            extra={<Button type="primary" onClick={() => { window.location.href = '/auth/login'; }}>返回登录</Button>}
+          />
+        ) : (
+          <div style={{ textAlign: 'center', padding: 24 }}>
+            <Spin tip="正在登录..." />
+          </div>
+        )}
+      </Card>
+    </div>
+  );
+};
+
+export default OidcCallbackPage;
@@ -0,0 +1,127 @@
+import React, { useState } from 'react';
+import { Card, Form, Input, Button, Result, Typography } from 'antd';
+import { UserOutlined, LockOutlined } from '@ant-design/icons';
+import { authService } from '../../services/authService';
+
+// 找回密码页面：没有令牌时填写账号申请重置链接，通过邮件中的链接进入时设置新密码
+const ResetPasswordPage: React.FC = () => {
+  const token = new URLSearchParams(window.location.search).get('token');
+  const [loading, setLoading] = useState(false);
+  const [done, setDone] = useState(false);
+  const [form] = Form.useForm();
+
+  // 申请重置链接
+  const handleForgot = async (values: { account: string }) => {
+    setLoading(true);
+    try {
+      const result = await authService.forgotPassword(values.account);
+      if (result.code === 0) {
+        setDone(true);
+      }
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  // 设置新密码
+  const handleReset = async (values: { password: string }) => {
+    setLoading(true);
+    try {
+      const result = await authService.confirmPasswordReset(token || '', values.password);
+      if (result.code === 0) {
+        setDone(true);
+      }
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const backToLogin = (
+    <Button type="primary" onClick={() => { window.location.href = '/auth/login'; }}>返回登录</Button>
+  );
+
+  const renderContent = () => {
+    if (done && token) {
+      return <Result status="success" title="密码已重置" subTitle="请使用新密码重新登录，其他设备上的登录已全部退出" extra={backToLogin} />;
+    }
+    if (done) {
+      return <Result status="success" title="请查收邮件" subTitle="如果账号存在且绑定了邮箱，重置链接已发送到该邮箱，请在有效期内打开链接设置新密码" extra={backToLogin} />;
+    }
+
+    if (token) {
+      return (
+        <Form form={form} layout="vertical" onFinish={handleReset}>
+          <Form.Item
+            name="password"
+            label="新密码"
+            rules={[{ required: true, message: '请输入新密码' }]}
+          >
+            <Input.Password prefix={<LockOutlined />} placeholder="请输入新密码" autoComplete="new-password" />
+          </Form.Item>
+          <Form.Item
+            name="confirm"
+            label="确认新密码"
+            dependencies={['password']}
+            rules={[
+              { required: true, message: '请再次输入新密码' },
+              ({ getFieldValue }) => ({
+                validator(_, value) {
+                  if (!value || getFieldValue('password') === value) {
+                    return Promise.resolve();
+                  }
+                  return Promise.reject(new Error('两次输入的密码不一致'));
+                },
+              }),
+            ]}
+          >
+            <Input.Password prefix={<LockOutlined />} placeholder="请再次输入新密码" autoComplete="new-password" />
+          </Form.Item>
+          <Form.Item>
+            <Button type="primary" htmlType="submit" style={{ width: '100%' }} loading={loading}>
+              设置新密码
+            </Button>
+          </Form.Item>
+        </Form>
+      );
+    }
+
+    return (
+      <Form form={form} layout="vertical" onFinish={handleForgot}>
+        <Form.Item
+          name="account"
+          label="用户名或邮箱"
+          rules={[
+            { required: true, message: '请输入用户名或邮箱' },
+            { max: 100, message: '长度不能超过100个字符' },
+          ]}
+        >
+          <Input prefix={<UserOutlined />} placeholder="请输入用户名或邮箱" autoComplete="username" />
+        </Form.Item>
+        <Form.Item>
+          <Button type="primary" htmlType="submit" style={{ width: '100%' }} loading={loading}>
+            发送重置链接
+          </Button>
+        </Form.Item>
+        <div style={{ textAlign: 'right' }}>
+          <Typography.Link href="/auth/login">返回登录</Typography.Link>
+        </div>
+      </Form>
+    );
+  };
+
+  return (
+    <div style={{
+      minHeight: '100vh',
+      display: 'flex',
+      alignItems: 'center',
+      justifyContent: 'center',
+      backgroundColor: '#f0f2f5',
+    }}>
+      <Card title={token ? '设置新密码' : '找回密码'} style={{ width: 400, borderRadius: 8 }}>
+        {renderContent()}
+      </Card>
+    </div>
+  );
+};
+
+export default ResetPasswordPage;
@@ -2,7 +2,7 @@ import React, { useEffect } from 'react';
 import { Modal, Form, Input, InputNumber, Select, Switch, TreeSelect, Button, Row, Col } from 'antd';
 import { apiService } from '../../../services/apiService';
 import { message } from 'antd';
-import { ApiData, validateHttpMethod, transformToTreeData } from '../../../utils/api/ApiUtils';
+import { ApiData, validateHttpMethod, transformToTreeData, authTypeOptions } from '../../../utils/api/ApiUtils';
 
 interface ApiEditProps {
   visible: boolean;
@@ -20,6 +20,7 @@ const ApiEdit: React.FC<ApiEditProps> = ({
   onSuccess
 }) => {
   const [form] = Form.useForm();
+  const isMenu = Form.useWatch('isMenu', form);
 
   // 当编辑记录变化时，更新表单值
   useEffect(() => {
@@ -34,6 +35,10 @@ const ApiEdit: React.FC<ApiEditProps> = ({
           sort: editingRecord.sort,
           status: editingRecord.status === 1,
           isMenu: editingRecord.isMenu === 1,
+          authType: editingRecord.authType || 0,
+          icon: editingRecord.icon,
+          route: editingRecord.route,
+          component: editingRecord.component,
           description: editingRecord.description
         });
       } else {
@@ -161,7 +166,7 @@ const ApiEdit: React.FC<ApiEditProps> = ({
                 { max: 200, message: '接口URL长度不能超过200个字符' }
               ]}
             >
-              <Input placeholder="请输入接口URL，支持通配符" />
+              <Input placeholder="如 /sys/user/detail/:id，支持 :id、{id}、* 和末尾的 /**" />
             </Form.Item>
           </Col>
           <Col span={12}>
@@ -181,6 +186,7 @@ const ApiEdit: React.FC<ApiEditProps> = ({
                 <Select.Option value="PATCH">PATCH</Select.Option>
                 <Select.Option value="OPTIONS">OPTIONS</Select.Option>
                 <Select.Option value="HEAD">HEAD</Select.Option>
+                <Select.Option value="ANY">ANY（所有方法）</Select.Option>
               </Select>
             </Form.Item>
           </Col>
@@ -210,7 +216,7 @@ const ApiEdit: React.FC<ApiEditProps> = ({
               />
             </Form.Item>
           </Col>
-          <Col span={12}>
+          <Col span={6}>
             <Form.Item
               name="isMenu"
               label="是否为菜单"
@@ -224,8 +230,51 @@ const ApiEdit: React.FC<ApiEditProps> = ({
               />
             </Form.Item>
           </Col>
+          <Col span={6}>
+            <Form.Item
+              name="authType"
+              label="鉴权方式"
+              initialValue={0}
+              tooltip="只需要登录和不需要鉴权的接口保存后立即生效，auth.yaml 中的默认配置始终生效"
+            >
+              <Select options={authTypeOptions} />
+            </Form.Item>
+          </Col>
         </Row>
 
+        {/* 菜单的图标、路由和组件，用于生成侧边栏菜单 */}
+        {isMenu ? (
+          <Row gutter={16}>
+            <Col span={6}>
+              <Form.Item
+                name="icon"
+                label="菜单图标"
+                rules={[{ max: 50, message: '菜单图标长度不能超过50个字符' }]}
+              >
+                <Input placeholder="如：UserOutlined" />
+              </Form.Item>
+            </Col>
+            <Col span={9}>
+              <Form.Item
+                name="route"
+                label="菜单路由"
+                rules={[{ max: 200, message: '菜单路由长度不能超过200个字符' }]}
+              >
+                <Input placeholder="如：/permission/user，目录可为空" />
+              </Form.Item>
+            </Col>
+            <Col span={9}>
+              <Form.Item
+                name="component"
+                label="菜单组件"
+                rules={[{ max: 200, message: '菜单组件长度不能超过200个字符' }]}
+              >
+                <Input placeholder="如：permission/user/index" />
+              </Form.Item>
+            </Col>
+          </Row>
+        ) : null}
+
         <Form.Item
           name="description"
           label="描述"
@@ -1,7 +1,7 @@
 import React, { useState, useEffect, useRef } from 'react';
-import { Layout, Table, Button, Space, Popconfirm, Card, Row, Col } from 'antd';
+import { Layout, Table, Button, Space, Popconfirm, Card, Row, Col, Modal } from 'antd';
 import type { ColumnsType } from 'antd/es/table';
-import { PlusOutlined } from '@ant-design/icons';
+import { PlusOutlined, SyncOutlined, ReloadOutlined } from '@ant-design/icons';
 import { PermissionAction } from '../../../utils/permission';
 import { apiService } from '../../../services/apiService';
 import ApiEdit from './edit';
@@ -11,7 +11,8 @@ import {
   getAllApiKeys,
   getMethodStyle,
   getStatusDisplay,
-  getIsMenuDisplay
+  getIsMenuDisplay,
+  getAuthTypeDisplay
 } from '../../../utils/api/ApiUtils';
 
 const { Content } = Layout;
@@ -76,6 +77,41 @@ const ApiList: React.FC = () => {
     }
   };
 
+  // 先比较，确认后再同步
+  const handleSync = async () => {
+    const preview = await apiService.syncApis(true);
+    if (preview.code !== 0 || !preview.data) {
+      return;
+    }
+    const { created, orphans } = preview.data;
+    Modal.confirm({
+      title: '同步路由',
+      width: 640,
+      content: (
+        <div>
+          <p>将新增 {created.length} 个API，新增的API需要再分配给角色。</p>
+          {created.map((item) => (
+            <div key={`${item.method} ${item.url}`}>{item.method} {item.url} → {item.permissionCode}</div>
+          ))}
+          {orphans.length > 0 && <p style={{ marginTop: 12 }}>以下 {orphans.length} 个API没有对应的路由，不会修改：</p>}
+          {orphans.map((item) => (
+            <div key={item.id}>{item.method} {item.url} ({item.name})</div>
+          ))}
+        </div>
+      ),
+      okButtonProps: { disabled: created.length === 0 },
+      onOk: async () => {
+        await apiService.syncApis(false);
+        fetchApiTree();
+      }
+    });
+  };
+
+  // 直接修改数据库后重新加载鉴权缓存
+  const handleReload = async () => {
+    await apiService.reloadApis();
+  };
+
   const fetchApiTree = async () => {
     setLoading(true);
     try {
@@ -139,6 +175,15 @@ const ApiList: React.FC = () => {
         <span>{getIsMenuDisplay(isMenu)}</span>
       ),
     },
+    {
+      title: '鉴权方式',
+      dataIndex: 'authType',
+      key: 'authType',
+      width: '8%',
+      render: (authType: number) => (
+        <span>{getAuthTypeDisplay(authType)}</span>
+      ),
+    },
     {
       title: '状态',
       dataIndex: 'status',
@@ -163,7 +208,7 @@ const ApiList: React.FC = () => {
       title: '描述',
       dataIndex: 'description',
       key: 'description',
-      width: '25%',
+      width: '17%',
     },
     {
       title: '操作',
@@ -220,6 +265,12 @@ const ApiList: React.FC = () => {
                     >
                       新增API
                     </Button>
+                    <Button icon={<SyncOutlined />} onClick={handleSync}>
+                      同步路由
+                    </Button>
+                    <Button icon={<ReloadOutlined />} onClick={handleReload}>
+                      重新加载
+                    </Button>
                   </Space>
                 </Col>
               </PermissionAction>
@@ -1,7 +1,8 @@
 import React, { useState, useEffect } from 'react';
 import { Modal, Form, Input, InputNumber, Select, Switch, TreeSelect, message, Button, Space, Row, Col } from 'antd';
-import { roleService, RoleCreateReq } from '../../../services/roleService.ts';
+import { roleService, RoleCreateReq, RoleInheritedApis } from '../../../services/roleService.ts';
 import { apiService } from '../../../services/apiService.ts';
+import { departmentService } from '../../../services/departmentService.ts';
 import { RoleData, convertApiIdsToObjects, convertApiIdsToValues, transformApiData } from '../../../utils/role/RoleUtils.tsx';
 
 const { TextArea } = Input;
@@ -21,12 +22,21 @@ const RoleEdit: React.FC<RoleEditProps> = ({
 }) => {
   const [formInstance] = Form.useForm();
   const [apiTreeData, setApiTreeData] = useState<any[]>([]);
+  const [departmentTreeData, setDepartmentTreeData] = useState<any[]>([]);
+  const [roleOptions, setRoleOptions] = useState<{ label: string; value: number }[]>([]);
+  const [inheritedApis, setInheritedApis] = useState<RoleInheritedApis[]>([]);
+  const dataScope = Form.useWatch('dataScope', formInstance);
+  // 超级管理员角色不能禁用，数据权限范围只能是全部
+  const isSuper = !!editingRecord?.isSuper;
   const [loading, setLoading] = useState(false);
 
   // 当编辑记录变化时，加载API树数据和角色详情
   useEffect(() => {
     if (visible) {
       fetchApiTree();
+      fetchDepartmentTree();
+      fetchRoleOptions();
+      setInheritedApis([]);
       if (editingRecord) {
         loadRoleDetail();
       } else {
@@ -53,6 +63,37 @@ const RoleEdit: React.FC<RoleEditProps> = ({
     }
   };
 
+  // 获取部门树形数据，用于自定义数据权限范围
+  const fetchDepartmentTree = async () => {
+    try {
+      const response = await departmentService.getDepartmentTree();
+      if (response.code === 0 && response.data && response.data.list) {
+        const transform = (list: any[]): any[] => list.map(item => ({
+          title: item.name,
+          value: item.id,
+          children: item.children ? transform(item.children) : undefined
+        }));
+        setDepartmentTreeData(transform(response.data.list));
+      }
+    } catch (error) {
+      message.error('获取部门数据失败');
+    }
+  };
+
+  // 获取上级角色选项，不能选择自己
+  const fetchRoleOptions = async () => {
+    try {
+      const response = await roleService.getRoleList({ page: 1, size: 100 });
+      if (response.code === 0 && response.data) {
+        setRoleOptions(response.data.list
+          .filter(role => role.id !== editingRecord?.id)
+          .map(role => ({ label: role.name, value: role.id })));
+      }
+    } catch (error) {
+      message.error('获取角色数据失败');
+    }
+  };
+
   // 获取角色详情
   const loadRoleDetail = async () => {
     if (!editingRecord) return;
@@ -66,13 +107,16 @@ const RoleEdit: React.FC<RoleEditProps> = ({
         // 将apiIds数组转换为{value: number}格式的对象数组
         const apiIdsWithValue = convertApiIdsToObjects(roleDetail.apiIds);
 
+        setInheritedApis(roleDetail.inheritedApis || []);
         formInstance.setFieldsValue({
+          parentId: roleDetail.parentId || undefined,
           name: roleDetail.name,
           description: roleDetail.description,
           dataScope: roleDetail.dataScope,
           sort: roleDetail.sort,
           status: roleDetail.status,
-          apiIds: apiIdsWithValue
+          apiIds: apiIdsWithValue,
+          departmentIds: roleDetail.departmentIds || []
         });
       } else {
         // 如果详情接口失败，使用列表中的旧数据
@@ -101,12 +145,14 @@ const RoleEdit: React.FC<RoleEditProps> = ({
       const apiIdsArray = convertApiIdsToValues(values.apiIds);
 
       const requestData: RoleCreateReq = {
+        parentId: values.parentId || 0,
         name: values.name,
         description: values.description,
         dataScope: values.dataScope,
         sort: values.sort,
         status: values.status,
-        apiIds: apiIdsArray
+        apiIds: apiIdsArray,
+        departmentIds: values.dataScope === 5 ? values.departmentIds || [] : []
       };
 
       if (editingRecord) {
@@ -167,7 +213,7 @@ const RoleEdit: React.FC<RoleEditProps> = ({
               label="数据权限范围"
               rules={[{ required: true, message: '数据权限范围不能为空' }]}
             >
-              <Select placeholder="请选择数据权限范围">
+              <Select placeholder="请选择数据权限范围" disabled={isSuper}>
                 <Select.Option value={1}>全部</Select.Option>
                 <Select.Option value={2}>本部门</Select.Option>
                 <Select.Option value={3}>本部门及子部门</Select.Option>
@@ -178,6 +224,48 @@ const RoleEdit: React.FC<RoleEditProps> = ({
           </Col>
         </Row>
 
+        {dataScope === 5 && (
+          <Row gutter={24}>
+            <Col span={24}>
+              <Form.Item
+                name="departmentIds"
+                label="自定义部门"
+                rules={[{ required: true, message: '自定义数据权限范围需要选择部门' }]}
+              >
+                <TreeSelect
+                  treeData={departmentTreeData}
+                  placeholder="请选择可以访问数据的部门"
+                  style={{ width: '100%' }}
+                  multiple
+                  treeCheckable
+                  showCheckedStrategy={TreeSelect.SHOW_ALL}
+                  treeCheckStrictly={false}
+                  treeDefaultExpandAll
+                  dropdownStyle={{ maxHeight: 400, overflow: 'auto' }}
+                />
+              </Form.Item>
+            </Col>
+          </Row>
+        )}
+
+        <Row gutter={24}>
+          <Col span={24}>
+            <Form.Item
+              name="parentId"
+              label="上级角色"
+              extra="角色继承上级角色及其上级的接口权限"
+            >
+              <Select
+                placeholder="不继承其他角色"
+                options={roleOptions}
+                allowClear
+                showSearch
+                optionFilterProp="label"
+              />
+            </Form.Item>
+          </Col>
+        </Row>
+
         <Row gutter={24}>
           <Col span={12}>
             <Form.Item
@@ -195,7 +283,7 @@ const RoleEdit: React.FC<RoleEditProps> = ({
               valuePropName="checked"
               initialValue={true}
             >
-              <Switch checkedChildren="启用" unCheckedChildren="禁用" />
+              <Switch checkedChildren="启用" unCheckedChildren="禁用" disabled={isSuper} />
             </Form.Item>
           </Col>
         </Row>
@@ -216,7 +304,8 @@ const RoleEdit: React.FC<RoleEditProps> = ({
           <Col span={24}>
             <Form.Item
               name="apiIds"
-              label="API权限"
+              label="直接分配的API权限"
+              extra={isSuper ? '超级管理员拥有所有接口权限，不需要分配' : undefined}
             >
           <TreeSelect
               treeData={apiTreeData}
@@ -233,6 +322,27 @@ const RoleEdit: React.FC<RoleEditProps> = ({
           </Form.Item>
           </Col>
         </Row>
+
+        {inheritedApis.map(item => (
+          <Row gutter={24} key={item.roleId}>
+            <Col span={24}>
+              <Form.Item label={`继承自「${item.roleName}」的API权限`}>
+                <TreeSelect
+                  treeData={apiTreeData}
+                  value={convertApiIdsToObjects(item.apiIds || [])}
+                  placeholder="没有API权限"
+                  style={{ width: '100%' }}
+                  multiple
+                  treeCheckable
+                  showCheckedStrategy={TreeSelect.SHOW_ALL}
+                  treeCheckStrictly={true}
+                  fieldNames={{ label: 'title', value: 'value', children: 'children' }}
+                  disabled
+                />
+              </Form.Item>
+            </Col>
+          </Row>
+        ))}
           
           <Form.Item style={{ textAlign: 'right', marginTop: 24 }}>
             <Space>
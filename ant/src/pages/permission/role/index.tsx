@@ -1,5 +1,5 @@
 import React, { useState, useEffect, useRef } from 'react';
-import { Table, Button, Space, Popconfirm, Input, Select, Card, Row, Col, Layout } from 'antd';
+import { Table, Button, Space, Popconfirm, Input, Select, Card, Row, Col, Layout, Tag } from 'antd';
 import type { ColumnsType } from 'antd/es/table';
 import { roleService } from '../../../services/roleService.ts';
 import { PermissionAction } from '../../../utils/permission.tsx';
@@ -38,6 +38,12 @@ const RoleList: React.FC = () => {
       dataIndex: 'name',
       key: 'name',
       width: '15%',
+      render: (name: string, record) => (
+        <Space>
+          {name}
+          {record.isSuper && <Tag color="gold">超级管理员</Tag>}
+        </Space>
+      )
     },
     {
       title: '描述',
@@ -89,6 +95,7 @@ const RoleList: React.FC = () => {
               编辑
             </Button>
           </PermissionAction>
+          {!record.isSuper && (
           <PermissionAction permission="sys.role.delete">
             <Popconfirm
               title={`是否删除角色"${record.name}"？`}
@@ -101,6 +108,7 @@ const RoleList: React.FC = () => {
               </Button>
             </Popconfirm>
           </PermissionAction>
+          )}
         </Space>
       ),
     },
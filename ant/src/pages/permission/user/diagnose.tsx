@@ -0,0 +1,131 @@
+import React, { useEffect, useState } from 'react';
+import { Modal, Form, Input, Select, Button, Descriptions, Table, Tag, Alert, Space } from 'antd';
+import { userService, DiagnosePermissionResponse, DiagnosePermissionRole } from '../../../services/userService.ts';
+
+interface UserDiagnoseProps {
+  visible: boolean;
+  user?: { id: number; username: string };
+  onClose: () => void;
+}
+
+// 路由的鉴权方式
+const routeTypeMap: Record<string, string> = {
+  ignore: '不需要鉴权',
+  public: '只需要登录',
+  permission: '需要接口权限'
+};
+
+// 权限诊断：检查用户能否访问接口及原因
+const UserDiagnose: React.FC<UserDiagnoseProps> = ({ visible, user, onClose }) => {
+  const [form] = Form.useForm();
+  const [loading, setLoading] = useState(false);
+  const [result, setResult] = useState<DiagnosePermissionResponse | null>(null);
+
+  useEffect(() => {
+    if (visible) {
+      form.resetFields();
+      setResult(null);
+    }
+  }, [visible, form]);
+
+  const handleSubmit = async (values: { method?: string; url?: string; permissionCode?: string }) => {
+    if (!user) {
+      return;
+    }
+    setLoading(true);
+    try {
+      const response = await userService.diagnosePermission(user.id, values);
+      if (response.code === 0 && response.data) {
+        setResult(response.data);
+      }
+    } catch (error) {
+      // 错误处理已在service中完成
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const roleColumns = [
+    { title: '角色', dataIndex: 'name', key: 'name' },
+    {
+      title: '状态',
+      dataIndex: 'status',
+      key: 'status',
+      render: (status: boolean) => status ? <Tag color="green">启用</Tag> : <Tag color="red">禁用</Tag>
+    },
+    {
+      title: '授予权限',
+      key: 'granted',
+      render: (_: any, role: DiagnosePermissionRole) => (
+        <Space>
+          {role.granted ? <Tag color="green">是</Tag> : <Tag>否</Tag>}
+          {role.isSuper && <Tag color="gold">超级管理员</Tag>}
+        </Space>
+      )
+    },
+    {
+      title: '分配接口的角色',
+      dataIndex: 'grantedBy',
+      key: 'grantedBy',
+      render: (grantedBy: string[]) => grantedBy && grantedBy.length > 0 ? grantedBy.join('、') : '-'
+    }
+  ];
+
+  return (
+    <Modal
+      title={`权限诊断 - ${user?.username || ''}`}
+      open={visible}
+      onCancel={onClose}
+      footer={null}
+      width={800}
+    >
+      <Form form={form} layout="inline" onFinish={handleSubmit} initialValues={{ method: 'GET' }}>
+        <Form.Item name="method">
+          <Select style={{ width: 100 }} options={['GET', 'POST', 'PUT', 'DELETE', 'PATCH'].map(m => ({ label: m, value: m }))} />
+        </Form.Item>
+        <Form.Item name="url">
+          <Input placeholder="请求路径，如：/sys/user/list" style={{ width: 260 }} />
+        </Form.Item>
+        <Form.Item name="permissionCode">
+          <Input placeholder="或权限标识" style={{ width: 180 }} />
+        </Form.Item>
+        <Form.Item>
+          <Button type="primary" htmlType="submit" loading={loading}>诊断</Button>
+        </Form.Item>
+      </Form>
+
+      {result && (
+        <div style={{ marginTop: 16 }}>
+          <Alert
+            type={result.allowed ? 'success' : 'error'}
+            message={result.allowed ? '允许访问' : '拒绝访问'}
+            description={result.reason}
+            showIcon
+            style={{ marginBottom: 16 }}
+          />
+          <Descriptions column={2} size="small" bordered>
+            <Descriptions.Item label="请求">{result.method} {result.url || '-'}</Descriptions.Item>
+            <Descriptions.Item label="鉴权方式">{routeTypeMap[result.routeType] || result.routeType}</Descriptions.Item>
+            <Descriptions.Item label="匹配的接口">
+              {result.api ? `${result.api.name}（${result.api.method} ${result.api.url}）` : '没有匹配的接口'}
+            </Descriptions.Item>
+            <Descriptions.Item label="权限标识">{result.permissionCode || '-'}</Descriptions.Item>
+            <Descriptions.Item label="用户状态" span={2}>
+              {result.user.error ? <Tag color="red">{result.user.error}</Tag> : <Tag color="green">正常</Tag>}
+            </Descriptions.Item>
+          </Descriptions>
+          <Table
+            style={{ marginTop: 16 }}
+            size="small"
+            rowKey="id"
+            columns={roleColumns}
+            dataSource={result.roles}
+            pagination={false}
+          />
+        </div>
+      )}
+    </Modal>
+  );
+};
+
+export default UserDiagnose;
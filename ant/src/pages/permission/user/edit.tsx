@@ -26,6 +26,7 @@ const UserEdit: React.FC<UserEditProps> = ({
   onSuccess,
 }) => {
   const [form] = Form.useForm();
+  const authSource = Form.useWatch('authSource', form);
 
   // 重置表单
   const resetForm = () => {
@@ -45,6 +46,7 @@ const UserEdit: React.FC<UserEditProps> = ({
           departmentId: currentUser.departmentId,
           roleIds: currentUser.roleIds || [],
           status: currentUser.status === 1,
+          authSource: currentUser.authSource || 'local',
         });
       }
     }
@@ -132,7 +134,18 @@ const UserEdit: React.FC<UserEditProps> = ({
           />
         </Form.Item>
 
-        {modalType === 'create' && (
+        <Form.Item
+          label="认证方式"
+          name="authSource"
+          tooltip="LDAP 用户使用目录服务的密码登录，不需要设置本地密码"
+        >
+          <Select>
+            <Option value="local">本地密码</Option>
+            <Option value="ldap">LDAP</Option>
+          </Select>
+        </Form.Item>
+
+        {modalType === 'create' && authSource !== 'ldap' && (
           <Form.Item
             label="密码"
             name="passwordHash"
@@ -220,6 +233,7 @@ const UserEdit: React.FC<UserEditProps> = ({
           layout="vertical"
           initialValues={{
             status: true,
+            authSource: 'local',
           }}
         >
           {renderFormItems()}
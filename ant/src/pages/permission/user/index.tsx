@@ -1,9 +1,10 @@
 import React, { useState, useEffect, useRef } from 'react';
 import { Table, Button, Space, Input, Select, Card, Row, Col, Layout, Popconfirm } from 'antd';
-import { PlusOutlined, EditOutlined, DeleteOutlined, KeyOutlined } from '@ant-design/icons';
+import { PlusOutlined, EditOutlined, DeleteOutlined, KeyOutlined, SafetyOutlined } from '@ant-design/icons';
 import { userService, UserData } from '../../../services/userService.ts';
 import { PermissionAction } from '../../../utils/permission.tsx';
 import UserEdit from './edit.tsx';
+import UserDiagnose from './diagnose.tsx';
 import { renderStatusTag, renderRoleTags, formatDateTime } from '../../../utils/user/UserUtils.tsx';
 
 const { Header, Content } = Layout;
@@ -29,6 +30,7 @@ const UserList: React.FC = () => {
   const [modalType, setModalType] = useState<'create' | 'edit'>('create');
   const [currentUser, setCurrentUser] = useState<UserData | null>(null);
   const [passwordModalVisible, setPasswordModalVisible] = useState(false);
+  const [diagnoseUser, setDiagnoseUser] = useState<{ id: number; username: string } | undefined>();
   const [selectedUser, setSelectedUser] = useState({ id: null as number | null, username: '' });
 
   // 使用ref来跟踪上一次的查询参数
@@ -208,6 +210,7 @@ const UserList: React.FC = () => {
               编辑
             </Button>
           </PermissionAction>
+          {record.authSource !== 'ldap' && (
           <PermissionAction permission="sys.user.update-password">
             <Button
               type="link"
@@ -217,6 +220,16 @@ const UserList: React.FC = () => {
               修改密码
             </Button>
           </PermissionAction>
+          )}
+          <PermissionAction permission="sys.user.diagnose-permission">
+            <Button
+              type="link"
+              icon={<SafetyOutlined />}
+              onClick={() => setDiagnoseUser({ id: record.id, username: record.username })}
+            >
+              权限诊断
+            </Button>
+          </PermissionAction>
           <PermissionAction permission="sys.user.delete">
             <Popconfirm
               title={
@@ -342,6 +355,13 @@ const UserList: React.FC = () => {
           onClose={() => setPasswordModalVisible(false)}
           onSuccess={handleSuccess}
         />
+
+        {/* 权限诊断模态框 */}
+        <UserDiagnose
+          visible={!!diagnoseUser}
+          user={diagnoseUser}
+          onClose={() => setDiagnoseUser(undefined)}
+        />
       </Layout>
     </div>
   );
@@ -9,6 +9,10 @@ export interface SysApiCreateReq {
   sort?: number;
   status: number;
   isMenu: number;
+  authType?: number;
+  icon?: string;
+  route?: string;
+  component?: string;
   description?: string;
 }
 
@@ -20,6 +24,14 @@ export interface ApiResponse<T = any> {
   data?: T;
 }
 
+// 同步路由结果
+export interface SysApiSyncResult {
+  created: SysApiCreateReq[];
+  orphans: (SysApiCreateReq & { id: number })[];
+  existing: number;
+  skipped: number;
+}
+
 export interface ApiTreeResponse {
   code: number;
   message: string;
@@ -104,6 +116,34 @@ export const apiService = {
     }
   },
 
+  /**
+   * 把服务中注册的路由同步到API
+   * @param dryRun 只比较不修改
+   * @param disableOrphans 禁用没有对应路由的API
+   */
+  async syncApis(dryRun: boolean, disableOrphans = false): Promise<ApiResponse<SysApiSyncResult>> {
+    return post<ApiResponse<SysApiSyncResult>>(
+      '/sys/api/sync',
+      { dryRun, disableOrphans },
+      {
+        operationName: dryRun ? '比较路由' : '同步路由'
+      }
+    );
+  },
+
+  /**
+   * 重新加载鉴权缓存，直接修改数据库后使用
+   */
+  async reloadApis(): Promise<ApiResponse> {
+    return post<ApiResponse>(
+      '/sys/api/reload',
+      {},
+      {
+        operationName: '重新加载鉴权缓存'
+      }
+    );
+  },
+
   async deleteApi(id: string): Promise<ApiResponse> {
     try {
       const result = await del<ApiResponse>(
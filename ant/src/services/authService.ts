@@ -21,11 +21,27 @@ export interface User {
   lastLoginIp: string;
   loginAttempts: number;
   lockedUntil: string | null;
+  totpEnabled: boolean;
   createdAt: string;
   updatedAt: string;
   deletedAt: string | null;
 }
 
+// 登录记录
+export interface LoginLog {
+  id: number;
+  userId: number;
+  username: string;
+  method: 'password' | 'mfa' | 'oidc' | 'impersonate';
+  status: number; // 0=失败 1=成功 2=待两步验证
+  reason: string;
+  message: string;
+  ip: string;
+  device: string;
+  userAgent: string;
+  createdAt: string;
+}
+
 // Role接口定义
 export interface Role {
   id: number;
@@ -34,6 +50,7 @@ export interface Role {
   dataScope: number;
   sort: number;
   status: boolean;
+  requireMfa: boolean;
   createdAt: string;
   updatedAt: string;
   deletedAt: string;
@@ -64,6 +81,54 @@ export interface LoginRes {
   apis: Api[];
   apiCodes: string[];
   token: string;
+  refreshToken: string;
+  expire: string;
+  refresh: string;
+  // 密码已过期，需要修改密码
+  mustChangePassword: boolean;
+  // 开启两步验证时只返回以下字段
+  mfaRequired: boolean;
+  mfaToken?: string;
+  mfaSetup?: MfaSetupRes;
+  recoveryCodes?: string[];
+}
+
+// 两步验证绑定信息
+export interface MfaSetupRes {
+  secret: string;
+  uri: string;
+}
+
+// 当前用户的菜单，由接口树中是否为菜单的节点组成
+export interface AuthMenu {
+  id: number;
+  parentId: number;
+  name: string;
+  permissionCode: string;
+  icon: string;
+  route: string;
+  component: string;
+  sort: number;
+  permissions: string[];
+  children?: AuthMenu[];
+}
+
+// 单点登录配置
+export interface OidcConfigRes {
+  enabled: boolean;
+  name: string;
+}
+
+export interface LoginMfaRequest {
+  mfaToken: string;
+  code?: string;
+  recoveryCode?: string;
+}
+
+// TokenRes接口定义
+export interface TokenRes {
+  token: string;
+  refreshToken: string;
   expire: string;
   refresh: string;
 }
@@ -103,18 +168,7 @@ export const authService = {
       
       // 如果登录成功，存储token
       if (result.code === 0 && result.data && result.data.token) {
-        // 缓存用户信息
-        localStorage.setItem('user', JSON.stringify(result.data.user));
-        // 缓存token
-        localStorage.setItem('token', result.data.token);
-        // 缓存过期时间
-        localStorage.setItem('expireTime', result.data.expire);
-        // 缓存刷新时间
-        localStorage.setItem('refreshTime', result.data.refresh);
-        // 缓存ApiCodes
-        localStorage.setItem('apiCodes', JSON.stringify(result.data.apiCodes));
-        // 缓存角色信息
-        localStorage.setItem('roles', JSON.stringify(result.data.roles));
+        this.saveLogin(result.data);
       }
 
       
@@ -124,6 +178,140 @@ export const authService = {
     }
   },
 
+  /**
+   * 两步验证登录
+   * @param data 登录挑战令牌和验证码
+   * @returns 登录响应数据
+   */
+  async loginMfa(data: LoginMfaRequest): Promise<ApiResponse<LoginRes>> {
+    const result = await post<ApiResponse<LoginRes>>(
+      '/auth/login/mfa',
+      data,
+      {
+        operationName: '两步验证',
+        needToken: false
+      }
+    );
+
+    if (result.code === 0 && result.data && result.data.token) {
+      this.saveLogin(result.data);
+    }
+
+    return result;
+  },
+
+  /**
+   * 单点登录配置，开启时登录页显示单点登录按钮
+   */
+  async getOidcConfig(): Promise<OidcConfigRes> {
+    const result = await get<ApiResponse<OidcConfigRes>>(
+      '/auth/oidc/config',
+      {},
+      {
+        operationName: '获取单点登录配置',
+        needToken: false,
+        processResponse: false
+      }
+    );
+
+    return result.code === 0 && result.data ? result.data : { enabled: false, name: '' };
+  },
+
+  /**
+   * 开始单点登录，返回身份提供方的授权地址
+   */
+  async oidcAuthorize(): Promise<ApiResponse<{ url: string }>> {
+    return get<ApiResponse<{ url: string }>>(
+      '/auth/oidc/authorize',
+      {},
+      {
+        operationName: '单点登录',
+        needToken: false
+      }
+    );
+  },
+
+  /**
+   * 单点登录回调，使用授权码登录
+   * @param code 授权码
+   * @param state 登录流程标识
+   */
+  async loginOidc(code: string, state: string): Promise<ApiResponse<LoginRes>> {
+    const result = await post<ApiResponse<LoginRes>>(
+      '/auth/oidc/callback',
+      { code, state },
+      {
+        operationName: '单点登录',
+        needToken: false
+      }
+    );
+
+    if (result.code === 0 && result.data && result.data.token) {
+      this.saveLogin(result.data);
+    }
+
+    return result;
+  },
+
+  /**
+   * 缓存登录信息
+   * @param data 登录响应数据
+   */
+  saveLogin(data: LoginRes) {
+    // 缓存用户信息
+    localStorage.setItem('user', JSON.stringify(data.user));
+    // 缓存token
+    localStorage.setItem('token', data.token);
+    // 缓存过期时间
+    localStorage.setItem('expireTime', data.expire);
+    // 缓存刷新时间
+    localStorage.setItem('refreshTime', data.refresh);
+    // 缓存刷新令牌
+    localStorage.setItem('refreshToken', data.refreshToken);
+    // 缓存ApiCodes
+    localStorage.setItem('apiCodes', JSON.stringify(data.apiCodes));
+    // 缓存角色信息
+    localStorage.setItem('roles', JSON.stringify(data.roles));
+    // 密码过期标记，进入系统后提示修改密码
+    if (data.mustChangePassword) {
+      localStorage.setItem('mustChangePassword', '1');
+    } else {
+      localStorage.removeItem('mustChangePassword');
+    }
+  },
+
+  /**
+   * 刷新令牌
+   * 刷新令牌只能使用一次，成功后会返回新的刷新令牌
+   * @returns 是否刷新成功
+   */
+  async refreshToken(): Promise<boolean> {
+    const refreshToken = localStorage.getItem('refreshToken');
+    if (!refreshToken) {
+      return false;
+    }
+
+    const result = await post<ApiResponse<TokenRes>>(
+      '/auth/refresh',
+      { refreshToken },
+      {
+        operationName: '刷新令牌',
+        needToken: false,
+        processResponse: false
+      }
+    );
+
+    if (result.code === 0 && result.data && result.data.token) {
+      localStorage.setItem('token', result.data.token);
+      localStorage.setItem('refreshToken', result.data.refreshToken);
+      localStorage.setItem('expireTime', result.data.expire);
+      localStorage.setItem('refreshTime', result.data.refresh);
+      return true;
+    }
+
+    return false;
+  },
+
   /**
    * 获取验证码
    * @param width 验证码图片宽度
@@ -158,9 +346,24 @@ export const authService = {
 
   /**
    * 用户登出
+   * 通知服务端吊销当前令牌，失败时也清除本地登录状态
    */
-  logout(): void {
+  async logout(): Promise<void> {
+    try {
+      await post<ApiResponse>(
+        '/auth/logout',
+        {},
+        {
+          operationName: '退出登录',
+          needToken: true,
+          processResponse: false
+        }
+      );
+    } catch (error) {
+      console.error('退出登录失败:', error);
+    }
     localStorage.removeItem('token');
+    localStorage.removeItem('refreshToken');
     localStorage.removeItem('userInfo');
     message.success('已成功登出');
   },
@@ -192,14 +395,15 @@ export const authService = {
 
   /**
    * 重置密码
+   * @param oldPassword 原密码
    * @param password 新密码
    * @returns 重置密码响应
    */
-  async resetPassword(password: string): Promise<ApiResponse> {
+  async resetPassword(oldPassword: string, password: string): Promise<ApiResponse> {
     try {
       const result = await post<ApiResponse>(
         '/auth/reset-password',
-        { password },
+        { oldPassword, password },
         {
           operationName: '重置密码',
           needToken: true
@@ -211,13 +415,98 @@ export const authService = {
     }
   },
 
+  /**
+   * 找回密码，向账号绑定的邮箱发送重置链接
+   * @param account 用户名或邮箱
+   */
+  async forgotPassword(account: string): Promise<ApiResponse> {
+    return post<ApiResponse>(
+      '/auth/password/forgot',
+      { account },
+      {
+        operationName: '找回密码',
+        needToken: false
+      }
+    );
+  },
+
+  /**
+   * 通过重置链接设置新密码
+   * @param token 邮件链接中的重置令牌
+   * @param password 新密码
+   */
+  async confirmPasswordReset(token: string, password: string): Promise<ApiResponse> {
+    return post<ApiResponse>(
+      '/auth/password/reset',
+      { token, password },
+      {
+        operationName: '设置新密码',
+        needToken: false
+      }
+    );
+  },
+
+  /**
+   * 代登录，保存自己的登录信息后切换为该用户
+   * @param userId 被代登录的用户ID
+   * @param reason 代登录原因
+   */
+  async impersonate(userId: number, reason?: string): Promise<ApiResponse<LoginRes>> {
+    const result = await post<ApiResponse<LoginRes>>(
+      `/sys/user/impersonate/${userId}`,
+      { reason },
+      {
+        operationName: '代登录',
+        needToken: true
+      }
+    );
+    if (result.code === 0 && result.data) {
+      const keys = ['user', 'token', 'expireTime', 'refreshTime', 'refreshToken', 'apiCodes', 'roles'];
+      const saved: Record<string, string | null> = {};
+      keys.forEach((key) => (saved[key] = localStorage.getItem(key)));
+      localStorage.setItem('impersonator', JSON.stringify(saved));
+      this.saveLogin(result.data);
+    }
+    return result;
+  },
+
+  /**
+   * 结束代登录，恢复自己的登录信息
+   */
+  async endImpersonation(): Promise<void> {
+    try {
+      await post<ApiResponse>(
+        '/auth/impersonation/end',
+        {},
+        {
+          operationName: '结束代登录',
+          needToken: true,
+          processResponse: false
+        }
+      );
+    } catch (error) {
+      console.error('结束代登录失败:', error);
+    }
+    const saved = localStorage.getItem('impersonator');
+    if (saved) {
+      Object.entries(JSON.parse(saved) as Record<string, string | null>).forEach(([key, value]) => {
+        if (value === null) {
+          localStorage.removeItem(key);
+        } else {
+          localStorage.setItem(key, value);
+        }
+      });
+      localStorage.removeItem('impersonator');
+    }
+  },
+
   /**
    * 获取个人中心信息
    * @returns 个人中心信息
    */
-  async getProfile(): Promise<ApiResponse<{ user: User }>> {
+  async getProfile(): Promise<ApiResponse<{ user: User; recentLogins: LoginLog[]; impersonator?: { userId: number; username: string } }>> {
     try {
-      const result = await get<ApiResponse<{ user: User }>>(
+      const result = await get<ApiResponse<{ user: User; recentLogins: LoginLog[]; impersonator?: { userId: number; username: string } }>>(
         '/auth/profile',
         {},
         {
@@ -230,4 +519,24 @@ export const authService = {
       throw error;
     }
   },
+
+  /**
+   * 获取当前用户的菜单树
+   * @returns 菜单树
+   */
+  async getMenus(): Promise<ApiResponse<{ list: AuthMenu[] }>> {
+    try {
+      const result = await get<ApiResponse<{ list: AuthMenu[] }>>(
+        '/auth/menus',
+        {},
+        {
+          operationName: '获取菜单',
+          needToken: true
+        }
+      );
+      return result;
+    } catch (error) {
+      throw error;
+    }
+  },
 };
\ No newline at end of file
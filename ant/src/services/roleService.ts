@@ -1,12 +1,14 @@
 import { get, post, put, del } from '../utils/request';
 
 export interface RoleCreateReq {
+  parentId?: number;
   name: string;
   description?: string;
   dataScope: number;
   sort: number;
   status: boolean;
   apiIds: number[];
+  departmentIds?: number[];
 }
 
 export interface RoleUpdateReq extends RoleCreateReq {
@@ -31,14 +33,24 @@ export interface RoleListResponse {
   total: number;
 }
 
+export interface RoleInheritedApis {
+  roleId: number;
+  roleName: string;
+  apiIds: number[];
+}
+
 export interface RoleDetailResponse {
   id: number;
+  parentId?: number;
   name: string;
   description: string;
   dataScope: number;
   sort: number;
   status: boolean;
+  isSuper?: boolean;
   apiIds: number[];
+  departmentIds?: number[];
+  inheritedApis?: RoleInheritedApis[];
   apiCount: number;
   createdAt: string;
   updatedAt: string;
@@ -2,12 +2,13 @@ import { post, get, put, del, RequestOptions } from '../utils/request';
 
 export interface UserCreateReq {
   username: string;
-  passwordHash: string;
+  passwordHash?: string;
   email: string;
   mobile?: string;
   departmentId: number;
   status: number;
   roleIds: number[];
+  authSource?: 'local' | 'ldap';
 }
 
 export interface UserUpdateReq extends UserCreateReq {
@@ -22,6 +23,7 @@ export interface UserData {
   departmentId: number;
   status: number;
   roleIds: number[];
+  authSource?: 'local' | 'ldap';
   createdAt: string;
   updatedAt: string;
   departmentName?: string;
@@ -57,6 +59,34 @@ export interface UserUpdatePasswordReq {
   password: string;
 }
 
+// 权限诊断
+export interface DiagnosePermissionReq {
+  method?: string;
+  url?: string;
+  permissionCode?: string;
+}
+
+export interface DiagnosePermissionRole {
+  id: number;
+  name: string;
+  status: boolean;
+  isSuper: boolean;
+  granted: boolean;
+  grantedBy: string[];
+}
+
+export interface DiagnosePermissionResponse {
+  method: string;
+  url: string;
+  routeType: 'ignore' | 'public' | 'permission';
+  api?: { id: number; name: string; permissionCode: string; method: string; url: string; status: number };
+  permissionCode: string;
+  user: { id: number; username: string; status: number; error: string };
+  roles: DiagnosePermissionRole[];
+  allowed: boolean;
+  reason: string;
+}
+
 export const userService = {
   async createUser(data: UserCreateReq, options?: Omit<RequestOptions, 'url' | 'method' | 'data'>): Promise<ApiResponse> {
     try {
@@ -162,6 +192,23 @@ export const userService = {
       );
 
       
+      return result;
+    } catch (error) {
+      throw error;
+    }
+  },
+
+  async diagnosePermission(id: number, params: DiagnosePermissionReq, options?: Omit<RequestOptions, 'url' | 'method' | 'data'>): Promise<ApiResponse<DiagnosePermissionResponse>> {
+    try {
+      const result = await get<ApiResponse<DiagnosePermissionResponse>>(
+        `/sys/user/diagnose-permission/${id}`,
+        params,
+        {
+          operationName: '权限诊断',
+          ...options
+        }
+      );
+
       return result;
     } catch (error) {
       throw error;
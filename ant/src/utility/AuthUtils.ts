@@ -4,8 +4,10 @@ export const clearAllCache = (): void => {
   localStorage.removeItem('user');
   localStorage.removeItem('expireTime');
   localStorage.removeItem('refreshTime');
+  localStorage.removeItem('refreshToken');
   localStorage.removeItem('apiCodes');
   localStorage.removeItem('roles');
+  localStorage.removeItem('mustChangePassword');
 };
 
 // 获取用户信息
@@ -11,6 +11,10 @@ export interface ApiData {
   method: string;
   permissionCode: string;
   isMenu: number;
+  authType?: number;
+  icon?: string;
+  route?: string;
+  component?: string;
   status: number;
   sort: number;
   description: string;
@@ -29,6 +33,10 @@ export interface ApiRequestData {
   sort?: number;
   status: number;
   isMenu: number;
+  authType?: number;
+  icon?: string;
+  route?: string;
+  component?: string;
   description?: string;
 }
 
@@ -63,6 +71,10 @@ export const transformApiData = (
     method: item.method,
     permissionCode: item.permissionCode || '',
     isMenu: item.isMenu || 0,
+    authType: item.authType || 0,
+    icon: item.icon || '',
+    route: item.route || '',
+    component: item.component || '',
     status: item.status || 1,
     sort: item.sort || 0,
     description: item.description || '',
@@ -96,7 +108,7 @@ export const getAllApiKeys = (
 export const validateHttpMethod = (
   method: string
 ): { isValid: boolean; message: string } => {
-  const validMethods = ['GET', 'POST', 'PUT', 'DELETE', 'PATCH', 'OPTIONS', 'HEAD'];
+  const validMethods = ['GET', 'POST', 'PUT', 'DELETE', 'PATCH', 'OPTIONS', 'HEAD', 'ANY'];
   const isValid = validMethods.includes(method);
   
   return {
@@ -170,4 +182,24 @@ export const getIsMenuDisplay = (
   isMenu: number
 ): string => {
   return isMenu === 1 ? '是' : '否';
+};
+
+/**
+ * 鉴权方式选项，与 auth.yaml 中的 publicRoutes 和 ignoreRoutes 同时生效
+ */
+export const authTypeOptions = [
+  { label: '需要权限', value: 0 },
+  { label: '只需要登录', value: 1 },
+  { label: '不需要鉴权', value: 2 }
+];
+
+/**
+ * 根据鉴权方式获取显示文本
+ * @param authType 鉴权方式
+ * @returns 显示文本
+ */
+export const getAuthTypeDisplay = (
+  authType: number
+): string => {
+  return authTypeOptions.find(option => option.value === authType)?.label || '需要权限';
 };
\ No newline at end of file
@@ -5,11 +5,13 @@ import { Tag } from 'antd';
 export interface RoleData {
   key: React.Key;
   id: number;
+  parentId?: number;
   name: string;
   description: string;
   dataScope: number;
   sort: number;
   status: boolean;
+  isSuper?: boolean;
   apiIds: number[];
   apiCount: number;
   createdAt: string;
@@ -36,21 +36,47 @@ type IAdminCms interface {
 type IAdminV1 interface {
 	AuthCaptcha(ctx context.Context, req *v1.AuthCaptchaReq) (res *v1.AuthCaptchaRes, err error)
 	AuthLogin(ctx context.Context, req *v1.AuthLoginReq) (res *v1.AuthLoginRes, err error)
+	AuthLoginMfa(ctx context.Context, req *v1.AuthLoginMfaReq) (res *v1.AuthLoginMfaRes, err error)
+	AuthOidcConfig(ctx context.Context, req *v1.AuthOidcConfigReq) (res *v1.AuthOidcConfigRes, err error)
+	AuthOidcAuthorize(ctx context.Context, req *v1.AuthOidcAuthorizeReq) (res *v1.AuthOidcAuthorizeRes, err error)
+	AuthLoginOidc(ctx context.Context, req *v1.AuthLoginOidcReq) (res *v1.AuthLoginOidcRes, err error)
+	AuthRefresh(ctx context.Context, req *v1.AuthRefreshReq) (res *v1.AuthRefreshRes, err error)
+	AuthLogout(ctx context.Context, req *v1.AuthLogoutReq) (res *v1.AuthLogoutRes, err error)
+	AuthImpersonationEnd(ctx context.Context, req *v1.AuthImpersonationEndReq) (res *v1.AuthImpersonationEndRes, err error)
+	AuthMfaSetup(ctx context.Context, req *v1.AuthMfaSetupReq) (res *v1.AuthMfaSetupRes, err error)
+	AuthMfaEnable(ctx context.Context, req *v1.AuthMfaEnableReq) (res *v1.AuthMfaEnableRes, err error)
+	AuthMfaDisable(ctx context.Context, req *v1.AuthMfaDisableReq) (res *v1.AuthMfaDisableRes, err error)
+	AuthMfaRecoveryCodes(ctx context.Context, req *v1.AuthMfaRecoveryCodesReq) (res *v1.AuthMfaRecoveryCodesRes, err error)
+	AuthSessions(ctx context.Context, req *v1.AuthSessionsReq) (res *v1.AuthSessionsRes, err error)
+	AuthSessionRevoke(ctx context.Context, req *v1.AuthSessionRevokeReq) (res *v1.AuthSessionRevokeRes, err error)
+	AuthApiKeys(ctx context.Context, req *v1.AuthApiKeysReq) (res *v1.AuthApiKeysRes, err error)
+	AuthApiKeyCreate(ctx context.Context, req *v1.AuthApiKeyCreateReq) (res *v1.AuthApiKeyCreateRes, err error)
+	AuthApiKeyRevoke(ctx context.Context, req *v1.AuthApiKeyRevokeReq) (res *v1.AuthApiKeyRevokeRes, err error)
 	AuthResetPassword(ctx context.Context, req *v1.AuthResetPasswordReq) (res *v1.AuthResetPasswordRes, err error)
+	AuthForgotPassword(ctx context.Context, req *v1.AuthForgotPasswordReq) (res *v1.AuthForgotPasswordRes, err error)
+	AuthConfirmPasswordReset(ctx context.Context, req *v1.AuthConfirmPasswordResetReq) (res *v1.AuthConfirmPasswordResetRes, err error)
 	AuthProfile(ctx context.Context, req *v1.AuthProfileReq) (res *v1.AuthProfileRes, err error)
+	AuthMenus(ctx context.Context, req *v1.AuthMenusReq) (res *v1.AuthMenusRes, err error)
 	SysApiCreate(ctx context.Context, req *v1.SysApiCreateReq) (res *v1.SysApiCreateRes, err error)
 	SysApiUpdate(ctx context.Context, req *v1.SysApiUpdateReq) (res *v1.SysApiUpdateRes, err error)
 	SysApiDelete(ctx context.Context, req *v1.SysApiDeleteReq) (res *v1.SysApiDeleteRes, err error)
 	SysApiTree(ctx context.Context, req *v1.SysApiTreeReq) (res *v1.SysApiTreeRes, err error)
+	SysApiSync(ctx context.Context, req *v1.SysApiSyncReq) (res *v1.SysApiSyncRes, err error)
+	SysApiReload(ctx context.Context, req *v1.SysApiReloadReq) (res *v1.SysApiReloadRes, err error)
+	SysApiKeyList(ctx context.Context, req *v1.SysApiKeyListReq) (res *v1.SysApiKeyListRes, err error)
+	SysApiKeyRevoke(ctx context.Context, req *v1.SysApiKeyRevokeReq) (res *v1.SysApiKeyRevokeRes, err error)
 	SysDepartmentCreate(ctx context.Context, req *v1.SysDepartmentCreateReq) (res *v1.SysDepartmentCreateRes, err error)
 	SysDepartmentUpdate(ctx context.Context, req *v1.SysDepartmentUpdateReq) (res *v1.SysDepartmentUpdateRes, err error)
 	SysDepartmentDelete(ctx context.Context, req *v1.SysDepartmentDeleteReq) (res *v1.SysDepartmentDeleteRes, err error)
 	SysDepartmentTree(ctx context.Context, req *v1.SysDepartmentTreeReq) (res *v1.SysDepartmentTreeRes, err error)
+	SysLoginLogList(ctx context.Context, req *v1.SysLoginLogListReq) (res *v1.SysLoginLogListRes, err error)
 	SysRoleCreate(ctx context.Context, req *v1.SysRoleCreateReq) (res *v1.SysRoleCreateRes, err error)
 	SysRoleUpdate(ctx context.Context, req *v1.SysRoleUpdateReq) (res *v1.SysRoleUpdateRes, err error)
 	SysRoleDelete(ctx context.Context, req *v1.SysRoleDeleteReq) (res *v1.SysRoleDeleteRes, err error)
 	SysRoleList(ctx context.Context, req *v1.SysRoleListReq) (res *v1.SysRoleListRes, err error)
 	SysRoleDetail(ctx context.Context, req *v1.SysRoleDetailReq) (res *v1.SysRoleDetailRes, err error)
+	SysSessionList(ctx context.Context, req *v1.SysSessionListReq) (res *v1.SysSessionListRes, err error)
+	SysSessionRevoke(ctx context.Context, req *v1.SysSessionRevokeReq) (res *v1.SysSessionRevokeRes, err error)
 	Upload(ctx context.Context, req *v1.UploadReq) (res *v1.UploadRes, err error)
 	UploadList(ctx context.Context, req *v1.UploadListReq) (res *v1.UploadListRes, err error)
 	SysUserCreate(ctx context.Context, req *v1.SysUserCreateReq) (res *v1.SysUserCreateRes, err error)
@@ -59,4 +85,10 @@ type IAdminV1 interface {
 	SysUserList(ctx context.Context, req *v1.SysUserListReq) (res *v1.SysUserListRes, err error)
 	SysUserDetail(ctx context.Context, req *v1.SysUserDetailReq) (res *v1.SysUserDetailRes, err error)
 	SysUserUpdatePassword(ctx context.Context, req *v1.SysUserUpdatePasswordReq) (res *v1.SysUserUpdatePasswordRes, err error)
+	SysUserRevokeTokens(ctx context.Context, req *v1.SysUserRevokeTokensReq) (res *v1.SysUserRevokeTokensRes, err error)
+	SysUserUnlock(ctx context.Context, req *v1.SysUserUnlockReq) (res *v1.SysUserUnlockRes, err error)
+	SysUserResetMfa(ctx context.Context, req *v1.SysUserResetMfaReq) (res *v1.SysUserResetMfaRes, err error)
+	SysUserLdapSync(ctx context.Context, req *v1.SysUserLdapSyncReq) (res *v1.SysUserLdapSyncRes, err error)
+	SysUserImpersonate(ctx context.Context, req *v1.SysUserImpersonateReq) (res *v1.SysUserImpersonateRes, err error)
+	SysUserDiagnosePermission(ctx context.Context, req *v1.SysUserDiagnosePermissionReq) (res *v1.SysUserDiagnosePermissionRes, err error)
 }
@@ -4,20 +4,23 @@ import (
 	adminModel "gf-ant-react/internal/model/admin"
 
 	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
 )
 
 // 获取验证码接口
 type AuthCaptchaReq struct {
 	g.Meta `path:"/auth/captcha" tags:"Auth" method:"get" summary:"获取验证码"`
-	Width  int `json:"width" dc:"验证码宽度" default:"120"`
-	Height int `json:"height" dc:"验证码高度" default:"40"`
+	Type   string `json:"type" v:"in:digit,math,string,audio#验证码类型必须是digit,math,string,audio中的一个" dc:"验证码类型: digit=数字, math=算术, string=字符, audio=语音，为空时使用网站设置"`
+	Width  int    `json:"width" dc:"验证码宽度" default:"120"`
+	Height int    `json:"height" dc:"验证码高度" default:"40"`
 }
 
 // AuthCaptchaRes 获取验证码接口返回
 type AuthCaptchaRes struct {
 	g.Meta `mime:"application/json"`
 	Id     string `json:"id" dc:"验证码ID"`
-	Base64 string `json:"base64" dc:"base64编码后的验证码图片"`
+	Type   string `json:"type" dc:"验证码类型"`
+	Base64 string `json:"base64" dc:"base64编码后的验证码图片，语音验证码为wav音频"`
 }
 
 // AuthLoginReq 登录接口定义
@@ -25,8 +28,8 @@ type AuthLoginReq struct {
 	g.Meta      `path:"/auth/login" tags:"Auth" method:"post" summary:"登录"`
 	Username    string `json:"username" v:"required#请输入用户名"`
 	Password    string `json:"password" v:"required#请输入密码"`
-	CaptchaCode string `json:"captchaCode" v:"required#请输入验证码"`
-	CaptchaId   string `json:"captchaId" v:"required#请输入验证码ID"`
+	CaptchaCode string `json:"captchaCode" dc:"验证码，开启登录失败后才需要验证码时可为空"`
+	CaptchaId   string `json:"captchaId" dc:"验证码ID"`
 }
 
 // AuthLoginRes 登录接口返回
@@ -35,10 +38,196 @@ type AuthLoginRes struct {
 	*adminModel.LoginRes
 }
 
+// 两步验证登录
+type AuthLoginMfaReq struct {
+	g.Meta       `path:"/auth/login/mfa" tags:"Auth" method:"post" summary:"两步验证登录"`
+	MfaToken     string `json:"mfaToken" v:"required#请重新登录"`
+	Code         string `json:"code" v:"required-without:recoveryCode#请输入验证码" dc:"验证器应用中的6位验证码"`
+	RecoveryCode string `json:"recoveryCode" dc:"恢复码，无法使用验证器应用时代替验证码"`
+}
+
+// 两步验证登录返回
+type AuthLoginMfaRes struct {
+	g.Meta `mime:"application/json"`
+	*adminModel.LoginRes
+}
+
+// 单点登录配置
+type AuthOidcConfigReq struct {
+	g.Meta `path:"/auth/oidc/config" tags:"Auth" method:"get" summary:"单点登录配置"`
+}
+
+// 单点登录配置返回
+type AuthOidcConfigRes struct {
+	g.Meta `mime:"application/json"`
+	*adminModel.OidcConfigRes
+}
+
+// 开始单点登录
+type AuthOidcAuthorizeReq struct {
+	g.Meta `path:"/auth/oidc/authorize" tags:"Auth" method:"get" summary:"开始单点登录"`
+}
+
+// 开始单点登录返回，前端跳转到 url
+type AuthOidcAuthorizeRes struct {
+	g.Meta `mime:"application/json"`
+	Url    string `json:"url" dc:"身份提供方授权地址"`
+}
+
+// 单点登录回调
+type AuthLoginOidcReq struct {
+	g.Meta `path:"/auth/oidc/callback" tags:"Auth" method:"post" summary:"单点登录回调"`
+	Code   string `json:"code" v:"required#缺少授权码"`
+	State  string `json:"state" v:"required#缺少state"`
+}
+
+// 单点登录回调返回
+type AuthLoginOidcRes struct {
+	g.Meta `mime:"application/json"`
+	*adminModel.LoginRes
+}
+
+// 刷新令牌
+type AuthRefreshReq struct {
+	g.Meta       `path:"/auth/refresh" tags:"Auth" method:"post" summary:"刷新令牌"`
+	RefreshToken string `json:"refreshToken" v:"required#请输入刷新令牌"`
+}
+
+// 刷新令牌返回
+type AuthRefreshRes struct {
+	g.Meta `mime:"application/json"`
+	*adminModel.TokenRes
+}
+
+// 退出登录
+type AuthLogoutReq struct {
+	g.Meta `path:"/auth/logout" tags:"Auth" method:"post" summary:"退出登录"`
+}
+
+// 退出登录返回
+type AuthLogoutRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 结束代登录
+type AuthImpersonationEndReq struct {
+	g.Meta `path:"/auth/impersonation/end" tags:"Auth" method:"post" summary:"结束代登录"`
+}
+
+// 结束代登录返回
+type AuthImpersonationEndRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 获取两步验证绑定信息
+type AuthMfaSetupReq struct {
+	g.Meta `path:"/auth/mfa/setup" tags:"Auth" method:"post" summary:"获取两步验证绑定信息"`
+}
+
+// 获取两步验证绑定信息返回
+type AuthMfaSetupRes struct {
+	g.Meta `mime:"application/json"`
+	*adminModel.MfaSetupRes
+}
+
+// 开启两步验证
+type AuthMfaEnableReq struct {
+	g.Meta `path:"/auth/mfa/enable" tags:"Auth" method:"post" summary:"开启两步验证"`
+	Code   string `json:"code" v:"required#请输入验证码"`
+}
+
+// 开启两步验证返回
+type AuthMfaEnableRes struct {
+	g.Meta `mime:"application/json"`
+	*adminModel.MfaRecoveryCodesRes
+}
+
+// 关闭两步验证
+type AuthMfaDisableReq struct {
+	g.Meta `path:"/auth/mfa/disable" tags:"Auth" method:"post" summary:"关闭两步验证"`
+	Code   string `json:"code" v:"required#请输入验证码"`
+}
+
+// 关闭两步验证返回
+type AuthMfaDisableRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 重新生成恢复码
+type AuthMfaRecoveryCodesReq struct {
+	g.Meta `path:"/auth/mfa/recovery-codes" tags:"Auth" method:"post" summary:"重新生成恢复码"`
+	Code   string `json:"code" v:"required#请输入验证码"`
+}
+
+// 重新生成恢复码返回
+type AuthMfaRecoveryCodesRes struct {
+	g.Meta `mime:"application/json"`
+	*adminModel.MfaRecoveryCodesRes
+}
+
+// 我的登录会话
+type AuthSessionsReq struct {
+	g.Meta `path:"/auth/sessions" tags:"Auth" method:"get" summary:"我的登录会话"`
+}
+
+// 我的登录会话返回
+type AuthSessionsRes struct {
+	g.Meta `mime:"application/json"`
+	*adminModel.SysUserSessionListResult
+}
+
+// 结束登录会话
+type AuthSessionRevokeReq struct {
+	g.Meta    `path:"/auth/sessions/:sessionId" tags:"Auth" method:"delete" summary:"结束登录会话"`
+	SessionId string `path:"sessionId" v:"required#会话ID不能为空"`
+}
+
+// 结束登录会话返回
+type AuthSessionRevokeRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 我的 API Key
+type AuthApiKeysReq struct {
+	g.Meta `path:"/auth/api-keys" tags:"Auth" method:"get" summary:"我的 API Key"`
+}
+
+// 我的 API Key 返回
+type AuthApiKeysRes struct {
+	g.Meta `mime:"application/json"`
+	*adminModel.SysApiKeyListResult
+}
+
+// 创建 API Key
+type AuthApiKeyCreateReq struct {
+	g.Meta    `path:"/auth/api-key" tags:"Auth" method:"post" summary:"创建 API Key"`
+	Name      string      `json:"name" v:"required|length:1,100#请输入名称|名称长度不能超过100个字符"`
+	Scopes    []string    `json:"scopes" dc:"允许的权限码，为空时拥有用户的全部权限"`
+	ExpiresAt *gtime.Time `json:"expiresAt" dc:"过期时间，为空时永不过期"`
+}
+
+// 创建 API Key 返回，key 只返回这一次
+type AuthApiKeyCreateRes struct {
+	g.Meta `mime:"application/json"`
+	*adminModel.SysApiKeyCreateResult
+}
+
+// 吊销 API Key
+type AuthApiKeyRevokeReq struct {
+	g.Meta `path:"/auth/api-key/:id" tags:"Auth" method:"delete" summary:"吊销 API Key"`
+	Id     uint64 `path:"id" v:"required#ID不能为空"`
+}
+
+// 吊销 API Key 返回
+type AuthApiKeyRevokeRes struct {
+	g.Meta `mime:"application/json"`
+}
+
 // 重置密码
 type AuthResetPasswordReq struct {
-	g.Meta   `path:"/auth/reset-password" tags:"Auth" method:"post" summary:"重置密码"`
-	Password string `json:"password" v:"required#请输入密码"`
+	g.Meta      `path:"/auth/reset-password" tags:"Auth" method:"post" summary:"重置密码"`
+	OldPassword string `json:"oldPassword" v:"required#请输入原密码"`
+	Password    string `json:"password" v:"required#请输入新密码" dc:"新密码，需符合密码策略"`
 }
 
 // 重置密码返回
@@ -46,6 +235,29 @@ type AuthResetPasswordRes struct {
 	g.Meta `mime:"application/json"`
 }
 
+// 找回密码，向账号绑定的邮箱发送重置链接
+type AuthForgotPasswordReq struct {
+	g.Meta  `path:"/auth/password/forgot" tags:"Auth" method:"post" summary:"找回密码"`
+	Account string `json:"account" v:"required|length:1,100#请输入用户名或邮箱|用户名或邮箱长度不能超过100个字符" dc:"用户名或邮箱"`
+}
+
+// 找回密码返回，不论账号是否存在都返回成功
+type AuthForgotPasswordRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 通过邮件中的重置链接设置新密码
+type AuthConfirmPasswordResetReq struct {
+	g.Meta   `path:"/auth/password/reset" tags:"Auth" method:"post" summary:"设置新密码"`
+	Token    string `json:"token" v:"required#重置链接无效"`
+	Password string `json:"password" v:"required#请输入新密码" dc:"新密码，需符合密码策略"`
+}
+
+// 设置新密码返回
+type AuthConfirmPasswordResetRes struct {
+	g.Meta `mime:"application/json"`
+}
+
 // 个人中心
 type AuthProfileReq struct {
 	g.Meta `path:"/auth/profile" tags:"Auth" method:"get" summary:"个人中心"`
@@ -56,3 +268,14 @@ type AuthProfileRes struct {
 	g.Meta `mime:"application/json"`
 	*adminModel.ProfileRes
 }
+
+// 我的菜单
+type AuthMenusReq struct {
+	g.Meta `path:"/auth/menus" tags:"Auth" method:"get" summary:"我的菜单"`
+}
+
+// 我的菜单返回
+type AuthMenusRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*adminModel.MenuItem `json:"list" dc:"菜单树，按排序从大到小"`
+}
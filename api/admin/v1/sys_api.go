@@ -2,6 +2,7 @@ package v1
 
 import (
 	"gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/service"
 
 	"github.com/gogf/gf/v2/frame/g"
 )
@@ -12,11 +13,15 @@ type SysApiCreateReq struct {
 	ParentId       uint64 `json:"parentId" v:"integer#上级ID必须为整数" description:"上级ID，NULL表示根节点"`
 	Name           string `json:"name" v:"required|length:1,50#名称不能为空|名称长度必须在1-50个字符之间" description:"名称，如：用户管理、查询用户"`
 	PermissionCode string `json:"permissionCode" v:"required|length:1,100#权限标识不能为空|权限标识长度必须在1-100个字符之间" description:"权限唯一标识，如：system:user:list"`
-	Url            string `json:"url" v:"required|length:1,200#接口URL不能为空|接口URL长度必须在1-200个字符之间" description:"接口URL，支持通配符"`
-	Method         string `json:"method" v:"required|in:GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD#请求方法不能为空|请求方法必须是GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD中的一个" description:"请求方法"`
+	Url            string `json:"url" v:"required|length:1,200#接口URL不能为空|接口URL长度必须在1-200个字符之间" description:"接口URL，支持 :id、{id}、* 匹配一个路径段，末尾的 /** 匹配剩余路径"`
+	Method         string `json:"method" v:"required|in:GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD,ANY#请求方法不能为空|请求方法必须是GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD,ANY中的一个" description:"请求方法，ANY匹配所有方法"`
 	Sort           int    `json:"sort" v:"integer#排序必须为整数" description:"排序"`
 	Status         int    `json:"status" v:"in:0,1#状态值必须是0,1中的一个" description:"状态：0=禁用，1=启用"`
 	IsMenu         int    `json:"isMenu" v:"in:0,1#菜单标识必须是0,1中的一个" description:"是否为菜单：0=否，1=是"`
+	AuthType       int    `json:"authType" v:"in:0,1,2#鉴权方式必须是0,1,2中的一个" description:"鉴权方式：0=需要权限，1=只需要登录，2=不需要鉴权"`
+	Icon           string `json:"icon" v:"length:0,50#菜单图标长度不能超过50个字符" description:"菜单图标，是否为菜单为1时使用"`
+	Route          string `json:"route" v:"length:0,200#菜单路由长度不能超过200个字符" description:"菜单前端路由，如：/sys/user"`
+	Component      string `json:"component" v:"length:0,200#菜单组件长度不能超过200个字符" description:"菜单前端组件，如：sys/user/index"`
 	Description    string `json:"description" v:"length:0,500#描述长度不能超过500个字符" description:"描述"`
 }
 
@@ -33,11 +38,15 @@ type SysApiUpdateReq struct {
 	ParentId       uint64 `json:"parentId" v:"integer#上级ID必须为整数" description:"上级ID，NULL表示根节点"`
 	Name           string `json:"name" v:"required|length:1,50#名称不能为空|名称长度必须在1-50个字符之间" description:"名称，如：用户管理、查询用户"`
 	PermissionCode string `json:"permissionCode" v:"required|length:1,100#权限标识不能为空|权限标识长度必须在1-100个字符之间" description:"权限唯一标识，如：system:user:list"`
-	Url            string `json:"url" v:"required|length:1,200#接口URL不能为空|接口URL长度必须在1-200个字符之间" description:"接口URL，支持通配符"`
-	Method         string `json:"method" v:"required|in:GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD#请求方法不能为空|请求方法必须是GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD中的一个" description:"请求方法"`
+	Url            string `json:"url" v:"required|length:1,200#接口URL不能为空|接口URL长度必须在1-200个字符之间" description:"接口URL，支持 :id、{id}、* 匹配一个路径段，末尾的 /** 匹配剩余路径"`
+	Method         string `json:"method" v:"required|in:GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD,ANY#请求方法不能为空|请求方法必须是GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD,ANY中的一个" description:"请求方法，ANY匹配所有方法"`
 	Sort           int    `json:"sort" v:"integer#排序必须为整数" description:"排序"`
 	Status         int    `json:"status" v:"in:0,1#状态值必须是0,1中的一个" description:"状态：0=禁用，1=启用"`
 	IsMenu         int    `json:"isMenu" v:"in:0,1#菜单标识必须是0,1中的一个" description:"是否为菜单：0=否，1=是"`
+	AuthType       int    `json:"authType" v:"in:0,1,2#鉴权方式必须是0,1,2中的一个" description:"鉴权方式：0=需要权限，1=只需要登录，2=不需要鉴权"`
+	Icon           string `json:"icon" v:"length:0,50#菜单图标长度不能超过50个字符" description:"菜单图标，是否为菜单为1时使用"`
+	Route          string `json:"route" v:"length:0,200#菜单路由长度不能超过200个字符" description:"菜单前端路由，如：/sys/user"`
+	Component      string `json:"component" v:"length:0,200#菜单组件长度不能超过200个字符" description:"菜单前端组件，如：sys/user/index"`
 	Description    string `json:"description" v:"length:0,500#描述长度不能超过500个字符" description:"描述"`
 }
 
@@ -68,3 +77,27 @@ type SysApiTreeRes struct {
 	List   []*admin.SysApiTreeResultItem `json:"list" description:"API树形结构"`
 	Config map[string]interface{}        `json:"config" description:"配置"`
 }
+
+// SysApiSyncReq 同步路由请求参数
+type SysApiSyncReq struct {
+	g.Meta         `path:"/sys/api/sync" tags:"SysApi" method:"post" summary:"同步路由到API"`
+	DryRun         bool `json:"dryRun" description:"只比较不修改"`
+	DisableOrphans bool `json:"disableOrphans" description:"禁用没有对应路由的接口"`
+}
+
+// SysApiSyncRes 同步路由响应参数
+type SysApiSyncRes struct {
+	g.Meta `mime:"application/json"`
+	*admin.SysApiSyncResult
+}
+
+// SysApiReloadReq 重新加载鉴权缓存请求参数
+type SysApiReloadReq struct {
+	g.Meta `path:"/sys/api/reload" tags:"SysApi" method:"post" summary:"重新加载鉴权缓存"`
+}
+
+// SysApiReloadRes 重新加载鉴权缓存响应参数
+type SysApiReloadRes struct {
+	g.Meta `mime:"application/json"`
+	*service.SysPermissionCacheStats
+}
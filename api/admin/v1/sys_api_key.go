@@ -0,0 +1,34 @@
+package v1
+
+import (
+	"gf-ant-react/internal/model/admin"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// SysApiKeyListReq 获取 API Key 列表请求参数
+type SysApiKeyListReq struct {
+	g.Meta      `path:"/sys/api-key/list" tags:"SysApiKey" method:"get" summary:"获取 API Key 列表"`
+	Page        int    `json:"page" d:"1" v:"min:1#页码不能小于1" description:"页码"`
+	Size        int    `json:"size" d:"10" v:"min:1|max:100#每页数量不能小于1|每页数量不能大于100" description:"每页数量"`
+	UserId      uint64 `json:"userId" v:"integer#用户ID必须为整数" description:"用户ID"`
+	WithRevoked bool   `json:"withRevoked" description:"是否包含已吊销的 API Key"`
+}
+
+// SysApiKeyListRes 获取 API Key 列表响应参数
+type SysApiKeyListRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*admin.SysApiKeyItem `json:"list" description:"API Key 列表"`
+	Total  int                    `json:"total" description:"总数量"`
+}
+
+// SysApiKeyRevokeReq 吊销 API Key 请求参数
+type SysApiKeyRevokeReq struct {
+	g.Meta `path:"/sys/api-key/revoke/:id" tags:"SysApiKey" method:"put" summary:"吊销 API Key"`
+	Id     uint64 `path:"id" v:"required#ID不能为空" description:"API Key ID"`
+}
+
+// SysApiKeyRevokeRes 吊销 API Key 响应参数
+type SysApiKeyRevokeRes struct {
+	g.Meta `mime:"application/json"`
+}
@@ -0,0 +1,30 @@
+package v1
+
+import (
+	"gf-ant-react/internal/model/entity"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// SysLoginLogListReq 获取登录日志列表请求参数
+type SysLoginLogListReq struct {
+	g.Meta    `path:"/sys/login-log/list" tags:"SysLoginLog" method:"get" summary:"获取登录日志列表"`
+	Page      int         `json:"page" d:"1" v:"min:1#页码不能小于1" description:"页码"`
+	Size      int         `json:"size" d:"10" v:"min:1|max:100#每页数量不能小于1|每页数量不能大于100" description:"每页数量"`
+	UserId    uint64      `json:"userId" v:"integer#用户ID必须为整数" description:"用户ID"`
+	Username  string      `json:"username" v:"length:0,50#用户名长度不能超过50个字符" description:"用户名（模糊查询）"`
+	Method    string      `json:"method" v:"in:password,mfa,oidc,impersonate#登录方式必须是password,mfa,oidc,impersonate中的一个" description:"登录方式：password=密码，mfa=两步验证，oidc=单点登录，impersonate=代登录"`
+	Status    *int        `json:"status" v:"in:0,1,2#登录结果必须是0,1,2中的一个" description:"登录结果：0=失败，1=成功，2=待两步验证"`
+	Reason    string      `json:"reason" v:"length:0,30#原因长度不能超过30个字符" description:"结果原因，如 bad_password、lockout、captcha"`
+	Ip        string      `json:"ip" v:"length:0,45#IP长度不能超过45个字符" description:"登录IP"`
+	StartTime *gtime.Time `json:"startTime" description:"开始时间"`
+	EndTime   *gtime.Time `json:"endTime" description:"结束时间"`
+}
+
+// SysLoginLogListRes 获取登录日志列表响应参数
+type SysLoginLogListRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*entity.SysLoginLogs `json:"list" description:"登录日志列表"`
+	Total  int                    `json:"total" description:"总数量"`
+}
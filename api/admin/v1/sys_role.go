@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"gf-ant-react/internal/model/admin"
 	"gf-ant-react/internal/service"
 
 	"github.com/gogf/gf/v2/frame/g"
@@ -8,13 +9,16 @@ import (
 
 // SysRoleCreateReq 创建角色请求参数
 type SysRoleCreateReq struct {
-	g.Meta      `path:"/sys/role/create" tags:"SysRole" method:"post" summary:"创建角色"`
-	Name        string   `json:"name" v:"required|length:1,50#角色名称不能为空|角色名称长度必须在1-50个字符之间" description:"角色名称"`
-	Description string   `json:"description" v:"length:0,500#描述长度不能超过500个字符" description:"描述"`
-	DataScope   int      `json:"dataScope" v:"required|in:1,2,3,4,5#数据权限范围不能为空|数据权限范围必须是1,2,3,4,5中的一个" description:"数据权限范围: 1=全部, 2=本部门, 3=本部门及子部门, 4=仅本人, 5=自定义"`
-	Sort        int      `json:"sort" v:"integer#排序必须为整数" description:"排序"`
-	Status      bool     `json:"status" description:"状态: false=禁用, true=启用"`
-	ApiIds      []uint64 `json:"apiIds" description:"关联的API权限ID列表"`
+	g.Meta        `path:"/sys/role/create" tags:"SysRole" method:"post" summary:"创建角色"`
+	ParentId      uint64   `json:"parentId" description:"上级角色ID，0表示没有上级，角色继承上级角色的接口权限"`
+	Name          string   `json:"name" v:"required|length:1,50#角色名称不能为空|角色名称长度必须在1-50个字符之间" description:"角色名称"`
+	Description   string   `json:"description" v:"length:0,500#描述长度不能超过500个字符" description:"描述"`
+	DataScope     int      `json:"dataScope" v:"required|in:1,2,3,4,5#数据权限范围不能为空|数据权限范围必须是1,2,3,4,5中的一个" description:"数据权限范围: 1=全部, 2=本部门, 3=本部门及子部门, 4=仅本人, 5=自定义"`
+	Sort          int      `json:"sort" v:"integer#排序必须为整数" description:"排序"`
+	Status        bool     `json:"status" description:"状态: false=禁用, true=启用"`
+	RequireMfa    bool     `json:"requireMfa" description:"是否要求两步验证"`
+	ApiIds        []uint64 `json:"apiIds" description:"关联的API权限ID列表"`
+	DepartmentIds []uint64 `json:"departmentIds" description:"自定义数据权限范围的部门ID列表，数据权限范围为自定义时必填"`
 }
 
 // SysRoleCreateRes 创建角色响应参数
@@ -25,14 +29,17 @@ type SysRoleCreateRes struct {
 
 // SysRoleUpdateReq 更新角色请求参数
 type SysRoleUpdateReq struct {
-	g.Meta      `path:"/sys/role/update/:id" tags:"SysRole" method:"put" summary:"更新角色"`
-	Id          uint64   `path:"id" v:"required|integer#ID不能为空|ID必须为整数" description:"主键"`
-	Name        string   `json:"name" v:"required|length:1,50#角色名称不能为空|角色名称长度必须在1-50个字符之间" description:"角色名称"`
-	Description string   `json:"description" v:"length:0,500#描述长度不能超过500个字符" description:"描述"`
-	DataScope   int      `json:"dataScope" v:"required|in:1,2,3,4,5#数据权限范围不能为空|数据权限范围必须是1,2,3,4,5中的一个" description:"数据权限范围: 1=全部, 2=本部门, 3=本部门及子部门, 4=仅本人, 5=自定义"`
-	Sort        int      `json:"sort" v:"integer#排序必须为整数" description:"排序"`
-	Status      bool     `json:"status" description:"状态: false=禁用, true=启用"`
-	ApiIds      []uint64 `json:"apiIds" description:"关联的API权限ID列表"`
+	g.Meta        `path:"/sys/role/update/:id" tags:"SysRole" method:"put" summary:"更新角色"`
+	Id            uint64   `path:"id" v:"required|integer#ID不能为空|ID必须为整数" description:"主键"`
+	ParentId      uint64   `json:"parentId" description:"上级角色ID，0表示没有上级，角色继承上级角色的接口权限"`
+	Name          string   `json:"name" v:"required|length:1,50#角色名称不能为空|角色名称长度必须在1-50个字符之间" description:"角色名称"`
+	Description   string   `json:"description" v:"length:0,500#描述长度不能超过500个字符" description:"描述"`
+	DataScope     int      `json:"dataScope" v:"required|in:1,2,3,4,5#数据权限范围不能为空|数据权限范围必须是1,2,3,4,5中的一个" description:"数据权限范围: 1=全部, 2=本部门, 3=本部门及子部门, 4=仅本人, 5=自定义"`
+	Sort          int      `json:"sort" v:"integer#排序必须为整数" description:"排序"`
+	Status        bool     `json:"status" description:"状态: false=禁用, true=启用"`
+	RequireMfa    bool     `json:"requireMfa" description:"是否要求两步验证"`
+	ApiIds        []uint64 `json:"apiIds" description:"关联的API权限ID列表"`
+	DepartmentIds []uint64 `json:"departmentIds" description:"自定义数据权限范围的部门ID列表，数据权限范围为自定义时必填"`
 }
 
 // SysRoleUpdateRes 更新角色响应参数
@@ -75,14 +82,19 @@ type SysRoleDetailReq struct {
 
 // SysRoleDetailRes 获取角色详情响应参数
 type SysRoleDetailRes struct {
-	g.Meta      `mime:"application/json"`
-	Id          uint64   `json:"id" description:"角色ID"`
-	Name        string   `json:"name" description:"角色名称"`
-	Description string   `json:"description" description:"描述"`
-	DataScope   int      `json:"dataScope" description:"数据权限范围"`
-	Sort        int      `json:"sort" description:"排序"`
-	Status      bool     `json:"status" description:"状态"`
-	ApiIds      []uint64 `json:"apiIds" description:"关联的API权限ID列表"`
-	CreatedAt   string   `json:"createdAt" description:"创建时间"`
-	UpdatedAt   string   `json:"updatedAt" description:"更新时间"`
+	g.Meta        `mime:"application/json"`
+	Id            uint64                        `json:"id" description:"角色ID"`
+	ParentId      uint64                        `json:"parentId" description:"上级角色ID"`
+	Name          string                        `json:"name" description:"角色名称"`
+	Description   string                        `json:"description" description:"描述"`
+	DataScope     int                           `json:"dataScope" description:"数据权限范围"`
+	Sort          int                           `json:"sort" description:"排序"`
+	Status        bool                          `json:"status" description:"状态"`
+	RequireMfa    bool                          `json:"requireMfa" description:"是否要求两步验证"`
+	IsSuper       bool                          `json:"isSuper" description:"是否超级管理员，拥有所有接口权限，不能删除或禁用"`
+	ApiIds        []uint64                      `json:"apiIds" description:"直接关联的API权限ID列表"`
+	InheritedApis []*admin.SysRoleInheritedApis `json:"inheritedApis" description:"从上级角色继承的API权限，按继承顺序从直接上级开始"`
+	DepartmentIds []uint64                      `json:"departmentIds" description:"自定义数据权限范围的部门ID列表"`
+	CreatedAt     string                        `json:"createdAt" description:"创建时间"`
+	UpdatedAt     string                        `json:"updatedAt" description:"更新时间"`
 }
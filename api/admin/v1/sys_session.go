@@ -0,0 +1,34 @@
+package v1
+
+import (
+	"gf-ant-react/internal/model/admin"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// SysSessionListReq 获取在线会话列表请求参数
+type SysSessionListReq struct {
+	g.Meta   `path:"/sys/session/list" tags:"SysSession" method:"get" summary:"获取在线会话列表"`
+	Page     int    `json:"page" d:"1" v:"min:1#页码不能小于1" description:"页码"`
+	Size     int    `json:"size" d:"10" v:"min:1|max:100#每页数量不能小于1|每页数量不能大于100" description:"每页数量"`
+	UserId   uint64 `json:"userId" v:"integer#用户ID必须为整数" description:"用户ID"`
+	Username string `json:"username" v:"length:0,50#用户名长度不能超过50个字符" description:"用户名（模糊查询）"`
+}
+
+// SysSessionListRes 获取在线会话列表响应参数
+type SysSessionListRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*admin.SysUserSessionItem `json:"list" description:"会话列表"`
+	Total  int                         `json:"total" description:"总数量"`
+}
+
+// SysSessionRevokeReq 强制结束会话请求参数
+type SysSessionRevokeReq struct {
+	g.Meta    `path:"/sys/session/revoke/:sessionId" tags:"SysSession" method:"put" summary:"强制结束会话"`
+	SessionId string `path:"sessionId" v:"required#会话ID不能为空" description:"会话ID"`
+}
+
+// SysSessionRevokeRes 强制结束会话响应参数
+type SysSessionRevokeRes struct {
+	g.Meta `mime:"application/json"`
+}
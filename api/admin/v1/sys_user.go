@@ -11,7 +11,8 @@ import (
 type SysUserCreateReq struct {
 	g.Meta       `path:"/sys/user/create" tags:"SysUser" method:"post" summary:"创建用户"`
 	Username     string   `json:"username" v:"required|length:3,50#用户名不能为空|用户名长度必须在3-50个字符之间" description:"用户名"`
-	PasswordHash string   `json:"passwordHash" v:"required|length:6,100#密码哈希不能为空|密码哈希长度必须在6-100个字符之间" description:"密码哈希"`
+	PasswordHash string   `json:"passwordHash" v:"required-unless:authSource,ldap|max-length:100#密码不能为空|密码长度不能超过100个字符" description:"密码，需符合密码策略，LDAP 用户不需要"`
+	AuthSource   string   `json:"authSource" d:"local" v:"in:local,ldap#认证方式必须是local,ldap中的一个" description:"认证方式: local=本地密码, ldap=LDAP"`
 	Email        string   `json:"email" v:"email#邮箱格式不正确" description:"邮箱"`
 	Mobile       string   `json:"mobile" v:"length:0,20#手机号长度不能超过20个字符" description:"手机号"`
 	DepartmentId uint64   `json:"departmentId" v:"integer#部门ID必须为整数" description:"所属部门ID"`
@@ -30,6 +31,7 @@ type SysUserUpdateReq struct {
 	g.Meta       `path:"/sys/user/update/:id" tags:"SysUser" method:"put" summary:"更新用户"`
 	Id           uint64   `path:"id" v:"required|integer#ID不能为空|ID必须为整数" description:"主键"`
 	Username     string   `json:"username" v:"required|length:3,50#用户名不能为空|用户名长度必须在3-50个字符之间" description:"用户名"`
+	AuthSource   string   `json:"authSource" d:"local" v:"in:local,ldap#认证方式必须是local,ldap中的一个" description:"认证方式: local=本地密码, ldap=LDAP"`
 	Email        string   `json:"email" v:"email#邮箱格式不正确" description:"邮箱"`
 	Mobile       string   `json:"mobile" v:"length:0,20#手机号长度不能超过20个字符" description:"手机号"`
 	DepartmentId uint64   `json:"departmentId" v:"integer#部门ID必须为整数" description:"所属部门ID"`
@@ -91,10 +93,82 @@ type SysUserDetailRes struct {
 type SysUserUpdatePasswordReq struct {
 	g.Meta   `path:"/sys/user/update-password/:id" tags:"SysUser" method:"put" summary:"修改密码"`
 	Id       uint64 `path:"id" v:"required|integer#ID不能为空|ID必须为整数" description:"主键"`
-	Password string `json:"password" v:"required|length:6,100#密码不能为空|密码长度必须在6-100个字符之间" description:"密码"`
+	Password string `json:"password" v:"required|max-length:100#密码不能为空|密码长度不能超过100个字符" description:"密码，需符合密码策略"`
 }
 
 // 修改密码响应参数
 type SysUserUpdatePasswordRes struct {
 	g.Meta `mime:"application/json"`
 }
+
+// 强制下线
+type SysUserRevokeTokensReq struct {
+	g.Meta `path:"/sys/user/revoke-tokens/:id" tags:"SysUser" method:"put" summary:"强制下线"`
+	Id     uint64 `path:"id" v:"required|integer#ID不能为空|ID必须为整数" description:"主键"`
+}
+
+// 强制下线响应参数
+type SysUserRevokeTokensRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 解除锁定
+type SysUserUnlockReq struct {
+	g.Meta `path:"/sys/user/unlock/:id" tags:"SysUser" method:"put" summary:"解除锁定"`
+	Id     uint64 `path:"id" v:"required|integer#ID不能为空|ID必须为整数" description:"主键"`
+}
+
+// 解除锁定响应参数
+type SysUserUnlockRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 重置两步验证
+type SysUserResetMfaReq struct {
+	g.Meta `path:"/sys/user/reset-mfa/:id" tags:"SysUser" method:"put" summary:"重置两步验证"`
+	Id     uint64 `path:"id" v:"required|integer#ID不能为空|ID必须为整数" description:"主键"`
+}
+
+// 重置两步验证返回
+type SysUserResetMfaRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 立即同步 LDAP 账号状态
+type SysUserLdapSyncReq struct {
+	g.Meta `path:"/sys/user/ldap-sync" tags:"SysUser" method:"put" summary:"同步LDAP账号状态"`
+}
+
+// 立即同步 LDAP 账号状态返回
+type SysUserLdapSyncRes struct {
+	g.Meta `mime:"application/json"`
+	*admin.LdapSyncRes
+}
+
+// 代登录，以该用户的身份签发短期访问令牌
+type SysUserImpersonateReq struct {
+	g.Meta `path:"/sys/user/impersonate/:id" tags:"SysUser" method:"post" summary:"代登录"`
+	Id     uint64 `path:"id" v:"required|integer#ID不能为空|ID必须为整数" description:"被代登录的用户ID"`
+	Reason string `json:"reason" v:"max-length:100#原因不能超过100个字符" description:"代登录原因，记入登录日志"`
+}
+
+// 代登录返回，不包含刷新令牌
+type SysUserImpersonateRes struct {
+	g.Meta `mime:"application/json"`
+	*admin.LoginRes
+}
+
+// 权限诊断，检查用户能否访问接口及原因
+type SysUserDiagnosePermissionReq struct {
+	g.Meta         `path:"/sys/user/diagnose-permission/:id" tags:"SysUser" method:"get" summary:"权限诊断"`
+	Id             uint64 `path:"id" v:"required|integer#ID不能为空|ID必须为整数" description:"用户ID"`
+	Method         string `json:"method" v:"required-with:url|in:GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD#请输入请求方法|请求方法必须是GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD中的一个" description:"请求方法"`
+	Url            string `json:"url" v:"required-without:permissionCode#请输入接口URL或权限标识" description:"请求路径，如：/sys/user/detail/1"`
+	PermissionCode string `json:"permissionCode" description:"权限标识，没有给出请求路径时使用"`
+}
+
+// 权限诊断返回
+type SysUserDiagnosePermissionRes struct {
+	g.Meta `mime:"application/json"`
+	*admin.DiagnosePermissionRes
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/gogf/gf/v2/frame/g"
@@ -14,6 +15,7 @@ import (
 	adminModel "gf-ant-react/internal/model/admin"
 	errorUtil "gf-ant-react/utility/error"
 	"gf-ant-react/utility/jwt"
+	"gf-ant-react/utility/revocation"
 )
 
 var (
@@ -22,38 +24,62 @@ var (
 		Usage: "main",
 		Brief: "start http server",
 		Func: func(ctx context.Context, parser *gcmd.Parser) (err error) {
+			// 按 auth.yaml 初始化接口的鉴权方式，运行时以数据库为准
+			// 失败时登录等不需要鉴权的接口也会要求权限，不能启动
+			if err = adminLogic.SysApiLogic.SeedAuthTypes(ctx); err != nil {
+				return fmt.Errorf("初始化接口鉴权方式失败: %w", err)
+			}
 			s := g.Server()
-			s.Use(
-				ghttp.MiddlewareCORS,
-			)
-			s.Group("/", func(group *ghttp.RouterGroup) {
-				group.Middleware(
-					ghttp.MiddlewareHandlerResponse,
-					MiddlewareAuthAdmin,
-				)
-				group.Bind(
-					hello.NewV1(),
-					admin.NewV1(),
-					admin.NewCms(),
-				)
-			})
+			bindRoutes(s)
+			// 定时同步 LDAP 账号状态
+			adminLogic.AuthLogic.StartLdapSync(ctx)
+			// 定时清理登录日志
+			adminLogic.AuthLogic.StartLoginLogCleanup(ctx)
+			// 订阅其他副本的鉴权缓存失效消息
+			adminLogic.AuthLogic.StartPermissionCacheBroadcast(ctx)
 			s.Run()
 			return nil
 		},
 	}
 )
 
+// bindRoutes 注册路由，sync-apis 命令读取同样的路由
+func bindRoutes(s *ghttp.Server) {
+	s.Use(
+		ghttp.MiddlewareCORS,
+	)
+	// 公开令牌校验公钥，不需要登录
+	s.BindHandler("GET:/.well-known/jwks.json", Jwks)
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(
+			ghttp.MiddlewareHandlerResponse,
+			MiddlewareAuthAdmin,
+		)
+		group.Bind(
+			hello.NewV1(),
+			admin.NewV1(),
+			admin.NewCms(),
+		)
+	})
+}
+
 // MiddlewareAuthAdmin 验证用户中间件
 func MiddlewareAuthAdmin(r *ghttp.Request) {
 
-	// 是否是忽略的路由
-	ignoreRoutes := g.Cfg("auth").MustGet(r.Context(), "ignoreRoutes").MapStrStr()
-	method, ok := ignoreRoutes[r.Router.Uri]
-	// 检查是否需要权限
-	if !ok || method != strings.ToUpper(r.Request.Method) {
+	// 检查是否是忽略的路由
+	if !adminLogic.AuthLogic.IsIgnoredRoute(r.Context(), r.Request.Method, r.URL.Path) {
 
 		// 从请求头中获取 token
 		token := r.Header.Get(g.Cfg("auth").MustGet(r.Context(), "TokenHeader").String())
+
+		// 没有 token 时使用 API Key 认证
+		if token == "" {
+			if key := r.Header.Get(g.Cfg("auth").MustGet(r.Context(), "ApiKeyHeader").String()); key != "" {
+				authApiKey(r, key)
+				return
+			}
+		}
+
 		if token == "" {
 			JsonExit(r, errorUtil.CodeNoLogin, "没有登录")
 			return
@@ -66,18 +92,50 @@ func MiddlewareAuthAdmin(r *ghttp.Request) {
 			return
 		}
 
+		// 检查令牌是否已被吊销
+		revoked, err := revocation.RevocationUtility.IsRevoked(r.Context(), claims)
+		if err != nil {
+			JsonExit(r, errorUtil.CodeNoLogin, err.Error())
+			return
+		}
+		if revoked {
+			JsonExit(r, errorUtil.CodeNoLogin, "登录已失效，请重新登录")
+			return
+		}
+
+		// 检查登录会话是否已结束，并记录访问时间
+		active, err := adminLogic.AuthLogic.TouchSession(r.Context(), claims, r.GetClientIp())
+		if err != nil {
+			JsonExit(r, errorUtil.CodeNoLogin, err.Error())
+			return
+		}
+		if !active {
+			JsonExit(r, errorUtil.CodeNoLogin, "会话已结束，请重新登录")
+			return
+		}
+
 		// 设置上下文用户ID
 		r.SetCtxVar(g.Cfg("auth").MustGet(r.Context(), "CtxUserKey").String(), claims.UserID)
 
+		// 设置上下文令牌声明
+		r.SetCtxVar(g.Cfg("auth").MustGet(r.Context(), "CtxClaimsKey").String(), claims)
+
+		// 代登录期间的操作记录审计日志，敏感操作不允许
+		if claims.Impersonator != nil {
+			adminLogic.AuthLogic.AuditImpersonation(r.Context(), claims, strings.ToUpper(r.Request.Method), r.URL.Path, r.GetClientIp())
+			if adminLogic.AuthLogic.ImpersonationDenied(r.Context(), r.URL.Path, strings.ToUpper(r.Request.Method)) {
+				JsonExit(r, errorUtil.CodeNoAuth, "代登录期间不能进行该操作")
+				return
+			}
+		}
+
 		// 只需要登录不需要权限的路由
-		publicRoutes := g.Cfg("auth").MustGet(r.Context(), "publicRoutes").MapStrStr()
-		method, publicOk := publicRoutes[r.Router.Uri]
-		if !publicOk || method != strings.ToUpper(r.Request.Method) {
+		if !adminLogic.AuthLogic.IsPublicRoute(r.Context(), r.Request.Method, r.URL.Path) {
 
 			// 验证权限
 			ok, err := adminLogic.AuthLogic.CheckPermission(r.Context(), &adminModel.CheckPermissionReq{
 				UserId: claims.UserID,
-				Url:    r.Router.Uri,
+				Url:    r.URL.Path,
 				Method: strings.ToUpper(r.Request.Method),
 			})
 			if err != nil {
@@ -98,6 +156,49 @@ func MiddlewareAuthAdmin(r *ghttp.Request) {
 	r.Middleware.Next()
 }
 
+// authApiKey 使用 API Key 认证，API Key 不能访问只需要登录的路由，所有接口都需要校验权限
+func authApiKey(r *ghttp.Request, key string) {
+
+	apiKey, err := adminLogic.AuthLogic.AuthenticateApiKey(r.Context(), key, r.GetClientIp())
+	if err != nil {
+		JsonExit(r, errorUtil.CodeNoLogin, err.Error())
+		return
+	}
+	if apiKey == nil {
+		JsonExit(r, errorUtil.CodeNoLogin, "API Key 无效或已过期")
+		return
+	}
+
+	// 设置上下文用户ID
+	r.SetCtxVar(g.Cfg("auth").MustGet(r.Context(), "CtxUserKey").String(), apiKey.UserId)
+
+	// 验证权限
+	ok, err := adminLogic.AuthLogic.CheckPermission(r.Context(), &adminModel.CheckPermissionReq{
+		UserId: apiKey.UserId,
+		Url:    r.URL.Path,
+		Method: strings.ToUpper(r.Request.Method),
+		Scopes: adminLogic.AuthLogic.ApiKeyScopes(apiKey),
+	})
+	if err != nil {
+		JsonExit(r, errorUtil.CodeNoAuth, err.Error())
+		return
+	}
+
+	// 没有权限
+	if !ok {
+		JsonExit(r, errorUtil.CodeNoAuth, "没有权限")
+		return
+	}
+
+	r.Middleware.Next()
+}
+
+// Jwks 返回 JSON Web Key Set，格式遵循 RFC 7517，不使用通用JSON数据结构
+func Jwks(r *ghttp.Request) {
+	r.Response.Header().Set("Cache-Control", "public, max-age=300")
+	r.Response.WriteJson(jwt.JwtUtility.Jwks())
+}
+
 // JsonResponse 数据返回通用JSON数据结构
 type JsonResponse struct {
 	Code    int         `json:"code"`    // 错误码((0:成功, 1:失败, >1:错误码))
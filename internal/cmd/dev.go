@@ -0,0 +1,8 @@
+package cmd
+
+import (
+	"github.com/gogf/gf/v2/os/gcmd"
+)
+
+// DevCommands 开发用子命令，只在使用 -tags dev 编译时注册，生产构建中不包含
+var DevCommands []*gcmd.Command
@@ -0,0 +1,41 @@
+//go:build dev
+
+package cmd
+
+import (
+	"context"
+	"net"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcmd"
+
+	"gf-ant-react/utility/ldap/ldaptest"
+)
+
+func init() {
+	DevCommands = append(DevCommands, &MockLdap)
+}
+
+// MockLdap 本地模拟 LDAP 目录，仅用于开发和联调 LDAP 认证，不要在生产环境使用
+// 只支持简单绑定和查询，目录内容固定，与 ldap.yaml 的默认配置对应
+var MockLdap = gcmd.Command{
+	Name:  "mock-ldap",
+	Usage: "mock-ldap [--address=:3389]",
+	Brief: "start a local mock LDAP directory for development",
+	Arguments: []gcmd.Argument{
+		{Name: "address", Brief: "listening address, default :3389"},
+	},
+	Func: func(ctx context.Context, parser *gcmd.Parser) (err error) {
+
+		listener, err := net.Listen("tcp", parser.GetOpt("address", ":3389").String())
+		if err != nil {
+			return err
+		}
+		defer listener.Close()
+
+		g.Log().Infof(ctx, "mock ldap listening on %s, base dn %s", listener.Addr(), ldaptest.BaseDn)
+		g.Log().Infof(ctx, "users: %s", ldaptest.Users)
+
+		return ldaptest.Serve(ctx, listener)
+	},
+}
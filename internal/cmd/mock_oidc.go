@@ -0,0 +1,48 @@
+//go:build dev
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gcmd"
+
+	"gf-ant-react/utility/oidc/oidctest"
+)
+
+func init() {
+	DevCommands = append(DevCommands, &MockOidc)
+}
+
+// MockOidc 本地模拟 OIDC 身份提供方，仅用于开发和联调单点登录，不要在生产环境使用
+// 授权页面可以填写任意用户信息，签发的 ID Token 使用启动时生成的 RSA 密钥签名
+var MockOidc = gcmd.Command{
+	Name:  "mock-oidc",
+	Usage: "mock-oidc [--address=:8090] [--issuer=http://127.0.0.1:8090] [--clientId=gf-ant-react] [--clientSecret=]",
+	Brief: "start a local mock OIDC provider for development",
+	Arguments: []gcmd.Argument{
+		{Name: "address", Brief: "listening address, default :8090"},
+		{Name: "issuer", Brief: "issuer, must match issuer in oidc.yaml, default http://127.0.0.1:8090"},
+		{Name: "clientId", Brief: "client id, must match clientId in oidc.yaml, default gf-ant-react"},
+		{Name: "clientSecret", Brief: "client secret, empty for public client"},
+	},
+	Func: func(ctx context.Context, parser *gcmd.Parser) (err error) {
+
+		provider, err := oidctest.NewProvider(
+			parser.GetOpt("issuer", "http://127.0.0.1:8090").String(),
+			parser.GetOpt("clientId", "gf-ant-react").String(),
+			parser.GetOpt("clientSecret", "").String(),
+		)
+		if err != nil {
+			return err
+		}
+
+		s := g.Server("mock-oidc")
+		s.SetAddr(parser.GetOpt("address", ":8090").String())
+		s.BindHandler("/*", ghttp.WrapH(provider))
+		s.Run()
+		return nil
+	},
+}
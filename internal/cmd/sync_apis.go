@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+
+	adminLogic "gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcmd"
+)
+
+// SyncApis 把注册的路由同步到接口表，与 /sys/api/sync 接口相同
+// 路由在服务启动时才会注册，这里在本地随机端口上临时启动一个服务读取路由
+var SyncApis = gcmd.Command{
+	Name:  "sync-apis",
+	Usage: "sync-apis [--dry-run] [--disable-orphans]",
+	Brief: "sync registered routes into sys_apis",
+	Arguments: []gcmd.Argument{
+		{Name: "dry-run", Orphan: true, Brief: "only print the differences"},
+		{Name: "disable-orphans", Orphan: true, Brief: "disable apis that match no registered route"},
+	},
+	Func: func(ctx context.Context, parser *gcmd.Parser) (err error) {
+
+		s := g.Server("sync-apis")
+		s.SetAddr("127.0.0.1:0")
+		s.SetDumpRouterMap(false)
+		bindRoutes(s)
+		if err = s.Start(); err != nil {
+			return err
+		}
+		routes := adminLogic.SysApiLogic.ServerRoutes(s)
+		if err = s.Shutdown(); err != nil {
+			return err
+		}
+
+		result, err := adminLogic.SysApiLogic.Sync(ctx, routes, &adminModel.SysApiSyncParam{
+			DryRun:         parser.GetOpt("dry-run") != nil,
+			DisableOrphans: parser.GetOpt("disable-orphans") != nil,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, item := range result.Created {
+			g.Log().Infof(ctx, "新增: %-7s %-40s %-30s %s", item.Method, item.Url, item.PermissionCode, item.Name)
+		}
+		for _, item := range result.Orphans {
+			g.Log().Warningf(ctx, "孤立: %-7s %-40s %-30s %s", item.Method, item.Url, item.PermissionCode, item.Name)
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,33 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/utility/auth"
+)
+
+func (c *ControllerV1) AuthApiKeyCreate(ctx context.Context, req *v1.AuthApiKeyCreateReq) (res *v1.AuthApiKeyCreateRes, err error) {
+
+	userId := auth.GetUserId(ctx)
+	if userId == 0 {
+		return nil, errors.New("用户不存在")
+	}
+
+	res = &v1.AuthApiKeyCreateRes{}
+
+	res.SysApiKeyCreateResult, err = admin.AuthLogic.CreateApiKey(ctx, &adminModel.SysApiKeyCreateParam{
+		UserId:    userId,
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
@@ -0,0 +1,25 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	"gf-ant-react/utility/auth"
+)
+
+func (c *ControllerV1) AuthApiKeyRevoke(ctx context.Context, req *v1.AuthApiKeyRevokeReq) (res *v1.AuthApiKeyRevokeRes, err error) {
+
+	userId := auth.GetUserId(ctx)
+	if userId == 0 {
+		return nil, errors.New("用户不存在")
+	}
+
+	// 只能吊销自己的 API Key
+	if err = admin.AuthLogic.RevokeApiKey(ctx, userId, req.Id); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,31 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/utility/auth"
+)
+
+func (c *ControllerV1) AuthApiKeys(ctx context.Context, req *v1.AuthApiKeysReq) (res *v1.AuthApiKeysRes, err error) {
+
+	userId := auth.GetUserId(ctx)
+	if userId == 0 {
+		return nil, errors.New("用户不存在")
+	}
+
+	res = &v1.AuthApiKeysRes{}
+
+	// 获取当前用户未吊销的 API Key
+	res.SysApiKeyListResult, err = admin.AuthLogic.ApiKeys(ctx, &adminModel.SysApiKeyListParam{
+		UserId: userId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
@@ -10,6 +10,7 @@ import (
 
 func (c *ControllerV1) AuthCaptcha(ctx context.Context, req *v1.AuthCaptchaReq) (res *v1.AuthCaptchaRes, err error) {
 	captchaRes, err := admin.AuthLogic.Captcha(ctx, &adminModel.CaptchaReq{
+		Type:   req.Type,
 		Width:  req.Width,
 		Height: req.Height,
 		Length: 6,
@@ -19,6 +20,7 @@ func (c *ControllerV1) AuthCaptcha(ctx context.Context, req *v1.AuthCaptchaReq)
 	}
 	return &v1.AuthCaptchaRes{
 		Id:     captchaRes.Id,
+		Type:   captchaRes.Type,
 		Base64: captchaRes.Base64,
 	}, nil
 }
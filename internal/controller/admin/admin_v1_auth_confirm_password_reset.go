@@ -0,0 +1,26 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+func (c *ControllerV1) AuthConfirmPasswordReset(ctx context.Context, req *v1.AuthConfirmPasswordResetReq) (res *v1.AuthConfirmPasswordResetRes, err error) {
+
+	// 设置新密码
+	err = admin.AuthLogic.ConfirmPasswordReset(ctx, &adminModel.ConfirmPasswordResetReq{
+		Token:    req.Token,
+		Password: req.Password,
+		Ip:       g.RequestFromCtx(ctx).GetClientIp(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,25 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+func (c *ControllerV1) AuthForgotPassword(ctx context.Context, req *v1.AuthForgotPasswordReq) (res *v1.AuthForgotPasswordRes, err error) {
+
+	// 发送重置链接
+	err = admin.AuthLogic.ForgotPassword(ctx, &adminModel.ForgotPasswordReq{
+		Account: req.Account,
+		Ip:      g.RequestFromCtx(ctx).GetClientIp(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
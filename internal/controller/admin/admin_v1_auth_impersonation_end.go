@@ -0,0 +1,20 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	"gf-ant-react/utility/auth"
+)
+
+func (c *ControllerV1) AuthImpersonationEnd(ctx context.Context, req *v1.AuthImpersonationEndReq) (res *v1.AuthImpersonationEndRes, err error) {
+
+	// 结束代登录
+	err = admin.AuthLogic.EndImpersonation(ctx, auth.GetClaims(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,32 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+func (c *ControllerV1) AuthLoginMfa(ctx context.Context, req *v1.AuthLoginMfaReq) (res *v1.AuthLoginMfaRes, err error) {
+
+	r := g.RequestFromCtx(ctx)
+
+	// 两步验证登录
+	data, err := admin.AuthLogic.LoginMfa(ctx, &adminModel.LoginMfaReq{
+		MfaToken:     req.MfaToken,
+		Code:         req.Code,
+		RecoveryCode: req.RecoveryCode,
+		Ip:           r.GetClientIp(),
+		UserAgent:    r.UserAgent(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.AuthLoginMfaRes{
+		LoginRes: data,
+	}, nil
+}
@@ -0,0 +1,31 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+func (c *ControllerV1) AuthLoginOidc(ctx context.Context, req *v1.AuthLoginOidcReq) (res *v1.AuthLoginOidcRes, err error) {
+
+	r := g.RequestFromCtx(ctx)
+
+	// 单点登录
+	data, err := admin.AuthLogic.LoginOidc(ctx, &adminModel.LoginOidcReq{
+		Code:      req.Code,
+		State:     req.State,
+		Ip:        r.GetClientIp(),
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.AuthLoginOidcRes{
+		LoginRes: data,
+	}, nil
+}
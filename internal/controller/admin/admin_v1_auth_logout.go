@@ -0,0 +1,31 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/utility/auth"
+)
+
+func (c *ControllerV1) AuthLogout(ctx context.Context, req *v1.AuthLogoutReq) (res *v1.AuthLogoutRes, err error) {
+
+	claims := auth.GetClaims(ctx)
+	if claims == nil {
+		return nil, errors.New("没有登录")
+	}
+
+	// 退出登录
+	err = admin.AuthLogic.Logout(ctx, &adminModel.LogoutReq{
+		TokenId:   claims.ID,
+		SessionId: claims.SessionID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,28 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	"gf-ant-react/utility/auth"
+)
+
+func (c *ControllerV1) AuthMenus(ctx context.Context, req *v1.AuthMenusReq) (res *v1.AuthMenusRes, err error) {
+
+	userId := auth.GetUserId(ctx)
+	if userId == 0 {
+		return nil, errors.New("用户不存在")
+	}
+
+	res = &v1.AuthMenusRes{}
+
+	// 当前用户的菜单树
+	res.List, err = admin.AuthLogic.Menus(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
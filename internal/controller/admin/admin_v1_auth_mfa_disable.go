@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/utility/auth"
+)
+
+func (c *ControllerV1) AuthMfaDisable(ctx context.Context, req *v1.AuthMfaDisableReq) (res *v1.AuthMfaDisableRes, err error) {
+
+	userId := auth.GetUserId(ctx)
+	if userId == 0 {
+		return nil, errors.New("用户不存在")
+	}
+
+	// 关闭两步验证
+	err = admin.AuthLogic.MfaDisable(ctx, &adminModel.MfaCodeReq{
+		UserId: userId,
+		Code:   req.Code,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,32 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/utility/auth"
+)
+
+func (c *ControllerV1) AuthMfaEnable(ctx context.Context, req *v1.AuthMfaEnableReq) (res *v1.AuthMfaEnableRes, err error) {
+
+	userId := auth.GetUserId(ctx)
+	if userId == 0 {
+		return nil, errors.New("用户不存在")
+	}
+
+	res = &v1.AuthMfaEnableRes{}
+
+	// 开启两步验证，返回恢复码
+	res.MfaRecoveryCodesRes, err = admin.AuthLogic.MfaEnable(ctx, &adminModel.MfaCodeReq{
+		UserId: userId,
+		Code:   req.Code,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
@@ -0,0 +1,32 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/utility/auth"
+)
+
+func (c *ControllerV1) AuthMfaRecoveryCodes(ctx context.Context, req *v1.AuthMfaRecoveryCodesReq) (res *v1.AuthMfaRecoveryCodesRes, err error) {
+
+	userId := auth.GetUserId(ctx)
+	if userId == 0 {
+		return nil, errors.New("用户不存在")
+	}
+
+	res = &v1.AuthMfaRecoveryCodesRes{}
+
+	// 重新生成恢复码
+	res.MfaRecoveryCodesRes, err = admin.AuthLogic.MfaRecoveryCodes(ctx, &adminModel.MfaCodeReq{
+		UserId: userId,
+		Code:   req.Code,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
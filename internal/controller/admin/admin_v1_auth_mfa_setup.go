@@ -0,0 +1,28 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	"gf-ant-react/utility/auth"
+)
+
+func (c *ControllerV1) AuthMfaSetup(ctx context.Context, req *v1.AuthMfaSetupReq) (res *v1.AuthMfaSetupRes, err error) {
+
+	userId := auth.GetUserId(ctx)
+	if userId == 0 {
+		return nil, errors.New("用户不存在")
+	}
+
+	res = &v1.AuthMfaSetupRes{}
+
+	// 获取两步验证绑定信息
+	res.MfaSetupRes, err = admin.AuthLogic.MfaSetup(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
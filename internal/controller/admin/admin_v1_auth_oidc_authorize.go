@@ -0,0 +1,20 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+)
+
+func (c *ControllerV1) AuthOidcAuthorize(ctx context.Context, req *v1.AuthOidcAuthorizeReq) (res *v1.AuthOidcAuthorizeRes, err error) {
+
+	url, err := admin.AuthLogic.OidcAuthorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.AuthOidcAuthorizeRes{
+		Url: url,
+	}, nil
+}
@@ -0,0 +1,14 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+)
+
+func (c *ControllerV1) AuthOidcConfig(ctx context.Context, req *v1.AuthOidcConfigReq) (res *v1.AuthOidcConfigRes, err error) {
+	return &v1.AuthOidcConfigRes{
+		OidcConfigRes: admin.AuthLogic.OidcConfig(ctx),
+	}, nil
+}
@@ -21,5 +21,13 @@ func (c *ControllerV1) AuthProfile(ctx context.Context, req *v1.AuthProfileReq)
 		return nil, err
 	}
 
+	// 代登录标记
+	if claims := auth.GetClaims(ctx); claims != nil && claims.Impersonator != nil {
+		res.Impersonator = &adminModel.Impersonator{
+			UserId:   claims.Impersonator.UserID,
+			Username: claims.Impersonator.Username,
+		}
+	}
+
 	return
 }
@@ -0,0 +1,24 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+)
+
+func (c *ControllerV1) AuthRefresh(ctx context.Context, req *v1.AuthRefreshReq) (res *v1.AuthRefreshRes, err error) {
+
+	res = &v1.AuthRefreshRes{}
+
+	// 刷新令牌
+	res.TokenRes, err = admin.AuthLogic.Refresh(ctx, &adminModel.RefreshReq{
+		RefreshToken: req.RefreshToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
@@ -19,8 +19,9 @@ func (c *ControllerV1) AuthResetPassword(ctx context.Context, req *v1.AuthResetP
 
 	// 重置密码
 	err = admin.AuthLogic.ResetPassword(ctx, &adminModel.ResetPasswordReq{
-		Id:           userId,
-		PasswordHash: req.Password,
+		Id:          userId,
+		OldPassword: req.OldPassword,
+		Password:    req.Password,
 	})
 	if err != nil {
 		return nil, err
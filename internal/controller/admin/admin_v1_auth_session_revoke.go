@@ -0,0 +1,25 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	"gf-ant-react/utility/auth"
+)
+
+func (c *ControllerV1) AuthSessionRevoke(ctx context.Context, req *v1.AuthSessionRevokeReq) (res *v1.AuthSessionRevokeRes, err error) {
+
+	userId := auth.GetUserId(ctx)
+	if userId == 0 {
+		return nil, errors.New("用户不存在")
+	}
+
+	// 只能结束自己的会话
+	if err = admin.AuthLogic.TerminateOwnSession(ctx, userId, req.SessionId); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,31 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/utility/auth"
+)
+
+func (c *ControllerV1) AuthSessions(ctx context.Context, req *v1.AuthSessionsReq) (res *v1.AuthSessionsRes, err error) {
+
+	claims := auth.GetClaims(ctx)
+	if claims == nil {
+		return nil, errors.New("没有登录")
+	}
+
+	res = &v1.AuthSessionsRes{}
+
+	// 获取当前用户的所有会话
+	res.SysUserSessionListResult, err = admin.AuthLogic.Sessions(ctx, &adminModel.SysUserSessionListParam{
+		UserId: claims.UserID,
+	}, claims.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
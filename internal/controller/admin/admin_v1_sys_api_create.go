@@ -18,6 +18,10 @@ func (c *ControllerV1) SysApiCreate(ctx context.Context, req *v1.SysApiCreateReq
 		Sort:           req.Sort,
 		Status:         req.Status,
 		IsMenu:         req.IsMenu,
+		AuthType:       req.AuthType,
+		Icon:           req.Icon,
+		Route:          req.Route,
+		Component:      req.Component,
 		Description:    req.Description,
 	}
 
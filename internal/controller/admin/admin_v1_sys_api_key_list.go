@@ -0,0 +1,27 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+)
+
+func (c *ControllerV1) SysApiKeyList(ctx context.Context, req *v1.SysApiKeyListReq) (res *v1.SysApiKeyListRes, err error) {
+
+	result, err := admin.SysUserLogic.ApiKeys(ctx, &adminModel.SysApiKeyListParam{
+		Page:        req.Page,
+		Size:        req.Size,
+		UserId:      req.UserId,
+		WithRevoked: req.WithRevoked,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.SysApiKeyListRes{
+		List:  result.List,
+		Total: result.Total,
+	}, nil
+}
@@ -0,0 +1,17 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+)
+
+func (c *ControllerV1) SysApiKeyRevoke(ctx context.Context, req *v1.SysApiKeyRevokeReq) (res *v1.SysApiKeyRevokeRes, err error) {
+
+	if err := admin.SysUserLogic.RevokeApiKey(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
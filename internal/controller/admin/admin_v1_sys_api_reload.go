@@ -0,0 +1,12 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+)
+
+func (c *ControllerV1) SysApiReload(ctx context.Context, req *v1.SysApiReloadReq) (res *v1.SysApiReloadRes, err error) {
+	return &v1.SysApiReloadRes{SysPermissionCacheStats: admin.SysApiLogic.Reload(ctx)}, nil
+}
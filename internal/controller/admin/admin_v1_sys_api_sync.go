@@ -0,0 +1,24 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+func (c *ControllerV1) SysApiSync(ctx context.Context, req *v1.SysApiSyncReq) (res *v1.SysApiSyncRes, err error) {
+
+	result, err := admin.SysApiLogic.Sync(ctx, admin.SysApiLogic.ServerRoutes(g.Server()), &adminModel.SysApiSyncParam{
+		DryRun:         req.DryRun,
+		DisableOrphans: req.DisableOrphans,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.SysApiSyncRes{SysApiSyncResult: result}, nil
+}
@@ -19,6 +19,10 @@ func (c *ControllerV1) SysApiUpdate(ctx context.Context, req *v1.SysApiUpdateReq
 		Sort:           req.Sort,
 		Status:         req.Status,
 		IsMenu:         req.IsMenu,
+		AuthType:       req.AuthType,
+		Icon:           req.Icon,
+		Route:          req.Route,
+		Component:      req.Component,
 		Description:    req.Description,
 	}
 
@@ -0,0 +1,33 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+)
+
+func (c *ControllerV1) SysLoginLogList(ctx context.Context, req *v1.SysLoginLogListReq) (res *v1.SysLoginLogListRes, err error) {
+
+	result, err := admin.SysUserLogic.LoginLogs(ctx, &adminModel.SysLoginLogListParam{
+		Page:      req.Page,
+		Size:      req.Size,
+		UserId:    req.UserId,
+		Username:  req.Username,
+		Method:    req.Method,
+		Status:    req.Status,
+		Reason:    req.Reason,
+		Ip:        req.Ip,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.SysLoginLogListRes{
+		List:  result.List,
+		Total: result.Total,
+	}, nil
+}
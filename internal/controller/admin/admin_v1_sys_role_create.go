@@ -11,12 +11,15 @@ import (
 func (c *ControllerV1) SysRoleCreate(ctx context.Context, req *v1.SysRoleCreateReq) (res *v1.SysRoleCreateRes, err error) {
 	// 构建角色参数
 	roleParam := &adminModel.SysRoleCreateParam{
-		Name:        req.Name,
-		Description: req.Description,
-		DataScope:   req.DataScope,
-		Sort:        req.Sort,
-		Status:      req.Status,
-		ApiIds:      req.ApiIds,
+		ParentId:      req.ParentId,
+		Name:          req.Name,
+		Description:   req.Description,
+		DataScope:     req.DataScope,
+		Sort:          req.Sort,
+		Status:        req.Status,
+		RequireMfa:    req.RequireMfa,
+		ApiIds:        req.ApiIds,
+		DepartmentIds: req.DepartmentIds,
 	}
 
 	// 调用业务层创建角色
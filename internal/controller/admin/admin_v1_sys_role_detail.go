@@ -14,15 +14,32 @@ func (c *ControllerV1) SysRoleDetail(ctx context.Context, req *v1.SysRoleDetailR
 		return nil, err
 	}
 
+	// 从上级角色继承的权限
+	inheritedApis, err := admin.SysRoleLogic.GetInheritedApis(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	// 自定义数据权限范围的部门
+	departmentIds, err := admin.SysRoleLogic.GetDepartmentIds(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
 	return &v1.SysRoleDetailRes{
-		Id:          role.Id,
-		Name:        role.Name,
-		Description: role.Description,
-		DataScope:   role.DataScope,
-		Sort:        role.Sort,
-		Status:      role.Status,
-		ApiIds:      apiIds,
-		CreatedAt:   role.CreatedAt.String(),
-		UpdatedAt:   role.UpdatedAt.String(),
+		Id:            role.Id,
+		ParentId:      role.ParentId,
+		Name:          role.Name,
+		Description:   role.Description,
+		DataScope:     role.DataScope,
+		Sort:          role.Sort,
+		Status:        role.Status,
+		RequireMfa:    role.RequireMfa,
+		IsSuper:       role.IsSuper,
+		ApiIds:        apiIds,
+		DepartmentIds: departmentIds,
+		InheritedApis: inheritedApis,
+		CreatedAt:     role.CreatedAt.String(),
+		UpdatedAt:     role.UpdatedAt.String(),
 	}, nil
 }
@@ -11,13 +11,16 @@ import (
 func (c *ControllerV1) SysRoleUpdate(ctx context.Context, req *v1.SysRoleUpdateReq) (res *v1.SysRoleUpdateRes, err error) {
 	// 构建角色参数
 	roleParam := &adminModel.SysRoleUpdateParam{
-		Id:          req.Id,
-		Name:        req.Name,
-		Description: req.Description,
-		DataScope:   req.DataScope,
-		Sort:        req.Sort,
-		Status:      req.Status,
-		ApiIds:      req.ApiIds,
+		Id:            req.Id,
+		ParentId:      req.ParentId,
+		Name:          req.Name,
+		Description:   req.Description,
+		DataScope:     req.DataScope,
+		Sort:          req.Sort,
+		Status:        req.Status,
+		RequireMfa:    req.RequireMfa,
+		ApiIds:        req.ApiIds,
+		DepartmentIds: req.DepartmentIds,
 	}
 
 	// 调用业务层更新角色
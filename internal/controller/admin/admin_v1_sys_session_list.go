@@ -0,0 +1,34 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/utility/auth"
+)
+
+func (c *ControllerV1) SysSessionList(ctx context.Context, req *v1.SysSessionListReq) (res *v1.SysSessionListRes, err error) {
+
+	// 当前会话在列表中标记出来
+	var currentSessionId string
+	if claims := auth.GetClaims(ctx); claims != nil {
+		currentSessionId = claims.SessionID
+	}
+
+	result, err := admin.SysUserLogic.Sessions(ctx, &adminModel.SysUserSessionListParam{
+		Page:     req.Page,
+		Size:     req.Size,
+		UserId:   req.UserId,
+		Username: req.Username,
+	}, currentSessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.SysSessionListRes{
+		List:  result.List,
+		Total: result.Total,
+	}, nil
+}
@@ -0,0 +1,17 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+)
+
+func (c *ControllerV1) SysSessionRevoke(ctx context.Context, req *v1.SysSessionRevokeReq) (res *v1.SysSessionRevokeRes, err error) {
+
+	if err := admin.SysUserLogic.TerminateSession(ctx, req.SessionId); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
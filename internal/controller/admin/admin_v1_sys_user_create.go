@@ -11,6 +11,7 @@ import (
 func (c *ControllerV1) SysUserCreate(ctx context.Context, req *v1.SysUserCreateReq) (res *v1.SysUserCreateRes, err error) {
 	param := &adminModel.SysUserCreateParam{
 		Username:     req.Username,
+		AuthSource:   req.AuthSource,
 		PasswordHash: req.PasswordHash,
 		Email:        req.Email,
 		Mobile:       req.Mobile,
@@ -0,0 +1,27 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+)
+
+func (c *ControllerV1) SysUserDiagnosePermission(ctx context.Context, req *v1.SysUserDiagnosePermissionReq) (res *v1.SysUserDiagnosePermissionRes, err error) {
+
+	res = &v1.SysUserDiagnosePermissionRes{}
+
+	// 使用与鉴权中间件相同的判断
+	res.DiagnosePermissionRes, err = admin.SysUserLogic.DiagnosePermission(ctx, &adminModel.DiagnosePermissionReq{
+		UserId:         req.Id,
+		Method:         req.Method,
+		Url:            req.Url,
+		PermissionCode: req.PermissionCode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
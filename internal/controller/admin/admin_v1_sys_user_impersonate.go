@@ -0,0 +1,34 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/utility/auth"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+func (c *ControllerV1) SysUserImpersonate(ctx context.Context, req *v1.SysUserImpersonateReq) (res *v1.SysUserImpersonateRes, err error) {
+
+	// 获取请求IP和User-Agent
+	r := g.RequestFromCtx(ctx)
+
+	// 代登录
+	data, err := admin.AuthLogic.Impersonate(ctx, &adminModel.ImpersonateReq{
+		UserId:    req.Id,
+		Operator:  auth.GetClaims(ctx),
+		Reason:    req.Reason,
+		Ip:        r.GetClientIp(),
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.SysUserImpersonateRes{
+		LoginRes: data,
+	}, nil
+}
@@ -0,0 +1,20 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+)
+
+func (c *ControllerV1) SysUserLdapSync(ctx context.Context, req *v1.SysUserLdapSyncReq) (res *v1.SysUserLdapSyncRes, err error) {
+
+	result, err := admin.SysUserLogic.SyncLdap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.SysUserLdapSyncRes{
+		LdapSyncRes: result,
+	}, nil
+}
@@ -0,0 +1,17 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+)
+
+func (c *ControllerV1) SysUserResetMfa(ctx context.Context, req *v1.SysUserResetMfaReq) (res *v1.SysUserResetMfaRes, err error) {
+
+	if err := admin.SysUserLogic.ResetMfa(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
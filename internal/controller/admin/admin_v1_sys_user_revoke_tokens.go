@@ -0,0 +1,17 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+)
+
+func (c *ControllerV1) SysUserRevokeTokens(ctx context.Context, req *v1.SysUserRevokeTokensReq) (res *v1.SysUserRevokeTokensRes, err error) {
+
+	if err := admin.SysUserLogic.RevokeTokens(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,17 @@
+package admin
+
+import (
+	"context"
+
+	v1 "gf-ant-react/api/admin/v1"
+	"gf-ant-react/internal/logic/admin"
+)
+
+func (c *ControllerV1) SysUserUnlock(ctx context.Context, req *v1.SysUserUnlockReq) (res *v1.SysUserUnlockRes, err error) {
+
+	if err := admin.SysUserLogic.Unlock(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -12,6 +12,7 @@ func (c *ControllerV1) SysUserUpdate(ctx context.Context, req *v1.SysUserUpdateR
 	param := &adminModel.SysUserUpdateParam{
 		Id:           req.Id,
 		Username:     req.Username,
+		AuthSource:   req.AuthSource,
 		Email:        req.Email,
 		Mobile:       req.Mobile,
 		DepartmentId: req.DepartmentId,
@@ -30,6 +30,10 @@ type SysApisColumns struct {
 	Sort           string // 排序
 	Status         string // 状态：0=禁用，1=启用
 	IsMenu         string // 是否为菜单：0=否，1=是
+	AuthType       string // 鉴权方式：0=需要权限，1=只需要登录，2=不需要鉴权，为空时按 auth.yaml 初始化
+	Icon           string // 菜单图标
+	Route          string // 菜单前端路由
+	Component      string // 菜单前端组件
 	Description    string // 描述
 	CreatedAt      string //
 	UpdatedAt      string //
@@ -47,6 +51,10 @@ var sysApisColumns = SysApisColumns{
 	Sort:           "sort",
 	Status:         "status",
 	IsMenu:         "is_menu",
+	AuthType:       "auth_type",
+	Icon:           "icon",
+	Route:          "route",
+	Component:      "component",
 	Description:    "description",
 	CreatedAt:      "created_at",
 	UpdatedAt:      "updated_at",
@@ -22,11 +22,14 @@ type SysRolesDao struct {
 // SysRolesColumns defines and stores column names for the table sys_roles.
 type SysRolesColumns struct {
 	Id          string // 角色ID
+	ParentId    string // 上级角色ID，0表示没有上级，继承上级角色的接口权限
 	Name        string // 角色名称 (兼具标识作用)
 	Description string // 描述
 	DataScope   string // 数据权限范围: 1=全部, 2=本部门, 3=本部门及子部门, 4=仅本人, 5=自定义
 	Sort        string // 排序
 	Status      string // 状态: 0=禁用, 1=启用
+	RequireMfa  string // 是否要求两步验证: 0=否, 1=是
+	IsSuper     string // 超级管理员: 0=否, 1=是，拥有所有接口权限，不能删除或禁用
 	CreatedAt   string //
 	UpdatedAt   string //
 	DeletedAt   string //
@@ -35,11 +38,14 @@ type SysRolesColumns struct {
 // sysRolesColumns holds the columns for the table sys_roles.
 var sysRolesColumns = SysRolesColumns{
 	Id:          "id",
+	ParentId:    "parent_id",
 	Name:        "name",
 	Description: "description",
 	DataScope:   "data_scope",
 	Sort:        "sort",
 	Status:      "status",
+	RequireMfa:  "require_mfa",
+	IsSuper:     "is_super",
 	CreatedAt:   "created_at",
 	UpdatedAt:   "updated_at",
 	DeletedAt:   "deleted_at",
@@ -21,38 +21,46 @@ type SysUsersDao struct {
 
 // SysUsersColumns defines and stores column names for the table sys_users.
 type SysUsersColumns struct {
-	Id            string //
-	Username      string // 用户名
-	PasswordHash  string // 密码哈希
-	Email         string // 邮箱
-	Mobile        string // 手机号
-	DepartmentId  string // 所属部门ID
-	Status        string // 状态: 0=禁用, 1=正常, 2=锁定
-	LastLoginAt   string // 最后登录时间
-	LastLoginIp   string // 最后登录IP
-	LoginAttempts string // 登录失败次数
-	LockedUntil   string // 锁定到期时间
-	CreatedAt     string //
-	UpdatedAt     string //
-	DeletedAt     string // 软删除时间 (NULL=未删除)
+	Id                string //
+	Username          string // 用户名
+	PasswordHash      string // 密码哈希
+	PasswordChangedAt string // 密码修改时间
+	AuthSource        string // 认证方式: local=本地密码, ldap=LDAP
+	Email             string // 邮箱
+	Mobile            string // 手机号
+	DepartmentId      string // 所属部门ID
+	Status            string // 状态: 0=禁用, 1=正常, 2=锁定
+	LastLoginAt       string // 最后登录时间
+	LastLoginIp       string // 最后登录IP
+	LoginAttempts     string // 登录失败次数
+	LockedUntil       string // 锁定到期时间
+	TotpSecret        string // 两步验证TOTP密钥
+	TotpEnabled       string // 两步验证: 0=未开启, 1=已开启
+	CreatedAt         string //
+	UpdatedAt         string //
+	DeletedAt         string // 软删除时间 (NULL=未删除)
 }
 
 // sysUsersColumns holds the columns for the table sys_users.
 var sysUsersColumns = SysUsersColumns{
-	Id:            "id",
-	Username:      "username",
-	PasswordHash:  "password_hash",
-	Email:         "email",
-	Mobile:        "mobile",
-	DepartmentId:  "department_id",
-	Status:        "status",
-	LastLoginAt:   "last_login_at",
-	LastLoginIp:   "last_login_ip",
-	LoginAttempts: "login_attempts",
-	LockedUntil:   "locked_until",
-	CreatedAt:     "created_at",
-	UpdatedAt:     "updated_at",
-	DeletedAt:     "deleted_at",
+	Id:                "id",
+	Username:          "username",
+	PasswordHash:      "password_hash",
+	PasswordChangedAt: "password_changed_at",
+	AuthSource:        "auth_source",
+	Email:             "email",
+	Mobile:            "mobile",
+	DepartmentId:      "department_id",
+	Status:            "status",
+	LastLoginAt:       "last_login_at",
+	LastLoginIp:       "last_login_ip",
+	LoginAttempts:     "login_attempts",
+	LockedUntil:       "locked_until",
+	TotpSecret:        "totp_secret",
+	TotpEnabled:       "totp_enabled",
+	CreatedAt:         "created_at",
+	UpdatedAt:         "updated_at",
+	DeletedAt:         "deleted_at",
 }
 
 // NewSysUsersDao creates and returns a new DAO object for table data access.
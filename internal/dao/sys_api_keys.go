@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"gf-ant-react/internal/dao/internal"
+)
+
+// sysApiKeysDao is the data access object for the table sys_api_keys.
+// You can define custom methods on it to extend its functionality as needed.
+type sysApiKeysDao struct {
+	*internal.SysApiKeysDao
+}
+
+var (
+	// SysApiKeys is a globally accessible object for table sys_api_keys operations.
+	SysApiKeys = sysApiKeysDao{internal.NewSysApiKeysDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"gf-ant-react/internal/dao/internal"
+)
+
+// sysLoginLogsDao is the data access object for the table sys_login_logs.
+// You can define custom methods on it to extend its functionality as needed.
+type sysLoginLogsDao struct {
+	*internal.SysLoginLogsDao
+}
+
+var (
+	// SysLoginLogs is a globally accessible object for table sys_login_logs operations.
+	SysLoginLogs = sysLoginLogsDao{internal.NewSysLoginLogsDao()}
+)
+
+// Add your custom methods and functionality below.
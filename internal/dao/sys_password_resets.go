@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"gf-ant-react/internal/dao/internal"
+)
+
+// sysPasswordResetsDao is the data access object for the table sys_password_resets.
+// You can define custom methods on it to extend its functionality as needed.
+type sysPasswordResetsDao struct {
+	*internal.SysPasswordResetsDao
+}
+
+var (
+	// SysPasswordResets is a globally accessible object for table sys_password_resets operations.
+	SysPasswordResets = sysPasswordResetsDao{internal.NewSysPasswordResetsDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"gf-ant-react/internal/dao/internal"
+)
+
+// sysRefreshTokensDao is the data access object for the table sys_refresh_tokens.
+// You can define custom methods on it to extend its functionality as needed.
+type sysRefreshTokensDao struct {
+	*internal.SysRefreshTokensDao
+}
+
+var (
+	// SysRefreshTokens is a globally accessible object for table sys_refresh_tokens operations.
+	SysRefreshTokens = sysRefreshTokensDao{internal.NewSysRefreshTokensDao()}
+)
+
+// Add your custom methods and functionality below.
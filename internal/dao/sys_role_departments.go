@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"gf-ant-react/internal/dao/internal"
+)
+
+// sysRoleDepartmentsDao is the data access object for the table sys_role_departments.
+// You can define custom methods on it to extend its functionality as needed.
+type sysRoleDepartmentsDao struct {
+	*internal.SysRoleDepartmentsDao
+}
+
+var (
+	// SysRoleDepartments is a globally accessible object for table sys_role_departments operations.
+	SysRoleDepartments = sysRoleDepartmentsDao{internal.NewSysRoleDepartmentsDao()}
+)
+
+// Add your custom methods and functionality below.
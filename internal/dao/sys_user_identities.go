@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"gf-ant-react/internal/dao/internal"
+)
+
+// sysUserIdentitiesDao is the data access object for the table sys_user_identities.
+// You can define custom methods on it to extend its functionality as needed.
+type sysUserIdentitiesDao struct {
+	*internal.SysUserIdentitiesDao
+}
+
+var (
+	// SysUserIdentities is a globally accessible object for table sys_user_identities operations.
+	SysUserIdentities = sysUserIdentitiesDao{internal.NewSysUserIdentitiesDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"gf-ant-react/internal/dao/internal"
+)
+
+// sysUserPasswordHistoriesDao is the data access object for the table sys_user_password_histories.
+// You can define custom methods on it to extend its functionality as needed.
+type sysUserPasswordHistoriesDao struct {
+	*internal.SysUserPasswordHistoriesDao
+}
+
+var (
+	// SysUserPasswordHistories is a globally accessible object for table sys_user_password_histories operations.
+	SysUserPasswordHistories = sysUserPasswordHistoriesDao{internal.NewSysUserPasswordHistoriesDao()}
+)
+
+// Add your custom methods and functionality below.
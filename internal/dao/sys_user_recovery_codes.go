@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"gf-ant-react/internal/dao/internal"
+)
+
+// sysUserRecoveryCodesDao is the data access object for the table sys_user_recovery_codes.
+// You can define custom methods on it to extend its functionality as needed.
+type sysUserRecoveryCodesDao struct {
+	*internal.SysUserRecoveryCodesDao
+}
+
+var (
+	// SysUserRecoveryCodes is a globally accessible object for table sys_user_recovery_codes operations.
+	SysUserRecoveryCodes = sysUserRecoveryCodesDao{internal.NewSysUserRecoveryCodesDao()}
+)
+
+// Add your custom methods and functionality below.
@@ -0,0 +1,22 @@
+// =================================================================================
+// This file is auto-generated by the GoFrame CLI tool. You may modify it as needed.
+// =================================================================================
+
+package dao
+
+import (
+	"gf-ant-react/internal/dao/internal"
+)
+
+// sysUserSessionsDao is the data access object for the table sys_user_sessions.
+// You can define custom methods on it to extend its functionality as needed.
+type sysUserSessionsDao struct {
+	*internal.SysUserSessionsDao
+}
+
+var (
+	// SysUserSessions is a globally accessible object for table sys_user_sessions operations.
+	SysUserSessions = sysUserSessionsDao{internal.NewSysUserSessionsDao()}
+)
+
+// Add your custom methods and functionality below.
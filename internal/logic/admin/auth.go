@@ -6,11 +6,20 @@ import (
 	"time"
 
 	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
 	"gf-ant-react/internal/service"
 	"gf-ant-react/utility/captcha"
 	errorUtil "gf-ant-react/utility/error"
 	"gf-ant-react/utility/jwt"
 	"gf-ant-react/utility/password"
+	"gf-ant-react/utility/revocation"
+	"gf-ant-react/utility/useragent"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/guid"
 )
 
 type sAuthLogic struct{}
@@ -19,54 +28,245 @@ var AuthLogic = &sAuthLogic{}
 
 // 验证码
 func (c *sAuthLogic) Captcha(ctx context.Context, req *adminModel.CaptchaReq) (res *adminModel.CaptchaRes, err error) {
+
+	// 验证码类型：请求参数 > 网站设置 > 配置文件
+	captchaType := req.Type
+	if captchaType == "" {
+		setting, err := service.CmsSiteSettingService.GetSiteSettingByKey(ctx, adminModel.SettingKeyCaptchaType)
+		if err != nil {
+			return nil, err
+		}
+		if setting != nil {
+			captchaType = setting.SettingValue
+		}
+	}
+	if captchaType == "" {
+		captchaType = g.Cfg("captcha").MustGet(ctx, "type", captcha.TypeDigit).String()
+	}
+
 	// 生成验证码
-	id, b64s, err := captcha.CaptchaUtility.GenerateCaptchaHandler(req.Width, req.Height, req.Length)
+	id, b64s, err := captcha.CaptchaUtility.GenerateCaptchaHandler(captchaType, req.Width, req.Height, req.Length)
 	if err != nil {
 		return nil, err
 	}
 	return &adminModel.CaptchaRes{
 		Id:     id,
+		Type:   captchaType,
 		Base64: b64s,
 	}, nil
 }
 
-// 登录
+// 校验登录验证码
+// 开启 onlyAfterFailure 时，只有该用户名或IP登录失败过才要求验证码；客户端主动提交的验证码总是校验
+func (c *sAuthLogic) verifyCaptcha(ctx context.Context, req *adminModel.LoginReq) error {
+
+	required, err := captcha.CaptchaUtility.IsRequired(ctx, c.captchaFailureKeys(req.Username, req.Ip)...)
+	if err != nil {
+		return err
+	}
+
+	if !required && req.CaptchaId == "" {
+		return nil
+	}
+
+	if req.CaptchaId == "" || req.CaptchaCode == "" {
+		return errors.New("请输入验证码")
+	}
+
+	if !captcha.CaptchaUtility.VerifyCaptchaHandler(req.CaptchaId, req.CaptchaCode) {
+		return errors.New("验证码错误")
+	}
+
+	return nil
+}
+
+// 登录失败标记的键，按用户名和IP分别记录
+func (c *sAuthLogic) captchaFailureKeys(username, ip string) []string {
+	return []string{"username:" + username, "ip:" + ip}
+}
+
+// 登录，每次尝试都记录登录日志
 func (c *sAuthLogic) Login(ctx context.Context, req *adminModel.LoginReq) (res *adminModel.LoginRes, err error) {
 
+	attempt := &adminModel.LoginAttempt{
+		Username:  req.Username,
+		Method:    adminModel.LoginMethodPassword,
+		Ip:        req.Ip,
+		UserAgent: req.UserAgent,
+	}
+
+	res, err = c.login(ctx, req, attempt)
+	c.recordLogin(ctx, attempt, res, err)
+
+	return res, err
+}
+
+func (c *sAuthLogic) login(ctx context.Context, req *adminModel.LoginReq, attempt *adminModel.LoginAttempt) (res *adminModel.LoginRes, err error) {
+
 	res = &adminModel.LoginRes{}
 
+	// 校验验证码
+	if err = c.verifyCaptcha(ctx, req); err != nil {
+		attempt.Reason = adminModel.LoginReasonCaptcha
+		return nil, err
+	}
+
 	// 获取用户信息
 	res.User, err = service.SysUserService.GetByUsername(ctx, req.Username)
 	if err != nil {
 		return nil, err
 	}
 
+	// 本地不存在的用户名，开启 LDAP 即时创建时尝试从 LDAP 认证并创建
+	var authenticated bool
+	if res.User == nil {
+		res.User, err = c.provisionLdapUser(ctx, req.Username, req.Password)
+		if err != nil {
+			return nil, err
+		}
+		authenticated = res.User != nil
+	}
+
+	// 用户不存在和密码错误返回相同的提示，避免暴露用户名是否存在
 	if res.User == nil {
-		return nil, errors.New("用户名或者密码错误")
+		attempt.Reason = adminModel.LoginReasonUserNotFound
+		if err = captcha.CaptchaUtility.RecordFailure(ctx, c.captchaFailureKeys(req.Username, req.Ip)...); err != nil {
+			return nil, err
+		}
+		return nil, errorUtil.ErrorLoginFailed
+	}
+	attempt.UserId = res.User.Id
+
+	// 锁定期间不再校验密码，返回与密码错误相同的提示，避免暴露用户名是否存在
+	if res.User.Status == adminModel.UserStatusLocked && c.checkUserStatus(res.User) != nil {
+		attempt.Reason = adminModel.LoginReasonLocked
+		if err = captcha.CaptchaUtility.RecordFailure(ctx, c.captchaFailureKeys(req.Username, req.Ip)...); err != nil {
+			return nil, err
+		}
+		return nil, errorUtil.ErrorLoginFailed
+	}
+
+	// 按用户的认证方式校验密码
+	if !authenticated {
+		authenticated, err = c.authenticate(ctx, res.User, req.Password)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !authenticated {
+		if err = captcha.CaptchaUtility.RecordFailure(ctx, c.captchaFailureKeys(req.Username, req.Ip)...); err != nil {
+			return nil, err
+		}
+		locked, err := c.loginFailed(ctx, res.User)
+		if err != nil {
+			return nil, err
+		}
+		if locked {
+			attempt.Reason = adminModel.LoginReasonLockout
+		}
+		return nil, errorUtil.ErrorLoginFailed
+	}
+
+	// 检查账号状态
+	if err = c.checkUserStatus(res.User); err != nil {
+		return nil, err
+	}
+
+	// 开启两步验证或角色要求两步验证时，先返回登录挑战
+	challengeRes, err := c.mfaChallenge(ctx, res.User)
+	if err != nil {
+		return nil, err
+	}
+	if challengeRes != nil {
+		return challengeRes, nil
+	}
+
+	return c.completeLogin(ctx, res.User, req.Ip, req.UserAgent)
+}
+
+// 完成登录：加载角色权限、签发令牌并记录登录信息
+func (c *sAuthLogic) completeLogin(ctx context.Context, user *entity.SysUsers, ip string, userAgent string) (res *adminModel.LoginRes, err error) {
+
+	res = &adminModel.LoginRes{User: user}
+
+	// 获取用户角色和权限
+	if err = c.loadAccess(ctx, res); err != nil {
+		return nil, err
+	}
+
+	// 签发令牌，每次登录开启一个新的会话，会话ID同时作为刷新令牌族ID
+	sessionId := guid.S()
+	res.TokenRes, err = c.issueToken(ctx, res.User.Id, res.User.Username, sessionId)
+	if err != nil {
+		return nil, err
 	}
 
-	// 校验密码
-	if !password.CheckPasswordHash(req.Password, res.User.PasswordHash) {
-		return nil, errors.New("密码错误")
+	// 记录登录会话
+	err = service.SysUserSessionService.Create(ctx, &entity.SysUserSessions{
+		SessionId:  sessionId,
+		UserId:     res.User.Id,
+		Device:     useragent.Device(userAgent),
+		Ip:         ip,
+		UserAgent:  gstr.SubStr(userAgent, 0, 500),
+		LastSeenIp: ip,
+		LastSeenAt: gtime.Now(),
+		ExpiresAt:  gtime.NewFromTime(res.Refresh),
+		CreatedAt:  gtime.Now(),
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	// 更新登录时间和登录IP
+	err = service.SysUserService.UpdateLoginInfo(ctx, res.User.Id, ip)
+	if err != nil {
+		return nil, err
+	}
+
+	// 登录成功后清除失败标记
+	if err = captcha.CaptchaUtility.ClearFailure(ctx, c.captchaFailureKeys(res.User.Username, ip)...); err != nil {
+		return nil, err
+	}
+
+	// 密码过期时提示修改密码
+	res.MustChangePassword, err = c.passwordExpired(ctx, res.User)
+	if err != nil {
+		return nil, err
+	}
+
+	// 去掉密码信息
+	res.User.PasswordHash = ""
+	res.User.TotpSecret = ""
+
+	return
+}
+
+// 加载用户的角色和可访问的API
+func (c *sAuthLogic) loadAccess(ctx context.Context, res *adminModel.LoginRes) (err error) {
+
 	// 获取用户角色
 	res.Roles, err = service.SysRoleService.GetUserRoles(ctx, res.User.Id)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// 存在角色才查询
 	if len(res.Roles) > 0 {
 		// 转换角色ID数组
+		super := false
 		for _, role := range res.Roles {
 			res.RoleIds = append(res.RoleIds, role.Id)
+			super = super || role.IsSuper
 		}
 
-		// 获取用户角色关联的API
-		res.Apis, err = service.SysApiService.GetApisByRoleIds(ctx, res.RoleIds)
+		// 获取用户角色关联的API，超级管理员拥有所有启用的API
+		if super {
+			res.Apis, err = service.SysApiService.GetEnabled(ctx)
+		} else {
+			res.Apis, err = service.SysApiService.GetApisByRoleIds(ctx, res.RoleIds)
+		}
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		if len(res.Apis) > 0 {
@@ -76,8 +276,150 @@ func (c *sAuthLogic) Login(ctx context.Context, req *adminModel.LoginReq) (res *
 		}
 	}
 
+	return nil
+}
+
+// 用户可访问的权限码
+func (c *sAuthLogic) userApiCodes(ctx context.Context, userId uint64) ([]string, error) {
+	res := &adminModel.LoginRes{User: &entity.SysUsers{Id: userId}}
+	if err := c.loadAccess(ctx, res); err != nil {
+		return nil, err
+	}
+	return res.ApiCodes, nil
+}
+
+// 按当前配置重新计算密码哈希
+// 失败不影响登录，下次登录时会再次尝试
+func (c *sAuthLogic) rehashPassword(ctx context.Context, user *entity.SysUsers, plain string) {
+
+	if !password.NeedsRehash(user.PasswordHash) {
+		return
+	}
+
+	passwordHash, err := password.HashPassword(plain)
+	if err == nil {
+		err = service.SysUserService.UpdatePasswordHash(ctx, user.Id, passwordHash)
+	}
+	if err != nil {
+		g.Log().Warningf(ctx, "重新计算密码哈希失败: userId=%d err=%v", user.Id, err)
+	}
+}
+
+// 检查账号状态
+// 锁定到期的账号视为正常，下次登录成功时解除锁定
+func (c *sAuthLogic) checkUserStatus(user *entity.SysUsers) error {
+	switch user.Status {
+	case adminModel.UserStatusEnabled:
+		return nil
+	case adminModel.UserStatusLocked:
+		if user.LockedUntil == nil {
+			return errorUtil.ErrorUserLocked
+		}
+		if user.LockedUntil.Before(gtime.Now()) {
+			return nil
+		}
+		return gerror.NewCodef(gerror.Code(errorUtil.ErrorUserLocked), "登录失败次数过多，账号已锁定，请于 %s 后重试", user.LockedUntil.Format("Y-m-d H:i:s"))
+	default:
+		return errorUtil.ErrorUserDisabled
+	}
+}
+
+// 登录失败，累计失败次数，返回本次失败后是否锁定
+// 每累计 maxAttempts 次失败锁定一次，锁定时长逐次翻倍
+func (c *sAuthLogic) loginFailed(ctx context.Context, user *entity.SysUsers) (bool, error) {
+
+	maxAttempts := g.Cfg("auth").MustGet(ctx, "loginLock.maxAttempts", 5).Uint()
+	if maxAttempts == 0 {
+		return false, nil
+	}
+
+	attempts, err := service.SysUserService.IncreaseLoginAttempts(ctx, user.Id)
+	if err != nil {
+		return false, err
+	}
+
+	if attempts < maxAttempts || attempts%maxAttempts != 0 {
+		return false, nil
+	}
+
+	// 计算锁定时长
+	lockMinutes := g.Cfg("auth").MustGet(ctx, "loginLock.lockMinutes", 15).Int64()
+	maxLockMinutes := g.Cfg("auth").MustGet(ctx, "loginLock.maxLockMinutes", 1440).Int64()
+	for i := uint(1); i < attempts/maxAttempts && lockMinutes < maxLockMinutes; i++ {
+		lockMinutes *= 2
+	}
+	if lockMinutes > maxLockMinutes {
+		lockMinutes = maxLockMinutes
+	}
+
+	lockedUntil := gtime.Now().Add(time.Duration(lockMinutes) * time.Minute)
+
+	g.Log().Warningf(ctx, "用户连续登录失败 %d 次，锁定至 %s: userId=%d", attempts, lockedUntil, user.Id)
+
+	return true, service.SysUserService.Lock(ctx, user.Id, lockedUntil)
+}
+
+// 刷新令牌
+// 刷新令牌只能使用一次，使用后签发新的刷新令牌；已使用的刷新令牌被重放时吊销整个令牌族
+func (c *sAuthLogic) Refresh(ctx context.Context, req *adminModel.RefreshReq) (res *adminModel.TokenRes, err error) {
+
+	// 获取刷新令牌
+	refreshToken, err := service.SysRefreshTokenService.GetByHash(ctx, jwt.JwtUtility.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		return nil, err
+	}
+
+	if refreshToken == nil || refreshToken.RevokedAt != nil || refreshToken.ExpiresAt.Before(gtime.Now()) {
+		return nil, errorUtil.ErrorRefreshTokenInvalid
+	}
+
+	// 已使用的刷新令牌再次出现，说明令牌可能已泄露
+	if refreshToken.UsedAt != nil {
+		return nil, c.revokeTokenFamily(ctx, refreshToken)
+	}
+
+	// 标记为已使用，并发请求中只有一个能成功
+	ok, err := service.SysRefreshTokenService.MarkUsed(ctx, refreshToken.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, c.revokeTokenFamily(ctx, refreshToken)
+	}
+
+	// 检查用户状态
+	user, _, err := service.SysUserService.GetById(ctx, refreshToken.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errorUtil.ErrorRefreshTokenInvalid
+	}
+	if err = c.checkUserStatus(user); err != nil {
+		return nil, err
+	}
+
+	// 在同一令牌族中签发新令牌
+	res, err = c.issueToken(ctx, user.Id, user.Username, refreshToken.FamilyId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 延长会话有效期
+	if err = service.SysUserSessionService.Extend(ctx, refreshToken.FamilyId, gtime.NewFromTime(res.Refresh)); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// 签发访问令牌和刷新令牌
+func (c *sAuthLogic) issueToken(ctx context.Context, userId uint64, username string, familyId string) (res *adminModel.TokenRes, err error) {
+
+	res = &adminModel.TokenRes{}
+
 	// 生成token
-	res.Token, err = jwt.JwtUtility.GenerateToken(res.User.Id, res.User.Username)
+	res.Token, err = jwt.JwtUtility.GenerateToken(userId, username, familyId)
 	if err != nil {
 		return nil, err
 	}
@@ -88,74 +430,125 @@ func (c *sAuthLogic) Login(ctx context.Context, req *adminModel.LoginReq) (res *
 	// 刷新时间
 	res.Refresh = res.Expire.Add(time.Duration(jwt.JwtUtility.RefreshExpire) * time.Second)
 
-	// 更新登录时间和登录IP
-	err = service.SysUserService.UpdateLoginInfo(ctx, res.User.Id, req.Ip)
+	// 生成刷新令牌，数据库只保存哈希
+	var tokenHash string
+	res.RefreshToken, tokenHash, err = jwt.JwtUtility.GenerateRefreshToken()
 	if err != nil {
 		return nil, err
 	}
 
-	// 去掉密码信息
-	res.User.PasswordHash = ""
+	err = service.SysRefreshTokenService.Create(ctx, &entity.SysRefreshTokens{
+		UserId:    userId,
+		FamilyId:  familyId,
+		TokenHash: tokenHash,
+		ExpiresAt: gtime.NewFromTime(res.Refresh),
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	return
+	return res, nil
+}
+
+// 吊销刷新令牌所在的整个令牌族
+func (c *sAuthLogic) revokeTokenFamily(ctx context.Context, refreshToken *entity.SysRefreshTokens) error {
+
+	g.Log().Warningf(ctx, "刷新令牌重复使用，吊销令牌族: userId=%d familyId=%s", refreshToken.UserId, refreshToken.FamilyId)
+
+	// 令牌族即登录会话，已签发的访问令牌一并失效
+	if err := c.TerminateSession(ctx, refreshToken.FamilyId); err != nil {
+		return err
+	}
+
+	return errorUtil.ErrorRefreshTokenReused
+}
+
+// 退出登录
+func (c *sAuthLogic) Logout(ctx context.Context, req *adminModel.LogoutReq) error {
+
+	// 吊销当前访问令牌
+	if err := revocation.RevocationUtility.RevokeToken(ctx, req.TokenId, req.ExpiresAt); err != nil {
+		return err
+	}
+
+	// 吊销当前登录的刷新令牌并结束会话
+	if req.SessionId != "" {
+		if err := service.SysRefreshTokenService.RevokeFamily(ctx, req.SessionId); err != nil {
+			return err
+		}
+		return service.SysUserSessionService.Revoke(ctx, req.SessionId)
+	}
+
+	return nil
 }
 
-// 重置密码
+// 吊销用户的所有令牌
+// 用户被禁用、锁定或密码被重置时调用
+func (c *sAuthLogic) RevokeUserTokens(ctx context.Context, userId uint64) error {
+
+	// 吊销已签发的访问令牌
+	if err := revocation.RevocationUtility.RevokeUser(ctx, userId); err != nil {
+		return err
+	}
+
+	// 吊销刷新令牌
+	if err := service.SysRefreshTokenService.RevokeByUserId(ctx, userId); err != nil {
+		return err
+	}
+
+	// 结束所有会话
+	return service.SysUserSessionService.RevokeByUserId(ctx, userId)
+}
+
+// 重置密码，需要校验原密码
 func (c *sAuthLogic) ResetPassword(ctx context.Context, req *adminModel.ResetPasswordReq) error {
 
 	// 检查用户是否存在
-	exist, err := service.SysUserService.CheckById(ctx, req.Id)
+	user, _, err := service.SysUserService.GetById(ctx, req.Id)
 	if err != nil {
 		return err
 	}
-	if !exist {
+	if user == nil {
 		return errors.New("用户不存在")
 	}
+	if !c.isLocalUser(user) {
+		return errors.New("该账号使用外部认证，请在目录服务中修改密码")
+	}
 
-	// 加密密码
-	req.PasswordHash, err = password.HashPassword(req.PasswordHash)
+	// 校验原密码
+	passwordHash, err := service.SysUserService.GetPasswordHash(ctx, req.Id)
 	if err != nil {
 		return err
 	}
+	if !password.CheckPasswordHash(req.OldPassword, passwordHash) {
+		return errors.New("原密码错误")
+	}
 
-	return service.SysUserService.ResetPassword(ctx, req.Id, req)
+	return c.ChangePassword(ctx, user.Id, user.Username, req.Password)
 }
 
 // 验证用户是否有权限访问接口
 func (c *sAuthLogic) CheckPermission(ctx context.Context, req *adminModel.CheckPermissionReq) (bool, error) {
 
-	// 获取用户信息
-	user, roles, err := service.SysUserService.GetById(ctx, req.UserId)
+	// 获取用户角色
+	roles, err := c.permissionRoles(ctx, req.UserId)
 	if err != nil {
 		return false, err
 	}
 
-	// 检查账号是否禁用
-	if user.Status != adminModel.UserStatusEnabled {
-		// 检查账号是否锁定
-		if user.Status == adminModel.UserStatusLocked {
-			return false, errorUtil.ErrorUserLocked
-		}
-		return false, errorUtil.ErrorUserDisabled
-	}
-
-	// 检查用户是否存在
-	if user == nil {
-		return false, errors.New("用户不存在")
-	}
-
-	if len(roles) == 0 {
-		return false, errors.New("用户没有角色，不能访问接口")
-	}
-
 	// 获取接口权限码
-	permissionCode, err := service.SysApiService.GetPermissionCode(ctx, req.Method, req.Url)
+	permissionCode, err := service.SysPermissionCacheService.GetPermissionCode(ctx, req.Method, req.Url)
 	if err != nil {
 		return false, err
 	}
 
+	// API Key 限定了权限范围时，只能访问范围内的接口
+	if len(req.Scopes) > 0 && !gstr.InArray(req.Scopes, permissionCode) {
+		return false, nil
+	}
+
 	// 检查角色是否有访问接口的权限
-	ok, err := service.SysRoleService.CheckPermission(ctx, roles, permissionCode)
+	ok, err := service.SysPermissionCacheService.CheckPermission(ctx, roles, permissionCode)
 	if err != nil {
 		return false, err
 	}
@@ -164,6 +557,37 @@ func (c *sAuthLogic) CheckPermission(ctx context.Context, req *adminModel.CheckP
 
 }
 
+// 鉴权时使用的用户角色，用户不存在、账号禁用或锁定、没有角色时返回错误
+func (c *sAuthLogic) permissionRoles(ctx context.Context, userId uint64) ([]uint64, error) {
+
+	// 获取用户信息
+	user, roles, err := service.SysPermissionCacheService.GetUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查用户是否存在
+	if user == nil {
+		return nil, errors.New("用户不存在")
+	}
+
+	// 检查账号是否禁用或锁定
+	if err = c.checkUserStatus(user); err != nil {
+		return nil, err
+	}
+
+	if len(roles) == 0 {
+		return nil, errors.New("用户没有角色，不能访问接口")
+	}
+
+	return roles, nil
+}
+
+// 订阅其他副本的鉴权缓存失效消息，未开启广播时不做任何事
+func (c *sAuthLogic) StartPermissionCacheBroadcast(ctx context.Context) {
+	service.SysPermissionCacheService.StartBroadcast(ctx)
+}
+
 // 个人中心
 func (c *sAuthLogic) Profile(ctx context.Context, req *adminModel.ProfileReq) (res *adminModel.ProfileRes, err error) {
 
@@ -175,5 +599,14 @@ func (c *sAuthLogic) Profile(ctx context.Context, req *adminModel.ProfileReq) (r
 		return nil, err
 	}
 
+	// 最近的登录记录
+	limit := g.Cfg("auth").MustGet(ctx, "loginLog.recentLogins", 10).Int()
+	if limit > 0 {
+		res.RecentLogins, err = service.SysLoginLogService.GetRecentByUserId(ctx, req.UserId, limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return res, nil
 }
@@ -0,0 +1,167 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
+	"gf-ant-react/internal/service"
+	"gf-ant-react/utility/apikey"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// 创建 API Key，权限范围不能超出用户自己的权限
+func (c *sAuthLogic) CreateApiKey(ctx context.Context, param *adminModel.SysApiKeyCreateParam) (*adminModel.SysApiKeyCreateResult, error) {
+
+	if param.ExpiresAt != nil && param.ExpiresAt.Before(gtime.Now()) {
+		return nil, errors.New("过期时间不能早于当前时间")
+	}
+
+	scopes, err := c.checkApiKeyScopes(ctx, param.UserId, param.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	key, prefix, hash, err := apikey.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := service.SysApiKeyService.Create(ctx, &entity.SysApiKeys{
+		UserId:    param.UserId,
+		Name:      param.Name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    strings.Join(scopes, ","),
+		ExpiresAt: param.ExpiresAt,
+		CreatedAt: gtime.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &adminModel.SysApiKeyCreateResult{
+		Id:     id,
+		Key:    key,
+		Prefix: prefix,
+	}, nil
+}
+
+// 检查权限范围，返回去重后的权限码
+func (c *sAuthLogic) checkApiKeyScopes(ctx context.Context, userId uint64, scopes []string) ([]string, error) {
+
+	if len(scopes) == 0 {
+		return nil, nil
+	}
+
+	codes, err := c.userApiCodes(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope == "" || gstr.InArray(result, scope) {
+			continue
+		}
+		if !gstr.InArray(codes, scope) {
+			return nil, fmt.Errorf("没有权限码 %s 的权限", scope)
+		}
+		result = append(result, scope)
+	}
+
+	return result, nil
+}
+
+// API Key 列表
+func (c *sAuthLogic) ApiKeys(ctx context.Context, param *adminModel.SysApiKeyListParam) (*adminModel.SysApiKeyListResult, error) {
+
+	list, total, err := service.SysApiKeyService.GetList(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adminModel.SysApiKeyListResult{
+		List:  list,
+		Total: total,
+	}, nil
+}
+
+// 吊销 API Key，userId 大于0时只能吊销该用户自己的 API Key
+func (c *sAuthLogic) RevokeApiKey(ctx context.Context, userId uint64, id uint64) error {
+
+	key, err := service.SysApiKeyService.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if key == nil || (userId > 0 && key.UserId != userId) {
+		return errors.New("API Key 不存在")
+	}
+
+	if err = service.SysApiKeyService.Revoke(ctx, id); err != nil {
+		return err
+	}
+
+	// 清除校验缓存，立即生效
+	_, err = gcache.Remove(ctx, c.apiKeyCacheKey(key.KeyHash))
+	return err
+}
+
+// 校验 API Key，返回 nil 表示无效、已吊销或已过期
+// 校验结果在 apiKey.cacheSeconds 内缓存，同时按该间隔记录最后使用时间和IP
+func (c *sAuthLogic) AuthenticateApiKey(ctx context.Context, key string, ip string) (*entity.SysApiKeys, error) {
+
+	if !strings.HasPrefix(key, apikey.Prefix) {
+		return nil, nil
+	}
+
+	hash := apikey.Hash(key)
+	interval := time.Duration(g.Cfg("auth").MustGet(ctx, "apiKey.cacheSeconds", 60).Int64()) * time.Second
+
+	value, err := gcache.GetOrSetFuncLock(ctx, c.apiKeyCacheKey(hash), func(ctx context.Context) (interface{}, error) {
+		apiKey, err := service.SysApiKeyService.GetByHash(ctx, hash)
+		if err != nil || apiKey == nil {
+			return nil, err
+		}
+		if err = service.SysApiKeyService.Touch(ctx, apiKey.Id, ip); err != nil {
+			return nil, err
+		}
+		return apiKey, nil
+	}, interval)
+	if err != nil || value.IsNil() {
+		return nil, err
+	}
+
+	var apiKey *entity.SysApiKeys
+	if err = value.Scan(&apiKey); err != nil {
+		return nil, err
+	}
+
+	if apiKey.RevokedAt != nil || (apiKey.ExpiresAt != nil && apiKey.ExpiresAt.Before(gtime.Now())) {
+		return nil, nil
+	}
+
+	return apiKey, nil
+}
+
+// API Key 校验缓存键
+func (c *sAuthLogic) apiKeyCacheKey(hash string) string {
+	return fmt.Sprintf("apikey:%s", hash)
+}
+
+// 解析 API Key 的权限范围
+func (c *sAuthLogic) ApiKeyScopes(key *entity.SysApiKeys) []string {
+	if key.Scopes == "" {
+		return nil
+	}
+	return strings.Split(key.Scopes, ",")
+}
@@ -0,0 +1,63 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
+	"gf-ant-react/utility/password"
+)
+
+// Authenticator 密码认证方式，按用户的认证方式 (auth_source) 选择
+type Authenticator interface {
+	// Authenticate 校验密码，密码错误时返回 false
+	Authenticate(ctx context.Context, user *entity.SysUsers, password string) (bool, error)
+}
+
+// 已注册的认证方式
+var authenticators = map[string]Authenticator{
+	adminModel.AuthSourceLocal: &localAuthenticator{},
+	adminModel.AuthSourceLdap:  &ldapAuthenticator{},
+}
+
+// RegisterAuthenticator 注册认证方式，已存在时覆盖
+func RegisterAuthenticator(source string, authenticator Authenticator) {
+	authenticators[source] = authenticator
+}
+
+// 按用户的认证方式校验密码
+func (c *sAuthLogic) authenticate(ctx context.Context, user *entity.SysUsers, plain string) (bool, error) {
+
+	source := user.AuthSource
+	if source == "" {
+		source = adminModel.AuthSourceLocal
+	}
+
+	authenticator, ok := authenticators[source]
+	if !ok {
+		return false, fmt.Errorf("不支持的认证方式: %s", source)
+	}
+
+	return authenticator.Authenticate(ctx, user, plain)
+}
+
+// 是否使用本地密码
+func (c *sAuthLogic) isLocalUser(user *entity.SysUsers) bool {
+	return user.AuthSource == "" || user.AuthSource == adminModel.AuthSourceLocal
+}
+
+// localAuthenticator 本地密码
+type localAuthenticator struct{}
+
+func (a *localAuthenticator) Authenticate(ctx context.Context, user *entity.SysUsers, plain string) (bool, error) {
+
+	if !password.CheckPasswordHash(plain, user.PasswordHash) {
+		return false, nil
+	}
+
+	// 哈希算法或参数已变更，趁有明文密码时重新计算
+	AuthLogic.rehashPassword(ctx, user, plain)
+
+	return true, nil
+}
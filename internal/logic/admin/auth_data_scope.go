@@ -0,0 +1,166 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/service"
+	"gf-ant-react/utility/auth"
+)
+
+var errDataScopeDenied = errors.New("数据不存在或没有权限访问")
+
+// DataScope 当前登录用户的数据权限范围
+func (c *sAuthLogic) DataScope(ctx context.Context) (*adminModel.DataScope, error) {
+	return c.UserDataScope(ctx, auth.GetUserId(ctx))
+}
+
+// UserDataScope 根据用户的角色和部门计算数据权限范围，多个角色取并集
+// 任一角色为全部或超级管理员时不限制；仅本人可以访问自己的数据；其他范围转换为可以访问的部门，自定义范围使用角色选择的部门
+func (c *sAuthLogic) UserDataScope(ctx context.Context, userId uint64) (*adminModel.DataScope, error) {
+
+	if userId == 0 {
+		return nil, errors.New("没有登录")
+	}
+
+	user, _, err := service.SysPermissionCacheService.GetUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("用户不存在")
+	}
+
+	roles, err := service.SysRoleService.GetUserRoles(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := &adminModel.DataScope{UserId: userId}
+	departments := make(map[uint64]struct{})
+	withChildren := false
+	var customRoleIds []uint64
+	for _, role := range roles {
+		if role.IsSuper {
+			scope.All = true
+			return scope, nil
+		}
+		switch role.DataScope {
+		case adminModel.DataScopeAll:
+			scope.All = true
+			return scope, nil
+		case adminModel.DataScopeDept:
+			if user.DepartmentId > 0 {
+				departments[user.DepartmentId] = struct{}{}
+			}
+		case adminModel.DataScopeDeptAndChild:
+			if user.DepartmentId > 0 {
+				departments[user.DepartmentId] = struct{}{}
+				withChildren = true
+			}
+		case adminModel.DataScopeSelf:
+			scope.Self = true
+		case adminModel.DataScopeCustom:
+			customRoleIds = append(customRoleIds, role.Id)
+		}
+	}
+
+	// 自定义范围的部门
+	customDepartmentIds, err := service.SysRoleService.GetDepartmentIds(ctx, customRoleIds...)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range customDepartmentIds {
+		departments[id] = struct{}{}
+	}
+
+	// 本部门及子部门
+	if withChildren {
+		if err = c.appendChildDepartments(ctx, user.DepartmentId, departments); err != nil {
+			return nil, err
+		}
+	}
+
+	for id := range departments {
+		scope.DepartmentIds = append(scope.DepartmentIds, id)
+	}
+
+	return scope, nil
+}
+
+// 把部门的所有下级部门加入 departments
+func (c *sAuthLogic) appendChildDepartments(ctx context.Context, departmentId uint64, departments map[uint64]struct{}) error {
+
+	all, err := service.SysDepartmentService.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	children := make(map[uint64][]uint64, len(all))
+	for _, department := range all {
+		children[department.ParentId] = append(children[department.ParentId], department.Id)
+	}
+
+	// 记录已访问的部门，避免数据错误形成环时死循环
+	visited := map[uint64]bool{departmentId: true}
+	queue := []uint64{departmentId}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, child := range children[id] {
+			if visited[child] {
+				continue
+			}
+			visited[child] = true
+			departments[child] = struct{}{}
+			queue = append(queue, child)
+		}
+	}
+
+	return nil
+}
+
+// 检查数据是否在当前用户的数据权限范围内，不在范围内时与不存在返回同样的错误
+func (c *sAuthLogic) checkDataScope(ctx context.Context, check func(ctx context.Context, id uint64, scope *adminModel.DataScope) (bool, error), id uint64) error {
+
+	scope, err := c.DataScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	ok, err := check(ctx, id, scope)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errDataScopeDenied
+	}
+
+	return nil
+}
+
+// dataScopeCovers 数据权限范围 scope 是否包含 target，targetDepartmentId 为 target 所属用户的部门
+// target 的仅本人数据按该用户所属部门判断
+func dataScopeCovers(scope, target *adminModel.DataScope, targetDepartmentId uint64) bool {
+
+	if scope.All {
+		return true
+	}
+	if target.All {
+		return false
+	}
+
+	for _, id := range target.DepartmentIds {
+		if !slices.Contains(scope.DepartmentIds, id) {
+			return false
+		}
+	}
+
+	if target.Self && !(scope.Self && scope.UserId == target.UserId) && !slices.Contains(scope.DepartmentIds, targetDepartmentId) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,37 @@
+package admin
+
+import (
+	"testing"
+
+	adminModel "gf-ant-react/internal/model/admin"
+)
+
+func TestDataScopeCovers(t *testing.T) {
+
+	dept := func(ids ...uint64) *adminModel.DataScope {
+		return &adminModel.DataScope{UserId: 1, DepartmentIds: ids}
+	}
+
+	tests := []struct {
+		name       string
+		scope      *adminModel.DataScope
+		target     *adminModel.DataScope
+		department uint64 // target 所属用户的部门
+		want       bool
+	}{
+		{"全部包含全部", &adminModel.DataScope{UserId: 1, All: true}, &adminModel.DataScope{UserId: 2, All: true}, 10, true},
+		{"本部门不能代登录全部", dept(10), &adminModel.DataScope{UserId: 2, All: true}, 10, false},
+		{"相同部门", dept(10), &adminModel.DataScope{UserId: 2, DepartmentIds: []uint64{10}}, 10, true},
+		{"本部门及子部门包含本部门", dept(10, 11, 12), &adminModel.DataScope{UserId: 2, DepartmentIds: []uint64{11}}, 11, true},
+		{"本部门不包含上级部门及子部门", dept(11), &adminModel.DataScope{UserId: 2, DepartmentIds: []uint64{10, 11}}, 11, false},
+		{"部门内用户的仅本人", dept(10), &adminModel.DataScope{UserId: 2, Self: true}, 10, true},
+		{"其他部门用户的仅本人", dept(10), &adminModel.DataScope{UserId: 2, Self: true}, 20, false},
+		{"仅本人不能代登录其他人的仅本人", &adminModel.DataScope{UserId: 1, Self: true}, &adminModel.DataScope{UserId: 2, Self: true}, 10, false},
+		{"没有数据权限", dept(10), &adminModel.DataScope{UserId: 2}, 20, true},
+	}
+	for _, tt := range tests {
+		if got := dataScopeCovers(tt.scope, tt.target, tt.department); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
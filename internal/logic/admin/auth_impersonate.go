@@ -0,0 +1,177 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
+	"gf-ant-react/internal/service"
+	"gf-ant-react/utility/jwt"
+	"gf-ant-react/utility/route"
+	"gf-ant-react/utility/useragent"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// 代登录：以其他用户的身份签发短期访问令牌，用于排查用户看到的页面和权限
+// 不签发刷新令牌，令牌中记录实际操作人，期间的操作同时记录两个用户
+func (c *sAuthLogic) Impersonate(ctx context.Context, req *adminModel.ImpersonateReq) (*adminModel.LoginRes, error) {
+
+	operator := req.Operator
+	if operator == nil || operator.SessionID == "" {
+		return nil, errors.New("请使用账号登录后再代登录")
+	}
+	if operator.Impersonator != nil {
+		return nil, errors.New("代登录期间不能再次代登录")
+	}
+	if operator.UserID == req.UserId {
+		return nil, errors.New("不能代登录自己")
+	}
+
+	// 只能代登录数据权限范围内的用户
+	if err := SysUserLogic.checkScope(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	user, _, err := service.SysUserService.GetById(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("用户不存在")
+	}
+	if err = c.checkUserStatus(user); err != nil {
+		return nil, err
+	}
+
+	res := &adminModel.LoginRes{User: user}
+	if err = c.loadAccess(ctx, res); err != nil {
+		return nil, err
+	}
+
+	// 不能通过代登录获得自己没有的权限
+	operatorCodes, err := c.userApiCodes(ctx, operator.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range res.ApiCodes {
+		if !gstr.InArray(operatorCodes, code) {
+			return nil, fmt.Errorf("不能代登录权限高于自己的用户，缺少权限: %s", code)
+		}
+	}
+
+	// 也不能获得更大的数据权限范围
+	operatorScope, err := c.UserDataScope(ctx, operator.UserID)
+	if err != nil {
+		return nil, err
+	}
+	targetScope, err := c.UserDataScope(ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !dataScopeCovers(operatorScope, targetScope, user.DepartmentId) {
+		return nil, errors.New("不能代登录数据权限范围大于自己的用户")
+	}
+
+	// 签发代登录令牌，过期时间不超过普通访问令牌
+	expire := g.Cfg("auth").MustGet(ctx, "impersonation.expire", 900).Int64()
+	if expire <= 0 || expire > jwt.JwtUtility.Expire {
+		expire = jwt.JwtUtility.Expire
+	}
+	expiresAt := time.Now().Add(time.Duration(expire) * time.Second)
+	sessionId := guid.S()
+
+	res.TokenRes = &adminModel.TokenRes{Expire: expiresAt, Refresh: expiresAt}
+	res.Token, err = jwt.JwtUtility.GenerateImpersonationToken(user.Id, user.Username, sessionId, &jwt.Impersonator{
+		UserID:    operator.UserID,
+		Username:  operator.Username,
+		SessionID: operator.SessionID,
+	}, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// 代登录会话出现在用户的会话列表中
+	err = service.SysUserSessionService.Create(ctx, &entity.SysUserSessions{
+		SessionId:      sessionId,
+		UserId:         user.Id,
+		ImpersonatorId: operator.UserID,
+		Device:         useragent.Device(req.UserAgent),
+		Ip:             req.Ip,
+		UserAgent:      gstr.SubStr(req.UserAgent, 0, 500),
+		LastSeenIp:     req.Ip,
+		LastSeenAt:     gtime.Now(),
+		ExpiresAt:      gtime.NewFromTime(expiresAt),
+		CreatedAt:      gtime.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 记入被代登录用户的登录日志
+	message := fmt.Sprintf("操作人: %s(%d)", operator.Username, operator.UserID)
+	if req.Reason != "" {
+		message += " 原因: " + req.Reason
+	}
+	err = service.SysLoginLogService.Create(ctx, &entity.SysLoginLogs{
+		UserId:    user.Id,
+		Username:  user.Username,
+		Method:    adminModel.LoginMethodImpersonate,
+		Status:    adminModel.LoginStatusSuccess,
+		Reason:    adminModel.LoginReasonSuccess,
+		Message:   gstr.SubStr(message, 0, 255),
+		Ip:        req.Ip,
+		Device:    useragent.Device(req.UserAgent),
+		UserAgent: gstr.SubStr(req.UserAgent, 0, 500),
+		CreatedAt: gtime.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	g.Log("audit").Noticef(ctx, "开始代登录: operator=%s(%d) user=%s(%d) session=%s ip=%s reason=%s",
+		operator.Username, operator.UserID, user.Username, user.Id, sessionId, req.Ip, req.Reason)
+
+	res.User.PasswordHash = ""
+	res.User.TotpSecret = ""
+
+	return res, nil
+}
+
+// 结束代登录，代登录令牌立即失效，操作人自己的登录不受影响
+func (c *sAuthLogic) EndImpersonation(ctx context.Context, claims *jwt.Claims) error {
+
+	if claims == nil || claims.Impersonator == nil {
+		return errors.New("当前不在代登录中")
+	}
+
+	if err := c.Logout(ctx, &adminModel.LogoutReq{
+		TokenId:   claims.ID,
+		SessionId: claims.SessionID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}); err != nil {
+		return err
+	}
+
+	g.Log("audit").Noticef(ctx, "结束代登录: operator=%s(%d) user=%s(%d) session=%s",
+		claims.Impersonator.Username, claims.Impersonator.UserID, claims.Username, claims.UserID, claims.SessionID)
+
+	return nil
+}
+
+// 记录代登录期间的操作，同时记录操作人和被代登录的用户
+func (c *sAuthLogic) AuditImpersonation(ctx context.Context, claims *jwt.Claims, method, path, ip string) {
+	g.Log("audit").Noticef(ctx, "代登录操作: operator=%s(%d) user=%s(%d) %s %s ip=%s",
+		claims.Impersonator.Username, claims.Impersonator.UserID, claims.Username, claims.UserID, method, path, ip)
+}
+
+// 代登录期间禁止的操作，如修改密码、两步验证和创建 API Key
+func (c *sAuthLogic) ImpersonationDenied(ctx context.Context, path, method string) bool {
+	return route.MatchMap(g.Cfg("auth").MustGet(ctx, "impersonation.denyRoutes").MapStrStr(), method, path)
+}
@@ -0,0 +1,185 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
+	"gf-ant-react/internal/service"
+	errorUtil "gf-ant-react/utility/error"
+	"gf-ant-react/utility/ldap"
+	"gf-ant-react/utility/password"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/os/gtimer"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// ldapUserStore 同步 LDAP 账号状态时读写的本地用户，测试时替换
+type ldapUserStore struct {
+	GetByAuthSource func(ctx context.Context, authSource string) ([]*entity.SysUsers, error)
+	UpdateStatus    func(ctx context.Context, id uint64, status int) error
+	RevokeTokens    func(ctx context.Context, userId uint64) error
+}
+
+var ldapUsers = &ldapUserStore{
+	GetByAuthSource: service.SysUserService.GetByAuthSource,
+	UpdateStatus:    service.SysUserService.UpdateStatus,
+	RevokeTokens:    AuthLogic.RevokeUserTokens,
+}
+
+// ldapAuthenticator LDAP，使用用户DN和密码绑定校验密码
+type ldapAuthenticator struct{}
+
+func (a *ldapAuthenticator) Authenticate(ctx context.Context, user *entity.SysUsers, plain string) (bool, error) {
+
+	entry, err := ldap.LdapUtility.Authenticate(ctx, user.Username, plain)
+	if err != nil || entry == nil {
+		return false, err
+	}
+
+	// 目录中已禁用的账号同步禁用
+	if entry.Disabled {
+		if err = AuthLogic.disableLdapUser(ctx, user.Id); err != nil {
+			return false, err
+		}
+		return false, errorUtil.ErrorUserDisabled
+	}
+
+	// 按组映射重新设置角色
+	config := ldap.LdapUtility.Config
+	if config.SyncRoles && len(config.GroupRoles) > 0 {
+		if err = service.SysUserService.UpdateRoles(ctx, user.Id, AuthLogic.ldapRoleIds(entry)); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// 即时创建 LDAP 用户，本地不存在该用户名时调用
+// 未开启即时创建或 LDAP 认证失败时返回 nil
+func (c *sAuthLogic) provisionLdapUser(ctx context.Context, username string, plain string) (*entity.SysUsers, error) {
+
+	config := ldap.LdapUtility.Config
+	if !config.Enabled || !config.Provision.Enabled {
+		return nil, nil
+	}
+
+	entry, err := ldap.LdapUtility.Authenticate(ctx, username, plain)
+	if err != nil || entry == nil {
+		return nil, err
+	}
+	if entry.Disabled {
+		return nil, errorUtil.ErrorUserDisabled
+	}
+
+	// 本地密码为随机值，认证始终走 LDAP
+	passwordHash, err := password.HashPassword(guid.S())
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := service.SysUserService.Create(ctx, &adminModel.SysUserCreateParam{
+		Username:          username,
+		PasswordHash:      passwordHash,
+		PasswordChangedAt: gtime.Now(),
+		AuthSource:        adminModel.AuthSourceLdap,
+		Email:             entry.Email,
+		Mobile:            entry.Mobile,
+		DepartmentId:      config.Provision.DepartmentId,
+		Status:            adminModel.UserStatusEnabled,
+		RoleIds:           c.ldapRoleIds(entry),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	g.Log().Infof(ctx, "LDAP 创建用户: id=%d username=%s dn=%s", id, username, entry.Dn)
+
+	return service.SysUserService.GetByUsername(ctx, username)
+}
+
+// 默认角色加上组映射的角色
+func (c *sAuthLogic) ldapRoleIds(entry *ldap.Entry) []uint64 {
+	config := ldap.LdapUtility.Config
+	return c.groupRoleIds(config.Provision.RoleIds, config.GroupRoles, entry.Groups)
+}
+
+// 禁用 LDAP 用户并强制下线
+func (c *sAuthLogic) disableLdapUser(ctx context.Context, userId uint64) error {
+
+	if err := ldapUsers.UpdateStatus(ctx, userId, adminModel.UserStatusDisabled); err != nil {
+		return err
+	}
+
+	return ldapUsers.RevokeTokens(ctx, userId)
+}
+
+// 同步 LDAP 账号状态：目录中已禁用或已删除的账号在本系统中禁用并强制下线
+// 只同步禁用，目录中重新启用的账号需要管理员在本系统中启用
+func (c *sAuthLogic) SyncLdapUsers(ctx context.Context) (*adminModel.LdapSyncRes, error) {
+
+	entries, err := ldap.LdapUtility.Users(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 查询条件配置错误时可能一个用户都查不到，此时不能把所有 LDAP 账号都禁用
+	if len(entries) == 0 {
+		return nil, errors.New("LDAP 没有返回任何用户，请检查 syncFilter 配置")
+	}
+
+	directory := make(map[string]*ldap.Entry, len(entries))
+	for _, entry := range entries {
+		directory[strings.ToLower(entry.Username)] = entry
+	}
+
+	users, err := ldapUsers.GetByAuthSource(ctx, adminModel.AuthSourceLdap)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &adminModel.LdapSyncRes{Total: len(users)}
+	for _, user := range users {
+
+		if user.Status == adminModel.UserStatusDisabled {
+			continue
+		}
+
+		entry, ok := directory[strings.ToLower(user.Username)]
+		if ok && !entry.Disabled {
+			continue
+		}
+
+		if err = c.disableLdapUser(ctx, user.Id); err != nil {
+			return nil, err
+		}
+		res.Disabled = append(res.Disabled, user.Username)
+	}
+
+	if len(res.Disabled) > 0 {
+		g.Log().Infof(ctx, "LDAP 同步禁用用户: %v", res.Disabled)
+	}
+
+	return res, nil
+}
+
+// 按 syncInterval 定时同步 LDAP 账号状态
+func (c *sAuthLogic) StartLdapSync(ctx context.Context) {
+
+	config := ldap.LdapUtility.Config
+	if !config.Enabled || config.SyncInterval <= 0 {
+		return
+	}
+
+	gtimer.AddSingleton(ctx, time.Duration(config.SyncInterval)*time.Minute, func(ctx context.Context) {
+		if _, err := c.SyncLdapUsers(ctx); err != nil {
+			g.Log().Warningf(ctx, "LDAP 同步失败: %v", err)
+		}
+	})
+}
@@ -0,0 +1,178 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
+	errorUtil "gf-ant-react/utility/error"
+	"gf-ant-react/utility/ldap"
+	"gf-ant-react/utility/ldap/ldaptest"
+)
+
+// 启动模拟的目录服务并替换 LDAP 配置，测试结束后恢复
+func useTestLdap(t *testing.T) *ldap.Config {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go ldaptest.Serve(context.Background(), listener)
+
+	config := &ldap.Config{
+		Enabled:      true,
+		Url:          "ldap://" + listener.Addr().String(),
+		Timeout:      5,
+		BindDn:       "cn=admin," + ldaptest.BaseDn,
+		BindPassword: "admin",
+		BaseDn:       "ou=people," + ldaptest.BaseDn,
+		UserFilter:   "(&(objectClass=person)(uid=%s))",
+		SyncFilter:   "(objectClass=person)",
+	}
+	config.Attributes.Username = "uid"
+	config.Attributes.Email = "mail"
+	config.Attributes.Mobile = "mobile"
+	config.Attributes.Groups = "memberOf"
+	config.Disabled.Attribute = "nsAccountLock"
+	config.Disabled.Value = "TRUE"
+
+	original := ldap.LdapUtility.Config
+	ldap.LdapUtility.Config = config
+	t.Cleanup(func() { ldap.LdapUtility.Config = original })
+
+	return config
+}
+
+// 内存中的本地用户，替换同步账号状态时读写的数据库
+type testLdapUsers struct {
+	users   []*entity.SysUsers
+	revoked []uint64
+}
+
+func useTestLdapUsers(t *testing.T, users ...*entity.SysUsers) *testLdapUsers {
+
+	store := &testLdapUsers{users: users}
+
+	original := ldapUsers
+	ldapUsers = &ldapUserStore{
+		GetByAuthSource: func(ctx context.Context, authSource string) ([]*entity.SysUsers, error) {
+			var result []*entity.SysUsers
+			for _, user := range store.users {
+				if user.AuthSource == authSource {
+					result = append(result, user)
+				}
+			}
+			return result, nil
+		},
+		UpdateStatus: func(ctx context.Context, id uint64, status int) error {
+			for _, user := range store.users {
+				if user.Id == id {
+					user.Status = status
+					return nil
+				}
+			}
+			return errors.New("用户不存在")
+		},
+		RevokeTokens: func(ctx context.Context, userId uint64) error {
+			store.revoked = append(store.revoked, userId)
+			return nil
+		},
+	}
+	t.Cleanup(func() { ldapUsers = original })
+
+	return store
+}
+
+func (s *testLdapUsers) statuses() string {
+	var result []string
+	for _, user := range s.users {
+		result = append(result, fmt.Sprintf("%s=%d", user.Username, user.Status))
+	}
+	return strings.Join(result, ",")
+}
+
+func TestLdapRoleIds(t *testing.T) {
+
+	ctx := context.Background()
+	config := useTestLdap(t)
+	config.Provision.RoleIds = []uint64{2, 3}
+	config.GroupRoles = map[string][]uint64{
+		"cn=admins,ou=groups,dc=example,dc=com": {1, 3},
+	}
+
+	tests := []struct {
+		username string
+		password string
+		want     string
+	}{
+		{"alice", "alice123", "[2 3 1]"}, // 默认角色加上组映射的角色，去重
+		{"bob", "bob123", "[2 3]"},       // 没有映射的组只有默认角色
+	}
+	for _, tt := range tests {
+		entry, err := ldap.LdapUtility.Authenticate(ctx, tt.username, tt.password)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entry == nil {
+			t.Fatalf("%s 认证失败", tt.username)
+		}
+		if got := fmt.Sprint(AuthLogic.ldapRoleIds(entry)); got != tt.want {
+			t.Errorf("%s 的角色为 %s, want %s", tt.username, got, tt.want)
+		}
+	}
+}
+
+func TestLdapAuthenticateDisabled(t *testing.T) {
+
+	ctx := context.Background()
+	useTestLdap(t)
+	carol := &entity.SysUsers{Id: 3, Username: "carol", AuthSource: adminModel.AuthSourceLdap, Status: adminModel.UserStatusEnabled}
+	store := useTestLdapUsers(t, carol)
+
+	// 目录中已禁用的账号登录时同步禁用并强制下线
+	ok, err := (&ldapAuthenticator{}).Authenticate(ctx, carol, "carol123")
+	if ok || !errors.Is(err, errorUtil.ErrorUserDisabled) {
+		t.Fatalf("禁用的账号不应当登录成功: ok=%v err=%v", ok, err)
+	}
+	if carol.Status != adminModel.UserStatusDisabled || fmt.Sprint(store.revoked) != "[3]" {
+		t.Fatalf("账号应当被禁用并强制下线: status=%d revoked=%v", carol.Status, store.revoked)
+	}
+}
+
+func TestSyncLdapUsers(t *testing.T) {
+
+	ctx := context.Background()
+	useTestLdap(t)
+	store := useTestLdapUsers(t,
+		&entity.SysUsers{Id: 1, Username: "alice", AuthSource: adminModel.AuthSourceLdap, Status: adminModel.UserStatusEnabled},
+		&entity.SysUsers{Id: 2, Username: "BOB", AuthSource: adminModel.AuthSourceLdap, Status: adminModel.UserStatusEnabled},   // 用户名不区分大小写
+		&entity.SysUsers{Id: 3, Username: "carol", AuthSource: adminModel.AuthSourceLdap, Status: adminModel.UserStatusEnabled}, // 目录中已禁用
+		&entity.SysUsers{Id: 4, Username: "dave", AuthSource: adminModel.AuthSourceLdap, Status: adminModel.UserStatusEnabled},  // 目录中已删除
+		&entity.SysUsers{Id: 5, Username: "erin", AuthSource: adminModel.AuthSourceLdap, Status: adminModel.UserStatusDisabled}, // 已经禁用
+		&entity.SysUsers{Id: 6, Username: "frank", AuthSource: adminModel.AuthSourceLocal, Status: adminModel.UserStatusEnabled},
+	)
+
+	res, err := AuthLogic.SyncLdapUsers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Total != 5 || strings.Join(res.Disabled, ",") != "carol,dave" {
+		t.Fatalf("同步结果不正确: %+v", res)
+	}
+	want := fmt.Sprintf("alice=%d,BOB=%d,carol=%d,dave=%d,erin=%d,frank=%d",
+		adminModel.UserStatusEnabled, adminModel.UserStatusEnabled, adminModel.UserStatusDisabled,
+		adminModel.UserStatusDisabled, adminModel.UserStatusDisabled, adminModel.UserStatusEnabled)
+	if got := store.statuses(); got != want {
+		t.Fatalf("用户状态为 %s, want %s", got, want)
+	}
+	if fmt.Sprint(store.revoked) != "[3 4]" {
+		t.Fatalf("强制下线的用户为 %v, want [3 4]", store.revoked)
+	}
+}
@@ -0,0 +1,113 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
+	"gf-ant-react/internal/service"
+	errorUtil "gf-ant-react/utility/error"
+	"gf-ant-react/utility/useragent"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/os/gtimer"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// 记录登录日志
+// 写入失败不影响登录结果，只记录警告
+func (c *sAuthLogic) recordLogin(ctx context.Context, attempt *adminModel.LoginAttempt, res *adminModel.LoginRes, loginErr error) {
+
+	log := &entity.SysLoginLogs{
+		UserId:    attempt.UserId,
+		Username:  gstr.SubStr(attempt.Username, 0, 50),
+		Method:    attempt.Method,
+		Status:    adminModel.LoginStatusSuccess,
+		Reason:    attempt.Reason,
+		Ip:        attempt.Ip,
+		Device:    useragent.Device(attempt.UserAgent),
+		UserAgent: gstr.SubStr(attempt.UserAgent, 0, 500),
+		CreatedAt: gtime.Now(),
+	}
+
+	switch {
+	case loginErr != nil:
+		log.Status = adminModel.LoginStatusFailed
+		log.Message = gstr.SubStr(loginErr.Error(), 0, 255)
+		if log.Reason == "" {
+			log.Reason = c.loginFailureReason(loginErr)
+		}
+	case res != nil && res.MfaRequired:
+		log.Status = adminModel.LoginStatusMfaPending
+		log.Reason = adminModel.LoginReasonMfaRequired
+	default:
+		log.Reason = adminModel.LoginReasonSuccess
+	}
+
+	if err := service.SysLoginLogService.Create(ctx, log); err != nil {
+		g.Log().Warningf(ctx, "记录登录日志失败: username=%s err=%v", attempt.Username, err)
+	}
+}
+
+// 按错误码判断失败原因
+func (c *sAuthLogic) loginFailureReason(err error) string {
+	switch gerror.Code(err).Code() {
+	case errorUtil.ErrorUserDisabledCode:
+		return adminModel.LoginReasonDisabled
+	case errorUtil.ErrorUserLockedCode:
+		return adminModel.LoginReasonLocked
+	case errorUtil.ErrorLoginFailedCode:
+		return adminModel.LoginReasonBadPassword
+	case errorUtil.ErrorMfaCodeCode:
+		return adminModel.LoginReasonMfaFailed
+	}
+	return adminModel.LoginReasonError
+}
+
+// 登录日志列表
+func (c *sAuthLogic) LoginLogs(ctx context.Context, param *adminModel.SysLoginLogListParam) (*adminModel.SysLoginLogListResult, error) {
+
+	list, total, err := service.SysLoginLogService.GetList(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adminModel.SysLoginLogListResult{
+		List:  list,
+		Total: total,
+	}, nil
+}
+
+// 清理超过保留天数的登录日志
+func (c *sAuthLogic) CleanLoginLogs(ctx context.Context) (int64, error) {
+
+	retentionDays := g.Cfg("auth").MustGet(ctx, "loginLog.retentionDays", 180).Int()
+	if retentionDays <= 0 {
+		return 0, nil
+	}
+
+	return service.SysLoginLogService.DeleteBefore(ctx, gtime.Now().AddDate(0, 0, -retentionDays))
+}
+
+// 定时清理登录日志
+func (c *sAuthLogic) StartLoginLogCleanup(ctx context.Context) {
+
+	if g.Cfg("auth").MustGet(ctx, "loginLog.retentionDays", 180).Int() <= 0 {
+		return
+	}
+
+	interval := time.Duration(g.Cfg("auth").MustGet(ctx, "loginLog.cleanupInterval", 60).Int64()) * time.Minute
+	gtimer.AddSingleton(ctx, interval, func(ctx context.Context) {
+		count, err := c.CleanLoginLogs(ctx)
+		if err != nil {
+			g.Log().Warningf(ctx, "清理登录日志失败: %v", err)
+			return
+		}
+		if count > 0 {
+			g.Log().Infof(ctx, "清理登录日志 %d 条", count)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package admin
+
+import (
+	"context"
+
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
+	"gf-ant-react/internal/service"
+)
+
+// Menus 当前用户的菜单树
+// 菜单为接口树中是否为菜单的节点，用户拥有该接口权限且上级菜单可见时显示，
+// 非菜单接口作为按钮权限归到最近的上级菜单下
+func (c *sAuthLogic) Menus(ctx context.Context, userId uint64) ([]*adminModel.MenuItem, error) {
+
+	// 用户可访问的API，包括继承的权限
+	access := &adminModel.LoginRes{User: &entity.SysUsers{Id: userId}}
+	if err := c.loadAccess(ctx, access); err != nil {
+		return nil, err
+	}
+	if len(access.Apis) == 0 {
+		return []*adminModel.MenuItem{}, nil
+	}
+	granted := make(map[uint64]bool, len(access.Apis))
+	for _, api := range access.Apis {
+		granted[api.Id] = true
+	}
+
+	// 完整的接口树用于查找上级菜单，已按 sort DESC, id DESC 排序
+	apis, err := service.SysApiService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	apiMap := make(map[uint64]*entity.SysApis, len(apis))
+	for _, api := range apis {
+		apiMap[api.Id] = api
+	}
+
+	// 最近的上级菜单，没有时为0，层级数限制防止数据中存在循环
+	parentMenu := func(api *entity.SysApis) uint64 {
+		parentId := api.ParentId
+		for i := 0; parentId != 0 && i < len(apis); i++ {
+			parent, ok := apiMap[parentId]
+			if !ok {
+				return 0
+			}
+			if parent.IsMenu == adminModel.IsMenuYes {
+				return parent.Id
+			}
+			parentId = parent.ParentId
+		}
+		return 0
+	}
+
+	// 用户拥有权限的菜单节点
+	menuMap := make(map[uint64]*adminModel.MenuItem)
+	for _, api := range apis {
+		if api.IsMenu != adminModel.IsMenuYes || !granted[api.Id] {
+			continue
+		}
+		menuMap[api.Id] = &adminModel.MenuItem{
+			Id:             api.Id,
+			ParentId:       parentMenu(api),
+			Name:           api.Name,
+			PermissionCode: api.PermissionCode,
+			Icon:           api.Icon,
+			Route:          api.Route,
+			Component:      api.Component,
+			Sort:           api.Sort,
+			Permissions:    []string{},
+		}
+	}
+
+	// 按接口顺序挂到上级菜单下，上级菜单不可见时整个分支不显示
+	menus := []*adminModel.MenuItem{}
+	for _, api := range apis {
+		if !granted[api.Id] {
+			continue
+		}
+		if item, ok := menuMap[api.Id]; ok {
+			if item.ParentId == 0 {
+				menus = append(menus, item)
+			} else if parent, ok := menuMap[item.ParentId]; ok {
+				parent.Children = append(parent.Children, item)
+			}
+			continue
+		}
+		// 按钮权限
+		if parent, ok := menuMap[parentMenu(api)]; ok && api.PermissionCode != "" {
+			parent.Permissions = append(parent.Permissions, api.PermissionCode)
+		}
+	}
+
+	return pruneMenus(menus), nil
+}
+
+// pruneMenus 去掉没有路由且没有可见子菜单的目录
+func pruneMenus(menus []*adminModel.MenuItem) []*adminModel.MenuItem {
+	res := make([]*adminModel.MenuItem, 0, len(menus))
+	for _, item := range menus {
+		item.Children = pruneMenus(item.Children)
+		if item.Route == "" && len(item.Children) == 0 {
+			continue
+		}
+		if len(item.Children) == 0 {
+			item.Children = nil
+		}
+		res = append(res, item)
+	}
+	return res
+}
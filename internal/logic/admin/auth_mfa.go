@@ -0,0 +1,374 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
+	"gf-ant-react/internal/service"
+	"gf-ant-react/utility/challenge"
+	errorUtil "gf-ant-react/utility/error"
+	"gf-ant-react/utility/totp"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// 密码校验通过后检查是否需要两步验证
+// 需要时创建登录挑战并返回，不需要时返回 nil
+func (c *sAuthLogic) mfaChallenge(ctx context.Context, user *entity.SysUsers) (*adminModel.LoginRes, error) {
+
+	requireMfa, err := c.roleRequireMfa(ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.TotpEnabled && !requireMfa {
+		return nil, nil
+	}
+
+	res := &adminModel.LoginRes{MfaRequired: true}
+	data := &challenge.Challenge{UserId: user.Id}
+
+	// 角色要求两步验证但尚未绑定，登录时完成绑定
+	if !user.TotpEnabled {
+		data.Secret, err = totp.TotpUtility.GenerateSecret()
+		if err != nil {
+			return nil, err
+		}
+		res.MfaSetup = c.mfaSetupRes(ctx, user.Username, data.Secret)
+	}
+
+	expire := time.Duration(g.Cfg("auth").MustGet(ctx, "mfa.challengeExpire", 300).Int64()) * time.Second
+	res.MfaToken, err = challenge.ChallengeUtility.Create(ctx, data, expire)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// 两步验证登录，每次尝试都记录登录日志
+func (c *sAuthLogic) LoginMfa(ctx context.Context, req *adminModel.LoginMfaReq) (res *adminModel.LoginRes, err error) {
+
+	attempt := &adminModel.LoginAttempt{
+		Method:    adminModel.LoginMethodMfa,
+		Ip:        req.Ip,
+		UserAgent: req.UserAgent,
+	}
+
+	res, err = c.loginMfa(ctx, req, attempt)
+	c.recordLogin(ctx, attempt, res, err)
+
+	return res, err
+}
+
+func (c *sAuthLogic) loginMfa(ctx context.Context, req *adminModel.LoginMfaReq, attempt *adminModel.LoginAttempt) (res *adminModel.LoginRes, err error) {
+
+	data, err := challenge.ChallengeUtility.Get(ctx, req.MfaToken)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errorUtil.ErrorMfaChallengeInvalid
+	}
+
+	// 获取用户信息并重新检查状态，挑战期间账号可能已被禁用
+	user, _, err := service.SysUserService.GetById(ctx, data.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errorUtil.ErrorMfaChallengeInvalid
+	}
+	attempt.UserId = user.Id
+	attempt.Username = user.Username
+
+	if err = c.checkUserStatus(user); err != nil {
+		return nil, err
+	}
+
+	// 校验验证码或恢复码，首次绑定时只能使用验证码
+	var ok bool
+	if data.Secret == "" && req.Code == "" && req.RecoveryCode != "" {
+		ok, err = service.SysUserRecoveryCodeService.Use(ctx, user.Id, totp.TotpUtility.HashRecoveryCode(req.RecoveryCode))
+	} else {
+		secret := data.Secret
+		if secret == "" {
+			if secret, err = service.SysUserService.GetTotpSecret(ctx, user.Id); err != nil {
+				return nil, err
+			}
+		}
+		ok, err = c.verifyTotp(ctx, user.Id, secret, req.Code)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, c.mfaFailed(ctx, req.MfaToken, data, user, attempt)
+	}
+
+	// 挑战只能成功使用一次
+	if err = challenge.ChallengeUtility.Remove(ctx, req.MfaToken); err != nil {
+		return nil, err
+	}
+
+	// 首次绑定，开启两步验证并生成恢复码
+	var recoveryCodes []string
+	if data.Secret != "" {
+		recoveryCodes, err = c.enableTotp(ctx, user.Id, data.Secret)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res, err = c.completeLogin(ctx, user, req.Ip, req.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+	res.RecoveryCodes = recoveryCodes
+
+	return res, nil
+}
+
+// 两步验证失败
+// 计入登录失败次数，同一个挑战失败过多时作废，需要重新输入密码
+func (c *sAuthLogic) mfaFailed(ctx context.Context, token string, data *challenge.Challenge, user *entity.SysUsers, attempt *adminModel.LoginAttempt) error {
+
+	data.Failures++
+	if data.Failures >= g.Cfg("auth").MustGet(ctx, "mfa.maxFailures", 5).Int() {
+		if err := challenge.ChallengeUtility.Remove(ctx, token); err != nil {
+			return err
+		}
+	} else if err := challenge.ChallengeUtility.Update(ctx, token, data); err != nil {
+		return err
+	}
+
+	locked, err := c.loginFailed(ctx, user)
+	if err != nil {
+		return err
+	}
+	if locked {
+		attempt.Reason = adminModel.LoginReasonLockout
+	}
+
+	return errorUtil.ErrorMfaCodeInvalid
+}
+
+// 获取两步验证绑定信息
+// 生成新的密钥，校验验证码开启后才生效
+func (c *sAuthLogic) MfaSetup(ctx context.Context, userId uint64) (*adminModel.MfaSetupRes, error) {
+
+	user, _, err := service.SysUserService.GetById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("用户不存在")
+	}
+	if user.TotpEnabled {
+		return nil, errors.New("已开启两步验证，请先关闭")
+	}
+
+	secret, err := totp.TotpUtility.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = service.SysUserService.UpdateTotp(ctx, userId, secret, false); err != nil {
+		return nil, err
+	}
+
+	return c.mfaSetupRes(ctx, user.Username, secret), nil
+}
+
+// 开启两步验证，返回恢复码
+func (c *sAuthLogic) MfaEnable(ctx context.Context, req *adminModel.MfaCodeReq) (*adminModel.MfaRecoveryCodesRes, error) {
+
+	user, _, err := service.SysUserService.GetById(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("用户不存在")
+	}
+	if user.TotpEnabled {
+		return nil, errors.New("已开启两步验证")
+	}
+
+	secret, err := service.SysUserService.GetTotpSecret(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if secret == "" {
+		return nil, errors.New("请先获取两步验证绑定信息")
+	}
+
+	ok, err := c.verifyTotp(ctx, req.UserId, secret, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errorUtil.ErrorMfaCodeInvalid
+	}
+
+	codes, err := c.enableTotp(ctx, req.UserId, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adminModel.MfaRecoveryCodesRes{RecoveryCodes: codes}, nil
+}
+
+// 关闭两步验证
+func (c *sAuthLogic) MfaDisable(ctx context.Context, req *adminModel.MfaCodeReq) error {
+
+	if err := c.checkMfaCode(ctx, req); err != nil {
+		return err
+	}
+
+	requireMfa, err := c.roleRequireMfa(ctx, req.UserId)
+	if err != nil {
+		return err
+	}
+	if requireMfa {
+		return errors.New("所属角色要求开启两步验证，不能关闭")
+	}
+
+	if err = service.SysUserService.UpdateTotp(ctx, req.UserId, "", false); err != nil {
+		return err
+	}
+
+	return service.SysUserRecoveryCodeService.DeleteByUserId(ctx, req.UserId)
+}
+
+// 重新生成恢复码，旧的恢复码全部失效
+func (c *sAuthLogic) MfaRecoveryCodes(ctx context.Context, req *adminModel.MfaCodeReq) (*adminModel.MfaRecoveryCodesRes, error) {
+
+	if err := c.checkMfaCode(ctx, req); err != nil {
+		return nil, err
+	}
+
+	codes, err := c.generateRecoveryCodes(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adminModel.MfaRecoveryCodesRes{RecoveryCodes: codes}, nil
+}
+
+// 检查已开启两步验证并校验验证码
+func (c *sAuthLogic) checkMfaCode(ctx context.Context, req *adminModel.MfaCodeReq) error {
+
+	user, _, err := service.SysUserService.GetById(ctx, req.UserId)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("用户不存在")
+	}
+	if !user.TotpEnabled {
+		return errors.New("未开启两步验证")
+	}
+
+	secret, err := service.SysUserService.GetTotpSecret(ctx, req.UserId)
+	if err != nil {
+		return err
+	}
+
+	ok, err := c.verifyTotp(ctx, req.UserId, secret, req.Code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errorUtil.ErrorMfaCodeInvalid
+	}
+
+	return nil
+}
+
+// 重置两步验证，用户丢失验证器和恢复码时由管理员操作
+func (c *sAuthLogic) ResetMfa(ctx context.Context, userId uint64) error {
+
+	if err := service.SysUserService.UpdateTotp(ctx, userId, "", false); err != nil {
+		return err
+	}
+
+	return service.SysUserRecoveryCodeService.DeleteByUserId(ctx, userId)
+}
+
+// 校验TOTP验证码，同一个验证码只能使用一次
+func (c *sAuthLogic) verifyTotp(ctx context.Context, userId uint64, secret, code string) (bool, error) {
+
+	if secret == "" || code == "" {
+		return false, nil
+	}
+
+	step, ok := totp.TotpUtility.Validate(secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	// 验证码在前后偏移的时间窗口内都有效，标记保留整个窗口
+	ttl := time.Duration((2*totp.TotpUtility.Skew+1)*totp.TotpUtility.Period) * time.Second
+	return challenge.ChallengeUtility.MarkOnce(ctx, fmt.Sprintf("totp:%d:%d", userId, step), ttl)
+}
+
+// 开启两步验证并生成恢复码
+func (c *sAuthLogic) enableTotp(ctx context.Context, userId uint64, secret string) ([]string, error) {
+
+	if err := service.SysUserService.UpdateTotp(ctx, userId, secret, true); err != nil {
+		return nil, err
+	}
+
+	return c.generateRecoveryCodes(ctx, userId)
+}
+
+// 生成恢复码，数据库只保存哈希
+func (c *sAuthLogic) generateRecoveryCodes(ctx context.Context, userId uint64) ([]string, error) {
+
+	codes, err := totp.TotpUtility.GenerateRecoveryCodes(g.Cfg("auth").MustGet(ctx, "mfa.recoveryCodes", 10).Int())
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, totp.TotpUtility.HashRecoveryCode(code))
+	}
+
+	if err = service.SysUserRecoveryCodeService.Replace(ctx, userId, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// 用户的角色中是否有要求两步验证的
+func (c *sAuthLogic) roleRequireMfa(ctx context.Context, userId uint64) (bool, error) {
+
+	roles, err := service.SysRoleService.GetUserRoles(ctx, userId)
+	if err != nil {
+		return false, err
+	}
+
+	for _, role := range roles {
+		if role.RequireMfa {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// 两步验证绑定信息
+func (c *sAuthLogic) mfaSetupRes(ctx context.Context, username, secret string) *adminModel.MfaSetupRes {
+	issuer := g.Cfg("auth").MustGet(ctx, "mfa.issuer", "gf-ant-react").String()
+	return &adminModel.MfaSetupRes{
+		Secret: secret,
+		Uri:    totp.TotpUtility.ProvisioningUri(issuer, username, secret),
+	}
+}
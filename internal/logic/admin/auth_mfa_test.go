@@ -0,0 +1,46 @@
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gf-ant-react/utility/totp"
+)
+
+func TestVerifyTotpSingleUse(t *testing.T) {
+
+	ctx := context.Background()
+	c := &sAuthLogic{}
+
+	secret, err := totp.TotpUtility.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := totp.TotpUtility.GenerateCode(secret, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := c.verifyTotp(ctx, 1, secret, code); err != nil || !ok {
+		t.Fatalf("第一次使用应当通过: %v %v", ok, err)
+	}
+
+	// 同一个验证码不能再次使用
+	if ok, err := c.verifyTotp(ctx, 1, secret, code); err != nil || ok {
+		t.Fatalf("重复使用应当失败: %v %v", ok, err)
+	}
+
+	// 其他用户使用同一个时间步的验证码不受影响
+	if ok, err := c.verifyTotp(ctx, 2, secret, code); err != nil || !ok {
+		t.Fatalf("其他用户应当通过: %v %v", ok, err)
+	}
+
+	// 空密钥或空验证码直接失败
+	if ok, _ := c.verifyTotp(ctx, 3, "", code); ok {
+		t.Fatal("空密钥应当失败")
+	}
+	if ok, _ := c.verifyTotp(ctx, 3, secret, ""); ok {
+		t.Fatal("空验证码应当失败")
+	}
+}
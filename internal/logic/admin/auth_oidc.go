@@ -0,0 +1,257 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
+	"gf-ant-react/internal/service"
+	"gf-ant-react/utility/oidc"
+	"gf-ant-react/utility/password"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/grand"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// 外部身份提供方
+const IdentityProviderOidc = "oidc"
+
+// 单点登录配置
+func (c *sAuthLogic) OidcConfig(ctx context.Context) *adminModel.OidcConfigRes {
+	return &adminModel.OidcConfigRes{
+		Enabled: oidc.OidcUtility.Config.Enabled,
+		Name:    oidc.OidcUtility.Config.Name,
+	}
+}
+
+// 开始单点登录，返回身份提供方的授权地址
+func (c *sAuthLogic) OidcAuthorize(ctx context.Context) (string, error) {
+	return oidc.OidcUtility.AuthorizeUrl(ctx)
+}
+
+// 单点登录回调：换取令牌、关联本地用户后按正常登录签发令牌
+// 开启两步验证或角色要求两步验证时，与密码登录一样先返回登录挑战
+func (c *sAuthLogic) LoginOidc(ctx context.Context, req *adminModel.LoginOidcReq) (res *adminModel.LoginRes, err error) {
+
+	attempt := &adminModel.LoginAttempt{
+		Method:    adminModel.LoginMethodOidc,
+		Ip:        req.Ip,
+		UserAgent: req.UserAgent,
+	}
+
+	res, err = c.loginOidc(ctx, req, attempt)
+	c.recordLogin(ctx, attempt, res, err)
+
+	return res, err
+}
+
+func (c *sAuthLogic) loginOidc(ctx context.Context, req *adminModel.LoginOidcReq, attempt *adminModel.LoginAttempt) (res *adminModel.LoginRes, err error) {
+
+	identity, err := oidc.OidcUtility.Exchange(ctx, req.Code, req.State)
+	if err != nil {
+		return nil, err
+	}
+	attempt.Username = identity.Username
+	if attempt.Username == "" {
+		attempt.Username = identity.Email
+	}
+
+	user, err := c.oidcUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	attempt.UserId = user.Id
+	attempt.Username = user.Username
+
+	// 检查账号状态
+	if err = c.checkUserStatus(user); err != nil {
+		return nil, err
+	}
+
+	// 按组映射重新设置角色
+	config := oidc.OidcUtility.Config
+	if config.SyncRoles && len(config.GroupRoles) > 0 {
+		if err = service.SysUserService.UpdateRoles(ctx, user.Id, c.oidcRoleIds(identity)); err != nil {
+			return nil, err
+		}
+	}
+
+	challengeRes, err := c.mfaChallenge(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if challengeRes != nil {
+		return challengeRes, nil
+	}
+
+	res, err = c.completeLogin(ctx, user, req.Ip, req.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	// 密码由身份提供方管理，不提示修改本地密码
+	res.MustChangePassword = false
+
+	return res, nil
+}
+
+// 查找或创建外部身份对应的本地用户
+// 顺序: 已关联的身份 > 按已验证的邮箱关联 > 即时创建
+func (c *sAuthLogic) oidcUser(ctx context.Context, identity *oidc.Identity) (*entity.SysUsers, error) {
+
+	config := oidc.OidcUtility.Config
+
+	linked, err := service.SysUserIdentityService.GetBySubject(ctx, IdentityProviderOidc, identity.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if linked != nil {
+		user, _, err := service.SysUserService.GetById(ctx, linked.UserId)
+		if err != nil {
+			return nil, err
+		}
+		if user != nil {
+			return user, service.SysUserIdentityService.UpdateLogin(ctx, linked.Id, identity.Email)
+		}
+
+		// 本地用户已删除，重新关联
+		if err = service.SysUserIdentityService.Delete(ctx, linked.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	var user *entity.SysUsers
+
+	// 只信任身份提供方验证过的邮箱，避免通过伪造邮箱接管本地账号
+	if config.LinkByEmail && identity.Email != "" && identity.EmailVerified {
+		user, err = service.SysUserService.GetByEmail(ctx, identity.Email)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if user == nil {
+		if !config.Provision.Enabled {
+			g.Log().Infof(ctx, "单点登录用户未关联本地账号: sub=%s email=%s", identity.Subject, identity.Email)
+			return nil, errors.New("该账号尚未开通，请联系管理员")
+		}
+		if user, err = c.provisionOidcUser(ctx, identity); err != nil {
+			return nil, err
+		}
+	}
+
+	err = service.SysUserIdentityService.Create(ctx, &entity.SysUserIdentities{
+		UserId:      user.Id,
+		Provider:    IdentityProviderOidc,
+		Subject:     identity.Subject,
+		Email:       identity.Email,
+		LastLoginAt: gtime.Now(),
+		CreatedAt:   gtime.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// 即时创建用户，本地密码为随机值，只能通过单点登录登录
+func (c *sAuthLogic) provisionOidcUser(ctx context.Context, identity *oidc.Identity) (*entity.SysUsers, error) {
+
+	username, err := c.oidcUsername(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := password.HashPassword(guid.S())
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := service.SysUserService.Create(ctx, &adminModel.SysUserCreateParam{
+		Username:          username,
+		PasswordHash:      passwordHash,
+		PasswordChangedAt: gtime.Now(),
+		Email:             identity.Email,
+		DepartmentId:      oidc.OidcUtility.Config.Provision.DepartmentId,
+		Status:            adminModel.UserStatusEnabled,
+		RoleIds:           c.oidcRoleIds(identity),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	g.Log().Infof(ctx, "单点登录创建用户: id=%d username=%s sub=%s", id, username, identity.Subject)
+
+	user, _, err := service.SysUserService.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("用户不存在")
+	}
+
+	return user, nil
+}
+
+// 生成不重复的用户名: 用户名 claim > 邮箱前缀 > sub
+func (c *sAuthLogic) oidcUsername(ctx context.Context, identity *oidc.Identity) (string, error) {
+
+	username := identity.Username
+	if username == "" && identity.Email != "" {
+		username = strings.Split(identity.Email, "@")[0]
+	}
+	if username == "" {
+		username = "oidc_" + identity.Subject
+	}
+	username = gstr.SubStr(username, 0, 40)
+
+	candidate := username
+	for i := 0; i < 5; i++ {
+		user, err := service.SysUserService.GetByUsername(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if user == nil {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s_%s", username, grand.Digits(4))
+	}
+
+	return "", errors.New("无法生成用户名，请联系管理员")
+}
+
+// 默认角色加上组映射的角色
+func (c *sAuthLogic) oidcRoleIds(identity *oidc.Identity) []uint64 {
+	config := oidc.OidcUtility.Config
+	return c.groupRoleIds(config.Provision.RoleIds, config.GroupRoles, identity.Groups)
+}
+
+// 合并默认角色和组映射的角色，去掉重复的角色
+func (c *sAuthLogic) groupRoleIds(defaultRoleIds []uint64, groupRoles map[string][]uint64, groups []string) []uint64 {
+
+	var roleIds []uint64
+	seen := make(map[uint64]bool)
+	add := func(ids []uint64) {
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				roleIds = append(roleIds, id)
+			}
+		}
+	}
+
+	add(defaultRoleIds)
+	for _, group := range groups {
+		add(groupRoles[group])
+	}
+
+	return roleIds
+}
@@ -0,0 +1,97 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	"gf-ant-react/internal/model/entity"
+	"gf-ant-react/internal/service"
+	"gf-ant-react/utility/password"
+)
+
+// 校验新密码是否符合密码策略，userId 不为 0 时同时检查历史密码
+func (c *sAuthLogic) ValidatePassword(ctx context.Context, userId uint64, username, newPassword string) error {
+
+	policy, err := password.GetPolicy(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = policy.Validate(newPassword, username); err != nil {
+		return err
+	}
+
+	if userId == 0 || policy.History <= 0 {
+		return nil
+	}
+
+	// 当前密码和最近使用过的密码都不能再用
+	hashes, err := service.SysUserPasswordHistoryService.GetRecent(ctx, userId, policy.History)
+	if err != nil {
+		return err
+	}
+	current, err := service.SysUserService.GetPasswordHash(ctx, userId)
+	if err != nil {
+		return err
+	}
+	hashes = append(hashes, current)
+
+	for _, hash := range hashes {
+		if hash != "" && password.CheckPasswordHash(newPassword, hash) {
+			return errors.New("不能使用最近用过的密码")
+		}
+	}
+
+	return nil
+}
+
+// 修改密码
+// 校验密码策略和历史密码，保存后用户的所有登录失效
+func (c *sAuthLogic) ChangePassword(ctx context.Context, userId uint64, username, newPassword string) error {
+
+	if err := c.ValidatePassword(ctx, userId, username, newPassword); err != nil {
+		return err
+	}
+
+	passwordHash, err := password.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	if err = service.SysUserService.UpdatePassword(ctx, userId, passwordHash); err != nil {
+		return err
+	}
+
+	if err = c.RecordPasswordHistory(ctx, userId, passwordHash); err != nil {
+		return err
+	}
+
+	// 密码已修改，旧的登录全部失效
+	return c.RevokeUserTokens(ctx, userId)
+}
+
+// 记录历史密码，未开启历史密码检查时不记录
+func (c *sAuthLogic) RecordPasswordHistory(ctx context.Context, userId uint64, passwordHash string) error {
+
+	policy, err := password.GetPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	if policy.History <= 0 {
+		return nil
+	}
+
+	return service.SysUserPasswordHistoryService.Create(ctx, userId, passwordHash, policy.History)
+}
+
+// 密码是否已过期，只检查本地密码
+func (c *sAuthLogic) passwordExpired(ctx context.Context, user *entity.SysUsers) (bool, error) {
+	if !c.isLocalUser(user) {
+		return false, nil
+	}
+	policy, err := password.GetPolicy(ctx)
+	if err != nil {
+		return false, err
+	}
+	return policy.IsExpired(user.PasswordChangedAt), nil
+}
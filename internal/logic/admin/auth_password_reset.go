@@ -0,0 +1,195 @@
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
+	"gf-ant-react/internal/service"
+	"gf-ant-react/utility/mail"
+	"gf-ant-react/utility/ratelimit"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+var errPasswordResetInvalid = errors.New("重置链接无效或已过期，请重新申请")
+
+// 找回密码：向账号绑定的邮箱发送重置链接
+// 账号不存在、没有邮箱或不能重置时同样返回成功，避免暴露账号是否存在
+func (c *sAuthLogic) ForgotPassword(ctx context.Context, req *adminModel.ForgotPasswordReq) error {
+
+	cfg := g.Cfg("auth")
+	if !cfg.MustGet(ctx, "passwordReset.enabled", true).Bool() {
+		return errors.New("未开启找回密码，请联系管理员")
+	}
+
+	// 按IP和提交的账号分别限制频率，账号不论是否存在都计数
+	window := time.Duration(cfg.MustGet(ctx, "passwordReset.window", 3600).Int64()) * time.Second
+	account := strings.ToLower(strings.TrimSpace(req.Account))
+	ok, err := ratelimit.RateLimitUtility.AllowAll(ctx, window,
+		ratelimit.Limit{Key: "password-reset:ip:" + req.Ip, Limit: cfg.MustGet(ctx, "passwordReset.ipLimit", 10).Int()},
+		ratelimit.Limit{Key: "password-reset:account:" + account, Limit: cfg.MustGet(ctx, "passwordReset.accountLimit", 3).Int()},
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("请求过于频繁，请稍后再试")
+	}
+
+	user, err := c.passwordResetUser(ctx, account)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		g.Log().Infof(ctx, "找回密码的账号不存在或不能重置: account=%s ip=%s", account, req.Ip)
+		return nil
+	}
+
+	// 同一用户只保留最新的重置链接
+	if err = service.SysPasswordResetService.InvalidateByUserId(ctx, user.Id); err != nil {
+		return err
+	}
+
+	token, tokenHash, err := c.passwordResetToken()
+	if err != nil {
+		return err
+	}
+
+	expire := cfg.MustGet(ctx, "passwordReset.expire", 1800).Int64()
+	err = service.SysPasswordResetService.Create(ctx, &entity.SysPasswordResets{
+		UserId:    user.Id,
+		TokenHash: tokenHash,
+		Ip:        req.Ip,
+		ExpiresAt: gtime.Now().Add(time.Duration(expire) * time.Second),
+		CreatedAt: gtime.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	link := cfg.MustGet(ctx, "passwordReset.url", "http://localhost:3000/auth/reset-password").String() + "?token=" + url.QueryEscape(token)
+	message := &mail.Message{
+		To:      []string{user.Email},
+		Subject: "重置密码",
+		Body: fmt.Sprintf("%s，您好：\n\n我们收到了重置您账号密码的申请，请在 %d 分钟内打开以下链接设置新密码，链接只能使用一次：\n\n%s\n\n如果这不是您本人的操作，请忽略本邮件，您的密码不会改变。\n",
+			user.Username, expire/60, link),
+	}
+
+	// 异步发送，响应时间不因账号是否存在而不同
+	go func(ctx context.Context) {
+		if err := mail.MailUtility.Send(ctx, message); err != nil {
+			g.Log().Warningf(ctx, "发送重置密码邮件失败: userId=%d err=%v", user.Id, err)
+		}
+	}(context.WithoutCancel(ctx))
+
+	return nil
+}
+
+// 按用户名或邮箱查找可以重置密码的用户
+// 只有绑定了邮箱且未禁用的本地密码用户可以重置
+func (c *sAuthLogic) passwordResetUser(ctx context.Context, account string) (*entity.SysUsers, error) {
+
+	if account == "" {
+		return nil, nil
+	}
+
+	user, err := service.SysUserService.GetByUsername(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil && strings.Contains(account, "@") {
+		if user, err = service.SysUserService.GetByEmail(ctx, account); err != nil {
+			return nil, err
+		}
+	}
+
+	if user == nil || user.Email == "" || user.Status == adminModel.UserStatusDisabled || !c.isLocalUser(user) {
+		return nil, nil
+	}
+
+	return user, nil
+}
+
+// 生成重置令牌，数据库只保存哈希
+func (c *sAuthLogic) passwordResetToken() (token string, hash string, err error) {
+
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(buf)
+
+	return token, c.passwordResetHash(token), nil
+}
+
+func (c *sAuthLogic) passwordResetHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// 通过重置链接设置新密码
+// 成功后链接作废、解除登录锁定，用户的所有登录失效
+func (c *sAuthLogic) ConfirmPasswordReset(ctx context.Context, req *adminModel.ConfirmPasswordResetReq) error {
+
+	reset, err := service.SysPasswordResetService.GetByHash(ctx, c.passwordResetHash(req.Token))
+	if err != nil {
+		return err
+	}
+	if reset == nil || reset.UsedAt != nil || reset.ExpiresAt.Before(gtime.Now()) {
+		return errPasswordResetInvalid
+	}
+
+	user, _, err := service.SysUserService.GetById(ctx, reset.UserId)
+	if err != nil {
+		return err
+	}
+	if user == nil || user.Status == adminModel.UserStatusDisabled || !c.isLocalUser(user) {
+		return errPasswordResetInvalid
+	}
+
+	// 先校验密码策略，不符合时链接仍可继续使用
+	if err = c.ValidatePassword(ctx, user.Id, user.Username, req.Password); err != nil {
+		return err
+	}
+
+	// 链接只能使用一次，并发请求中只有一个能成功
+	ok, err := service.SysPasswordResetService.MarkUsed(ctx, reset.Id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errPasswordResetInvalid
+	}
+
+	if err = c.ChangePassword(ctx, user.Id, user.Username, req.Password); err != nil {
+		return err
+	}
+
+	// 作废其他未使用的链接
+	if err = service.SysPasswordResetService.InvalidateByUserId(ctx, user.Id); err != nil {
+		return err
+	}
+
+	// 能收到邮件说明是本人，解除因密码错误导致的锁定
+	if user.Status == adminModel.UserStatusLocked || user.LoginAttempts > 0 {
+		if err = service.SysUserService.Unlock(ctx, user.Id); err != nil {
+			return err
+		}
+	}
+
+	g.Log().Infof(ctx, "通过邮件重置密码: userId=%d ip=%s", user.Id, req.Ip)
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
+	"gf-ant-react/internal/service"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// IsIgnoredRoute 不需要鉴权的路由，以接口表中的鉴权方式为准，auth.yaml 只用于初始化
+func (c *sAuthLogic) IsIgnoredRoute(ctx context.Context, method, path string) bool {
+	return c.routeAuthType(ctx, method, path) == adminModel.AuthTypeIgnore
+}
+
+// IsPublicRoute 只需要登录不需要权限的路由，以接口表中的鉴权方式为准，auth.yaml 只用于初始化
+func (c *sAuthLogic) IsPublicRoute(ctx context.Context, method, path string) bool {
+	return c.routeAuthType(ctx, method, path) == adminModel.AuthTypePublic
+}
+
+// 接口表中设置的鉴权方式，查询失败时需要权限
+func (c *sAuthLogic) routeAuthType(ctx context.Context, method, path string) int {
+	authType, err := service.SysPermissionCacheService.GetAuthType(ctx, strings.ToUpper(method), path)
+	if err != nil {
+		g.Log().Warningf(ctx, "获取接口鉴权方式失败: %s %s err=%v", method, path, err)
+		return adminModel.AuthTypePermission
+	}
+	return authType
+}
+
+// RouteType 路由的鉴权方式，判断顺序与 MiddlewareAuthAdmin 一致
+func (c *sAuthLogic) RouteType(ctx context.Context, method, path string) string {
+	if c.IsIgnoredRoute(ctx, method, path) {
+		return adminModel.RouteTypeIgnore
+	}
+	if c.IsPublicRoute(ctx, method, path) {
+		return adminModel.RouteTypePublic
+	}
+	return adminModel.RouteTypePermission
+}
+
+// DiagnosePermission 诊断用户能否访问接口，使用与 MiddlewareAuthAdmin 相同的判断
+// 只诊断使用令牌登录的情况，不包括代登录期间禁止的操作和 API Key 的权限范围
+func (c *sAuthLogic) DiagnosePermission(ctx context.Context, req *adminModel.DiagnosePermissionReq) (*adminModel.DiagnosePermissionRes, error) {
+
+	res := &adminModel.DiagnosePermissionRes{
+		Method:         strings.ToUpper(req.Method),
+		Url:            req.Url,
+		PermissionCode: req.PermissionCode,
+		Roles:          []*adminModel.DiagnosePermissionRole{},
+	}
+
+	// 给出请求时按路由匹配权限码，没有匹配的接口时权限码为空
+	if res.Url != "" {
+		res.PermissionCode = ""
+		if code, err := service.SysPermissionCacheService.GetPermissionCode(ctx, res.Method, res.Url); err == nil {
+			res.PermissionCode = code
+		}
+	}
+
+	// 匹配的接口
+	if res.PermissionCode != "" {
+		api, err := service.SysApiService.GetByPermissionCode(ctx, res.PermissionCode)
+		if err != nil {
+			return nil, err
+		}
+		res.Api = api
+		if res.Api != nil && res.Url == "" {
+			res.Method, res.Url = res.Api.Method, res.Api.Url
+		}
+	}
+
+	// 路由的鉴权方式
+	res.RouteType = adminModel.RouteTypePermission
+	if res.Url != "" {
+		res.RouteType = c.RouteType(ctx, res.Method, res.Url)
+	}
+
+	// 用户状态
+	user, roleIds, err := service.SysPermissionCacheService.GetUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("用户不存在")
+	}
+	res.User = &adminModel.DiagnosePermissionUser{
+		Id:       user.Id,
+		Username: user.Username,
+		Status:   user.Status,
+	}
+	if err = c.checkUserStatus(user); err != nil {
+		res.User.Error = err.Error()
+	}
+
+	// 每个角色是否授予权限码
+	res.Roles, err = c.diagnoseRoles(ctx, roleIds, res.Api, res.PermissionCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// 最终结果，判断顺序与中间件一致
+	switch res.RouteType {
+	case adminModel.RouteTypeIgnore:
+		res.Allowed, res.Reason = true, "不需要鉴权的路由"
+		return res, nil
+	case adminModel.RouteTypePublic:
+		res.Allowed, res.Reason = true, "只需要登录不需要权限的路由"
+		return res, nil
+	}
+
+	var checkErr error
+	if req.Url != "" {
+		res.Allowed, checkErr = c.CheckPermission(ctx, &adminModel.CheckPermissionReq{
+			UserId: req.UserId,
+			Url:    res.Url,
+			Method: res.Method,
+		})
+	} else {
+		// 只给出权限码时跳过路由匹配，其余与 CheckPermission 相同
+		var roles []uint64
+		if roles, checkErr = c.permissionRoles(ctx, req.UserId); checkErr == nil {
+			if res.Api == nil {
+				checkErr = errors.New("接口不存在")
+			} else {
+				res.Allowed, checkErr = service.SysPermissionCacheService.CheckPermission(ctx, roles, res.PermissionCode)
+			}
+		}
+	}
+
+	switch {
+	case checkErr != nil:
+		res.Allowed, res.Reason = false, checkErr.Error()
+	case res.Allowed:
+		res.Reason = "有权限"
+	default:
+		res.Reason = "没有权限"
+	}
+
+	return res, nil
+}
+
+// diagnoseRoles 用户的每个角色是否授予权限码，以及直接分配该接口的角色
+func (c *sAuthLogic) diagnoseRoles(ctx context.Context, roleIds []uint64, api *entity.SysApis, permissionCode string) ([]*adminModel.DiagnosePermissionRole, error) {
+
+	result := []*adminModel.DiagnosePermissionRole{}
+	if len(roleIds) == 0 {
+		return result, nil
+	}
+
+	all, err := service.SysRoleService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uint64]string, len(all))
+	for _, role := range all {
+		names[role.Id] = role.Name
+	}
+
+	for _, role := range all {
+		if !slices.Contains(roleIds, role.Id) {
+			continue
+		}
+
+		item := &adminModel.DiagnosePermissionRole{
+			Id:        role.Id,
+			Name:      role.Name,
+			Status:    role.Status,
+			IsSuper:   role.IsSuper,
+			GrantedBy: []string{},
+		}
+		result = append(result, item)
+
+		if permissionCode != "" {
+			if item.Granted, err = service.SysPermissionCacheService.CheckPermission(ctx, []uint64{role.Id}, permissionCode); err != nil {
+				return nil, err
+			}
+		}
+		if api == nil {
+			continue
+		}
+
+		// 角色自己及继承的上级角色中直接分配了该接口的角色
+		chain, err := service.SysRoleService.GetAncestors(ctx, role.Id)
+		if err != nil {
+			return nil, err
+		}
+		chainIds := make([]uint64, 0, len(chain))
+		for _, r := range chain {
+			chainIds = append(chainIds, r.Id)
+		}
+		apiIds, err := service.SysRoleService.GetApiIdsByRoleIds(ctx, chainIds)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range chainIds {
+			if slices.Contains(apiIds[id], api.Id) {
+				item.GrantedBy = append(item.GrantedBy, names[id])
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,91 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	adminModel "gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/service"
+	"gf-ant-react/utility/jwt"
+	"gf-ant-react/utility/revocation"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// 会话列表
+// currentSessionId 为当前请求所在的会话，列表中会标记出来
+func (c *sAuthLogic) Sessions(ctx context.Context, param *adminModel.SysUserSessionListParam, currentSessionId string) (*adminModel.SysUserSessionListResult, error) {
+
+	list, total, err := service.SysUserSessionService.GetList(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range list {
+		item.Current = item.SessionId == currentSessionId
+	}
+
+	return &adminModel.SysUserSessionListResult{
+		List:  list,
+		Total: total,
+	}, nil
+}
+
+// 结束自己的会话
+func (c *sAuthLogic) TerminateOwnSession(ctx context.Context, userId uint64, sessionId string) error {
+
+	session, err := service.SysUserSessionService.GetBySessionId(ctx, sessionId)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserId != userId {
+		return errors.New("会话不存在")
+	}
+
+	return c.TerminateSession(ctx, sessionId)
+}
+
+// 结束会话，会话中签发的访问令牌和刷新令牌全部失效
+func (c *sAuthLogic) TerminateSession(ctx context.Context, sessionId string) error {
+
+	if err := revocation.RevocationUtility.RevokeSession(ctx, sessionId); err != nil {
+		return err
+	}
+
+	if err := service.SysRefreshTokenService.RevokeFamily(ctx, sessionId); err != nil {
+		return err
+	}
+
+	return service.SysUserSessionService.Revoke(ctx, sessionId)
+}
+
+// 记录会话访问并检查会话是否仍然有效
+// 每个会话在 session.touchInterval 内只访问一次数据库，因此在其他副本结束的会话最迟在该间隔后失效
+func (c *sAuthLogic) TouchSession(ctx context.Context, claims *jwt.Claims, ip string) (bool, error) {
+
+	if claims.SessionID == "" {
+		return false, nil
+	}
+
+	interval := time.Duration(g.Cfg("auth").MustGet(ctx, "session.touchInterval", 60).Int64()) * time.Second
+	ok, err := gcache.SetIfNotExist(ctx, fmt.Sprintf("session:touch:%s", claims.SessionID), 1, interval)
+	if err != nil || !ok {
+		return true, err
+	}
+
+	session, err := service.SysUserSessionService.GetBySessionId(ctx, claims.SessionID)
+	if err != nil {
+		return false, err
+	}
+
+	// 没有会话记录或会话已结束，记入吊销列表，间隔内的后续请求直接拒绝
+	if session == nil || session.RevokedAt != nil || session.ExpiresAt.Before(gtime.Now()) {
+		return false, revocation.RevocationUtility.RevokeSession(ctx, claims.SessionID)
+	}
+
+	return true, service.SysUserSessionService.Touch(ctx, claims.SessionID, ip)
+}
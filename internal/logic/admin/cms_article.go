@@ -7,9 +7,11 @@ import (
 	"gf-ant-react/internal/model/admin"
 	"gf-ant-react/internal/model/entity"
 	"gf-ant-react/internal/service"
+	"gf-ant-react/utility/auth"
 
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/gconv"
 )
 
 type sCmsArticleLogic struct{}
@@ -35,6 +37,7 @@ func (s *sCmsArticleLogic) CreateArticle(ctx context.Context, req *cms.ArticleCr
 		ArticleType:    req.ArticleType,
 		ExternalUrl:    req.ExternalUrl,
 		CategoryId:     req.CategoryId,
+		AuthorId:       gconv.String(auth.GetUserId(ctx)),
 		AuthorName:     req.AuthorName,
 		CoverImage:     req.CoverImage,
 		Status:         req.Status,
@@ -63,6 +66,9 @@ func (s *sCmsArticleLogic) UpdateArticle(ctx context.Context, req *cms.ArticleUp
 	if article == nil {
 		return gerror.NewCode(gcode.CodeBusinessValidationFailed, "文章不存在")
 	}
+	if err = AuthLogic.checkDataScope(ctx, service.CmsArticleService.CheckInScope, article.Id); err != nil {
+		return err
+	}
 
 	// 检查文章标题是否已被其他文章使用
 	exists, err := service.CmsArticleService.CheckTitleExists(ctx, req.Title, req.Id)
@@ -109,6 +115,9 @@ func (s *sCmsArticleLogic) DeleteArticle(ctx context.Context, id uint64) error {
 	if article == nil {
 		return gerror.NewCode(gcode.CodeBusinessValidationFailed, "文章不存在")
 	}
+	if err = AuthLogic.checkDataScope(ctx, service.CmsArticleService.CheckInScope, article.Id); err != nil {
+		return err
+	}
 
 	// 调用服务层删除文章
 	return service.CmsArticleService.DeleteArticle(ctx, id)
@@ -124,6 +133,9 @@ func (s *sCmsArticleLogic) GetArticleDetail(ctx context.Context, id uint64) (*en
 	if article == nil {
 		return nil, gerror.NewCode(gcode.CodeBusinessValidationFailed, "文章不存在")
 	}
+	if err = AuthLogic.checkDataScope(ctx, service.CmsArticleService.CheckInScope, article.Id); err != nil {
+		return nil, err
+	}
 
 	return article, nil
 }
@@ -141,6 +153,13 @@ func (s *sCmsArticleLogic) GetArticleList(ctx context.Context, req *cms.ArticleL
 		IsRecommend: req.IsRecommend,
 	}
 
+	// 只查询数据权限范围内的文章
+	scope, err := AuthLogic.DataScope(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	searchParams.Scope = scope
+
 	// 调用服务层获取文章列表
 	articles, total, err := service.CmsArticleService.GetArticleList(ctx, req.Page, req.Size, searchParams)
 	if err != nil {
@@ -160,6 +179,9 @@ func (s *sCmsArticleLogic) UpdateArticleStatus(ctx context.Context, id uint64, s
 	if article == nil {
 		return gerror.NewCode(gcode.CodeBusinessValidationFailed, "文章不存在")
 	}
+	if err = AuthLogic.checkDataScope(ctx, service.CmsArticleService.CheckInScope, article.Id); err != nil {
+		return err
+	}
 
 	// 调用服务层更新状态
 	return service.CmsArticleService.UpdateArticleStatus(ctx, id, status)
@@ -175,6 +197,9 @@ func (s *sCmsArticleLogic) UpdateArticleTopStatus(ctx context.Context, id uint64
 	if article == nil {
 		return gerror.NewCode(gcode.CodeBusinessValidationFailed, "文章不存在")
 	}
+	if err = AuthLogic.checkDataScope(ctx, service.CmsArticleService.CheckInScope, article.Id); err != nil {
+		return err
+	}
 
 	// 调用服务层更新置顶状态
 	return service.CmsArticleService.UpdateArticleTopStatus(ctx, id, isTop)
@@ -190,6 +215,9 @@ func (s *sCmsArticleLogic) UpdateArticleHotStatus(ctx context.Context, id uint64
 	if article == nil {
 		return gerror.NewCode(gcode.CodeBusinessValidationFailed, "文章不存在")
 	}
+	if err = AuthLogic.checkDataScope(ctx, service.CmsArticleService.CheckInScope, article.Id); err != nil {
+		return err
+	}
 
 	// 调用服务层更新热门状态
 	return service.CmsArticleService.UpdateArticleHotStatus(ctx, id, isHot)
@@ -205,6 +233,9 @@ func (s *sCmsArticleLogic) UpdateArticleRecommendStatus(ctx context.Context, id
 	if article == nil {
 		return gerror.NewCode(gcode.CodeBusinessValidationFailed, "文章不存在")
 	}
+	if err = AuthLogic.checkDataScope(ctx, service.CmsArticleService.CheckInScope, article.Id); err != nil {
+		return err
+	}
 
 	// 调用服务层更新推荐状态
 	return service.CmsArticleService.UpdateArticleRecommendStatus(ctx, id, isRecommend)
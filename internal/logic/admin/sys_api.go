@@ -2,11 +2,13 @@ package admin
 
 import (
 	"context"
+	"fmt"
 	"sort"
 
 	"gf-ant-react/internal/model/admin"
 	"gf-ant-react/internal/model/entity"
 	"gf-ant-react/internal/service"
+	"gf-ant-react/utility/route"
 )
 
 type sSysApiLogic struct{}
@@ -14,13 +16,55 @@ type sSysApiLogic struct{}
 var SysApiLogic = &sSysApiLogic{}
 
 func (s *sSysApiLogic) Create(ctx context.Context, data *admin.SysApiCreateParam) (id uint64, err error) {
+	if err = s.checkRoute(ctx, 0, data.Method, data.Url); err != nil {
+		return 0, err
+	}
 	return service.SysApiService.Create(ctx, data)
 }
 
 func (s *sSysApiLogic) Update(ctx context.Context, data *admin.SysApiUpdateParam) error {
+	if err := s.checkRoute(ctx, data.Id, data.Method, data.Url); err != nil {
+		return err
+	}
 	return service.SysApiService.Update(ctx, data)
 }
 
+// checkRoute 检查路由规则是否有效，以及是否与其他接口的规则冲突
+// 形状相同的规则（如 /user/:id 和 /user/{uid}）会匹配同样的请求，无法决定使用哪一条
+func (s *sSysApiLogic) checkRoute(ctx context.Context, id uint64, method, url string) error {
+
+	pattern, err := route.Parse(method, url)
+	if err != nil {
+		return err
+	}
+
+	apis, err := service.SysApiService.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, api := range apis {
+		if api.Id == id {
+			continue
+		}
+		other, err := route.Parse(api.Method, api.Url)
+		if err != nil {
+			continue
+		}
+		if pattern.Conflict(other) {
+			return fmt.Errorf("路由规则与接口「%s」(%s %s) 冲突", api.Name, api.Method, api.Url)
+		}
+	}
+
+	return nil
+}
+
+// Reload 清空鉴权缓存，直接修改数据库后使用，多副本部署时同时通知其他副本
+func (s *sSysApiLogic) Reload(ctx context.Context) *service.SysPermissionCacheStats {
+	service.SysPermissionCacheService.InvalidateAll(ctx)
+	return service.SysPermissionCacheService.Stats()
+}
+
 func (s *sSysApiLogic) Delete(ctx context.Context, id uint64) error {
 	return service.SysApiService.Delete(ctx, id)
 }
@@ -37,9 +81,10 @@ func (s *sSysApiLogic) GetTree(ctx context.Context) (*admin.SysApiTreeResult, er
 	return &admin.SysApiTreeResult{
 		List: treeItems,
 		Config: map[string]interface{}{
-			"methodMap": admin.MethodMap,
-			"isMenuMap": admin.IsMenuMap,
-			"statusMap": admin.StatusMap,
+			"methodMap":   admin.MethodMap,
+			"isMenuMap":   admin.IsMenuMap,
+			"authTypeMap": admin.AuthTypeMap,
+			"statusMap":   admin.StatusMap,
 		},
 	}, nil
 }
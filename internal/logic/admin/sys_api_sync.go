@@ -0,0 +1,334 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
+	"gf-ant-react/internal/service"
+	"gf-ant-react/utility/route"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// ServerRoutes 服务中注册的接口路由，只包括规范路由（func(ctx, req) (res, err)）的接口
+func (s *sSysApiLogic) ServerRoutes(server *ghttp.Server) []*admin.SysApiRoute {
+
+	var routes []*admin.SysApiRoute
+	for _, item := range server.GetRoutes() {
+		if !item.IsServiceHandler || item.Handler == nil || item.Handler.Info.Type == nil || item.Handler.Info.Type.NumIn() != 2 {
+			continue
+		}
+
+		method := strings.ToUpper(item.Method)
+		if method == "ALL" {
+			method = route.MethodAny
+		}
+
+		routes = append(routes, &admin.SysApiRoute{
+			Method:  method,
+			Url:     item.Route,
+			Tag:     item.Handler.GetMetaTag("tags"),
+			Summary: item.Handler.GetMetaTag("summary"),
+		})
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Url != routes[j].Url {
+			return routes[i].Url < routes[j].Url
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	return routes
+}
+
+// Sync 把注册的路由同步到接口表
+// 没有接口的路由按 tags 生成权限码后新增，新增的接口不属于任何角色，需要再分配给角色
+// 同一 tags 的接口放在以分组权限码命名的上级接口下，上级接口不存在时一并新增
+// 没有对应路由的接口（不包括菜单和有下级的接口）列为孤立接口，可选择禁用
+// 已有不需要鉴权和只需要登录的接口匹配的路由不需要权限，跳过；新增的接口按 auth.yaml 设置鉴权方式
+func (s *sSysApiLogic) Sync(ctx context.Context, routes []*admin.SysApiRoute, param *admin.SysApiSyncParam) (*admin.SysApiSyncResult, error) {
+
+	// 先初始化已有接口的鉴权方式，以免跳过的路由判断不准确
+	if !param.DryRun {
+		if err := s.SeedAuthTypes(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	apis, err := service.SysApiService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		table    = route.NewTable()
+		patterns = make([]*route.Pattern, len(apis))
+		used     = make([]bool, len(apis))
+		codes    = make(map[string]uint64, len(apis)) // 权限码 => 接口ID，只包括已保存的接口
+		taken    = make(map[string]bool, len(apis))   // 已使用的权限码，包括本次新增的接口
+		parents  = make(map[uint64]bool)
+	)
+	for i, api := range apis {
+		codes[api.PermissionCode] = api.Id
+		taken[api.PermissionCode] = true
+		parents[api.ParentId] = true
+		_ = table.Add(api.Method, api.Url, api.PermissionCode)
+		patterns[i], _ = route.Parse(api.Method, api.Url)
+	}
+
+	result := &admin.SysApiSyncResult{
+		Created: []*admin.SysApiCreateParam{},
+		Orphans: []*entity.SysApis{},
+	}
+	for _, r := range routes {
+
+		for i, pattern := range patterns {
+			if pattern != nil && pattern.Match(r.Method, r.Url) {
+				used[i] = true
+			}
+		}
+
+		if AuthLogic.RouteType(ctx, r.Method, r.Url) != admin.RouteTypePermission {
+			result.Skipped++
+			continue
+		}
+		if _, ok := table.Lookup(r.Method, r.Url); ok {
+			result.Existing++
+			continue
+		}
+
+		group, code := s.routeCode(r, taken)
+
+		// 分组的上级接口
+		if !taken[group] {
+			data, err := s.createGroup(ctx, group, r.Tag, param.DryRun, codes, taken)
+			if err != nil {
+				return nil, err
+			}
+			result.Created = append(result.Created, data)
+		}
+
+		name := r.Summary
+		if name == "" {
+			name = r.Method + " " + r.Url
+		}
+		data := &admin.SysApiCreateParam{
+			ParentId:       codes[group],
+			Name:           gstr.SubStrRune(name, 0, 50),
+			PermissionCode: code,
+			Url:            r.Url,
+			Method:         r.Method,
+			Status:         admin.ApiStatusEnabled,
+			IsMenu:         admin.IsMenuNo,
+			AuthType:       s.defaultAuthType(ctx, r.Method, r.Url),
+			Description:    gstr.SubStrRune(fmt.Sprintf("同步自路由 %s %s，分组 %s", r.Method, r.Url, r.Tag), 0, 500),
+		}
+
+		if !param.DryRun {
+			id, err := service.SysApiService.Create(ctx, data)
+			if err != nil {
+				return nil, err
+			}
+			codes[code] = id
+		}
+		taken[code] = true
+		_ = table.Add(r.Method, r.Url, code)
+		result.Created = append(result.Created, data)
+	}
+
+	for i, api := range apis {
+		if used[i] || api.IsMenu == admin.IsMenuYes || parents[api.Id] {
+			continue
+		}
+		result.Orphans = append(result.Orphans, api)
+		if param.DisableOrphans && !param.DryRun && api.Status == admin.ApiStatusEnabled {
+			if err = service.SysApiService.UpdateStatus(ctx, api.Id, admin.ApiStatusDisabled); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	g.Log().Infof(ctx, "同步路由: 新增 %d 已有 %d 跳过 %d 孤立 %d dryRun=%v",
+		len(result.Created), result.Existing, result.Skipped, len(result.Orphans), param.DryRun)
+
+	return result, nil
+}
+
+// createGroup 新增分组的上级接口，只用于组织接口树
+func (s *sSysApiLogic) createGroup(ctx context.Context, group, tag string, dryRun bool, codes map[string]uint64, taken map[string]bool) (*admin.SysApiCreateParam, error) {
+
+	data := &admin.SysApiCreateParam{
+		Name:           gstr.SubStrRune(tag, 0, 50),
+		PermissionCode: group,
+		Url:            "/" + group, // 不会匹配实际的请求
+		Method:         admin.MethodGET,
+		Status:         admin.ApiStatusEnabled,
+		IsMenu:         admin.IsMenuNo,
+		AuthType:       admin.AuthTypePermission,
+		Description:    gstr.SubStrRune(fmt.Sprintf("同步路由时按分组 %s 新增", tag), 0, 500),
+	}
+	if data.Name == "" {
+		data.Name = group
+	}
+
+	if !dryRun {
+		id, err := service.SysApiService.Create(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+		codes[group] = id
+	}
+	taken[group] = true
+
+	return data, nil
+}
+
+// defaultAuthType auth.yaml 中配置的鉴权方式，只用于初始化接口
+func (s *sSysApiLogic) defaultAuthType(ctx context.Context, method, url string) int {
+	cfg := g.Cfg("auth")
+	if route.MatchMap(cfg.MustGet(ctx, "ignoreRoutes").MapStrStr(), method, url) {
+		return admin.AuthTypeIgnore
+	}
+	if route.MatchMap(cfg.MustGet(ctx, "publicRoutes").MapStrStr(), method, url) {
+		return admin.AuthTypePublic
+	}
+	return admin.AuthTypePermission
+}
+
+// SeedAuthTypes 按 auth.yaml 中的 ignoreRoutes 和 publicRoutes 初始化接口的鉴权方式
+// 鉴权方式为空的接口按配置设置；接口表从未初始化时，配置中没有对应接口的路由新增接口
+// 已初始化后以数据库为准，管理员删除的接口不会重新创建
+func (s *sSysApiLogic) SeedAuthTypes(ctx context.Context) error {
+
+	// 先判断是否初始化过，下面会填充为空的鉴权方式
+	seeded, err := service.SysApiService.HasAuthTypeSet(ctx)
+	if err != nil {
+		return err
+	}
+
+	unset, err := service.SysApiService.GetAuthTypeUnsetIds(ctx)
+	if err != nil {
+		return err
+	}
+	apis, err := service.SysApiService.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		table = route.NewTable()
+		codes = make(map[string]uint64, len(apis))
+		taken = make(map[string]bool, len(apis))
+	)
+	for _, api := range apis {
+		codes[api.PermissionCode] = api.Id
+		taken[api.PermissionCode] = true
+		_ = table.Add(api.Method, api.Url, api.PermissionCode)
+		if slices.Contains(unset, api.Id) {
+			if err = service.SysApiService.UpdateAuthType(ctx, api.Id, s.defaultAuthType(ctx, api.Method, api.Url)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if seeded {
+		return nil
+	}
+
+	cfg := g.Cfg("auth")
+	defaults := []struct {
+		routes   map[string]string
+		authType int
+	}{
+		{cfg.MustGet(ctx, "ignoreRoutes").MapStrStr(), admin.AuthTypeIgnore},
+		{cfg.MustGet(ctx, "publicRoutes").MapStrStr(), admin.AuthTypePublic},
+	}
+	for _, item := range defaults {
+		paths := make([]string, 0, len(item.routes))
+		for path := range item.routes {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			method := strings.ToUpper(item.routes[path])
+			if _, ok := table.Lookup(method, path); ok {
+				continue
+			}
+
+			// 分组取路径的第一段，如 /auth/login 的分组为 auth
+			r := &admin.SysApiRoute{Method: method, Url: path, Tag: strings.SplitN(strings.Trim(path, "/"), "/", 2)[0]}
+			group, code := s.routeCode(r, taken)
+			if !taken[group] {
+				if _, err = s.createGroup(ctx, group, r.Tag, false, codes, taken); err != nil {
+					return err
+				}
+			}
+
+			data := &admin.SysApiCreateParam{
+				ParentId:       codes[group],
+				Name:           gstr.SubStrRune(method+" "+path, 0, 50),
+				PermissionCode: code,
+				Url:            path,
+				Method:         method,
+				Status:         admin.ApiStatusEnabled,
+				IsMenu:         admin.IsMenuNo,
+				AuthType:       item.authType,
+				Description:    "按 auth.yaml 的默认配置新增",
+			}
+			id, err := service.SysApiService.Create(ctx, data)
+			if err != nil {
+				return err
+			}
+			codes[code] = id
+			taken[code] = true
+			if err = table.Add(method, path, code); err != nil {
+				g.Log().Warningf(ctx, "auth.yaml 中的路由规则无效: %s %s err=%v", method, path, err)
+			}
+			g.Log().Infof(ctx, "按 auth.yaml 新增接口: %s %s %s", method, path, admin.AuthTypeMap[item.authType])
+		}
+	}
+
+	return nil
+}
+
+// routeCode 生成权限码，格式为 分组:操作，如 SysUser 的 /sys/user/detail/:id 为 sys:user:detail
+// 分组来自 tags，已有同名权限码的接口作为上级；操作取路径中最后一个非参数段，重复时加上请求方法
+func (s *sSysApiLogic) routeCode(r *admin.SysApiRoute, taken map[string]bool) (group, code string) {
+
+	tag := r.Tag
+	if tag == "" {
+		tag = "default"
+	}
+	group = strings.ToLower(gstr.CaseDelimited(tag, ':'))
+
+	action := "index"
+	parts := strings.Split(strings.Trim(r.Url, "/"), "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if part := parts[i]; part != "" && !strings.ContainsAny(part, ":{}*") {
+			action = part
+			break
+		}
+	}
+
+	code = group + ":" + action
+	if taken[code] {
+		code += ":" + strings.ToLower(r.Method)
+	}
+	for i, base := 2, code; ; i++ {
+		if !taken[code] {
+			break
+		}
+		code = fmt.Sprintf("%s:%d", base, i)
+	}
+
+	return group, code
+}
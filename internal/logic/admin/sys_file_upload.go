@@ -139,11 +139,19 @@ func calculateFileMD5(file *ghttp.UploadFile) (string, error) {
 
 // GetFileById 根据ID获取文件信息
 func (s *sSysFileUploadLogic) GetFileById(ctx context.Context, id uint64) (*entity.SysFileUpload, error) {
+	if err := AuthLogic.checkDataScope(ctx, service.SysFileUploadService.CheckInScope, id); err != nil {
+		return nil, err
+	}
+
 	return service.SysFileUploadService.GetFileById(ctx, id)
 }
 
 // DeleteFile 删除文件
 func (s *sSysFileUploadLogic) DeleteFile(ctx context.Context, id uint64) error {
+	if err := AuthLogic.checkDataScope(ctx, service.SysFileUploadService.CheckInScope, id); err != nil {
+		return err
+	}
+
 	// 获取文件信息以进行删除操作前的验证
 	file, err := service.SysFileUploadService.GetFileById(ctx, id)
 	if err != nil {
@@ -160,5 +168,11 @@ func (s *sSysFileUploadLogic) DeleteFile(ctx context.Context, id uint64) error {
 
 // GetUploadList 获取文件列表
 func (s *sSysFileUploadLogic) GetUploadList(ctx context.Context, req *v1.UploadListReq) ([]*entity.SysFileUpload, int, error) {
-	return service.SysFileUploadService.GetFileList(ctx, req.BizType, req.FileName, req.Page, req.PageSize)
+	// 只查询数据权限范围内的文件
+	scope, err := AuthLogic.DataScope(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return service.SysFileUploadService.GetFileList(ctx, req.BizType, req.FileName, req.Page, req.PageSize, scope)
 }
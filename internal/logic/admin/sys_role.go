@@ -2,6 +2,9 @@ package admin
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 
 	"gf-ant-react/internal/model/admin"
 	"gf-ant-react/internal/model/entity"
@@ -13,14 +16,179 @@ type sSysRoleLogic struct{}
 var SysRoleLogic = &sSysRoleLogic{}
 
 func (s *sSysRoleLogic) Create(ctx context.Context, data *admin.SysRoleCreateParam) (uint64, error) {
+	if err := s.checkParent(ctx, 0, data.ParentId); err != nil {
+		return 0, err
+	}
+
+	departmentIds, err := s.checkDepartments(ctx, data.DataScope, data.DepartmentIds)
+	if err != nil {
+		return 0, err
+	}
+	data.DepartmentIds = departmentIds
+
 	return service.SysRoleService.Create(ctx, data)
 }
 
 func (s *sSysRoleLogic) Update(ctx context.Context, data *admin.SysRoleUpdateParam) error {
+	role, _, err := service.SysRoleService.GetById(ctx, data.Id)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return errors.New("角色不存在")
+	}
+
+	// 超级管理员角色不能降级
+	if role.IsSuper {
+		if !data.Status {
+			return errors.New("超级管理员角色不能禁用")
+		}
+		if data.DataScope != admin.DataScopeAll {
+			return errors.New("超级管理员角色的数据权限范围只能是全部")
+		}
+	}
+
+	if err = s.checkParent(ctx, data.Id, data.ParentId); err != nil {
+		return err
+	}
+
+	departmentIds, err := s.checkDepartments(ctx, data.DataScope, data.DepartmentIds)
+	if err != nil {
+		return err
+	}
+	data.DepartmentIds = departmentIds
+
 	return service.SysRoleService.Update(ctx, data)
 }
 
+// checkParent 检查上级角色存在，并且不能是自己或自己的下级角色，id 为 0 表示新角色
+func (s *sSysRoleLogic) checkParent(ctx context.Context, id, parentId uint64) error {
+	if parentId == 0 {
+		return nil
+	}
+	if parentId == id {
+		return errors.New("上级角色不能是自己")
+	}
+
+	roles, err := service.SysRoleService.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	parents := make(map[uint64]uint64, len(roles))
+	for _, role := range roles {
+		parents[role.Id] = role.ParentId
+	}
+	if _, ok := parents[parentId]; !ok {
+		return errors.New("上级角色不存在")
+	}
+
+	// 沿上级角色链向上查找，遇到自己说明形成环
+	seen := make(map[uint64]bool)
+	for current := parentId; current != 0 && !seen[current]; current = parents[current] {
+		if current == id {
+			return errors.New("上级角色不能是自己的下级角色")
+		}
+		seen[current] = true
+	}
+
+	return nil
+}
+
+// GetInheritedApis 获取角色从上级角色继承的接口权限，从直接上级开始，禁用的上级角色及其上级不再继承
+func (s *sSysRoleLogic) GetInheritedApis(ctx context.Context, role *entity.SysRoles) ([]*admin.SysRoleInheritedApis, error) {
+
+	ancestors, err := service.SysRoleService.GetAncestors(ctx, role.ParentId)
+	if err != nil {
+		return nil, err
+	}
+
+	roleIds := make([]uint64, 0, len(ancestors))
+	for _, ancestor := range ancestors {
+		roleIds = append(roleIds, ancestor.Id)
+	}
+	apiIds, err := service.SysRoleService.GetApiIdsByRoleIds(ctx, roleIds)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*admin.SysRoleInheritedApis, 0, len(ancestors))
+	for _, ancestor := range ancestors {
+		result = append(result, &admin.SysRoleInheritedApis{
+			RoleId:   ancestor.Id,
+			RoleName: ancestor.Name,
+			ApiIds:   apiIds[ancestor.Id],
+		})
+	}
+
+	return result, nil
+}
+
+// IsSuperUser 用户是否拥有启用的超级管理员角色
+func (s *sSysRoleLogic) IsSuperUser(ctx context.Context, userId uint64) (bool, error) {
+	roles, err := service.SysRoleService.GetUserRoles(ctx, userId)
+	if err != nil {
+		return false, err
+	}
+	for _, role := range roles {
+		if role.IsSuper {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkDepartments 检查自定义数据权限范围的部门，返回去重后的部门ID，其他范围不保存部门
+func (s *sSysRoleLogic) checkDepartments(ctx context.Context, dataScope int, departmentIds []uint64) ([]uint64, error) {
+	if dataScope != admin.DataScopeCustom {
+		return nil, nil
+	}
+	if len(departmentIds) == 0 {
+		return nil, errors.New("自定义数据权限范围需要选择部门")
+	}
+
+	departments, err := service.SysDepartmentService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exists := make(map[uint64]bool, len(departments))
+	for _, department := range departments {
+		exists[department.Id] = true
+	}
+
+	var result []uint64
+	for _, id := range departmentIds {
+		if !exists[id] {
+			return nil, fmt.Errorf("部门 %d 不存在", id)
+		}
+		if !slices.Contains(result, id) {
+			result = append(result, id)
+		}
+	}
+
+	return result, nil
+}
+
 func (s *sSysRoleLogic) Delete(ctx context.Context, id uint64) error {
+	role, _, err := service.SysRoleService.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return errors.New("角色不存在")
+	}
+	if role.IsSuper {
+		return errors.New("超级管理员角色不能删除")
+	}
+
+	// 下级角色继承该角色的权限
+	hasChildren, err := service.SysRoleService.HasChildren(ctx, id)
+	if err != nil {
+		return err
+	}
+	if hasChildren {
+		return errors.New("请先删除下级角色或修改下级角色的上级角色")
+	}
+
 	return service.SysRoleService.Delete(ctx, id)
 }
 
@@ -29,5 +197,17 @@ func (s *sSysRoleLogic) GetList(ctx context.Context, page, size int, name string
 }
 
 func (s *sSysRoleLogic) GetById(ctx context.Context, id uint64) (*entity.SysRoles, []uint64, error) {
-	return service.SysRoleService.GetById(ctx, id)
+	role, apiIds, err := service.SysRoleService.GetById(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if role == nil {
+		return nil, nil, errors.New("角色不存在")
+	}
+	return role, apiIds, nil
+}
+
+// GetDepartmentIds 获取角色自定义数据权限范围的部门ID列表
+func (s *sSysRoleLogic) GetDepartmentIds(ctx context.Context, id uint64) ([]uint64, error) {
+	return service.SysRoleService.GetDepartmentIds(ctx, id)
 }
\ No newline at end of file
@@ -2,11 +2,18 @@ package admin
 
 import (
 	"context"
+	"errors"
+	"slices"
 
 	"gf-ant-react/internal/model/admin"
 	"gf-ant-react/internal/model/entity"
 	"gf-ant-react/internal/service"
+	"gf-ant-react/utility/auth"
+	"gf-ant-react/utility/ldap"
 	"gf-ant-react/utility/password"
+
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/util/guid"
 )
 
 type sSysUserLogic struct{}
@@ -14,25 +21,107 @@ type sSysUserLogic struct{}
 var SysUserLogic = &sSysUserLogic{}
 
 func (s *sSysUserLogic) Create(ctx context.Context, data *admin.SysUserCreateParam) (uint64, error) {
+	if err := s.checkDepartmentScope(ctx, 0, data.DepartmentId); err != nil {
+		return 0, err
+	}
+	if err := s.checkSuperRoles(ctx, 0, data.RoleIds, data.Status); err != nil {
+		return 0, err
+	}
+
+	// LDAP 用户不使用本地密码
+	if data.AuthSource == admin.AuthSourceLdap {
+		return s.createLdapUser(ctx, data)
+	}
+
+	// 校验密码策略
+	if err := AuthLogic.ValidatePassword(ctx, 0, data.Username, data.PasswordHash); err != nil {
+		return 0, err
+	}
+
 	// 密码加密
 	var err error
 	data.PasswordHash, err = password.HashPassword(data.PasswordHash)
 	if err != nil {
 		return 0, err
 	}
+	data.PasswordChangedAt = gtime.Now()
+
+	id, err := service.SysUserService.Create(ctx, data)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, AuthLogic.RecordPasswordHistory(ctx, id, data.PasswordHash)
+}
+
+// createLdapUser 创建 LDAP 用户，本地密码为随机值
+func (s *sSysUserLogic) createLdapUser(ctx context.Context, data *admin.SysUserCreateParam) (uint64, error) {
+	if !ldap.LdapUtility.Config.Enabled {
+		return 0, errors.New("未开启 LDAP 认证")
+	}
+
+	var err error
+	data.PasswordHash, err = password.HashPassword(guid.S())
+	if err != nil {
+		return 0, err
+	}
+	data.PasswordChangedAt = gtime.Now()
+
 	return service.SysUserService.Create(ctx, data)
 }
 
 func (s *sSysUserLogic) Update(ctx context.Context, data *admin.SysUserUpdateParam) error {
-	return service.SysUserService.Update(ctx, data)
+	if data.AuthSource == admin.AuthSourceLdap && !ldap.LdapUtility.Config.Enabled {
+		return errors.New("未开启 LDAP 认证")
+	}
+
+	if err := s.checkScope(ctx, data.Id); err != nil {
+		return err
+	}
+	if err := s.checkDepartmentScope(ctx, data.Id, data.DepartmentId); err != nil {
+		return err
+	}
+	if err := s.checkSuperRoles(ctx, data.Id, data.RoleIds, data.Status); err != nil {
+		return err
+	}
+
+	if err := service.SysUserService.Update(ctx, data); err != nil {
+		return err
+	}
+
+	// 禁用或锁定的用户强制下线
+	if data.Status != admin.UserStatusEnabled {
+		return AuthLogic.RevokeUserTokens(ctx, data.Id)
+	}
+
+	return nil
 }
 
 func (s *sSysUserLogic) Delete(ctx context.Context, id uint64) error {
-	return service.SysUserService.Delete(ctx, id)
+	if err := s.checkScope(ctx, id); err != nil {
+		return err
+	}
+	if err := s.checkSuperRoles(ctx, id, nil, admin.UserStatusDisabled); err != nil {
+		return err
+	}
+
+	if err := service.SysUserService.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	// 删除的用户强制下线
+	return AuthLogic.RevokeUserTokens(ctx, id)
 }
 
 // GetListWithParam 使用参数结构体获取用户列表
 func (s *sSysUserLogic) GetList(ctx context.Context, param *admin.SysUserListParam) (*admin.SysUserListResult, error) {
+	// 只查询数据权限范围内的用户
+	scope, err := AuthLogic.DataScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	param.Scope = scope
+
 	users, total, err := service.SysUserService.GetList(ctx, param)
 	if err != nil {
 		return nil, err
@@ -59,16 +148,267 @@ func (s *sSysUserLogic) GetList(ctx context.Context, param *admin.SysUserListPar
 }
 
 func (s *sSysUserLogic) GetById(ctx context.Context, id uint64) (*entity.SysUsers, []uint64, error) {
+	if err := s.checkScope(ctx, id); err != nil {
+		return nil, nil, err
+	}
+
 	return service.SysUserService.GetById(ctx, id)
 }
 
 // UpdatePassword 修改密码
 func (s *sSysUserLogic) UpdatePassword(ctx context.Context, param *admin.SysUserUpdatePasswordParam) error {
-	// 密码加密
-	var err error
-	param.PasswordHash, err = password.HashPassword(param.PasswordHash)
+	if err := s.checkScope(ctx, param.Id); err != nil {
+		return err
+	}
+	if err := s.checkSuperTarget(ctx, param.Id); err != nil {
+		return err
+	}
+
+	user, _, err := service.SysUserService.GetById(ctx, param.Id)
 	if err != nil {
 		return err
 	}
-	return service.SysUserService.UpdateColumns(ctx, param.Id, param)
+	if user == nil {
+		return errors.New("用户不存在")
+	}
+	if user.AuthSource == admin.AuthSourceLdap {
+		return errors.New("LDAP 用户的密码请在目录服务中修改")
+	}
+
+	// 校验密码策略后修改密码，旧的登录全部失效
+	return AuthLogic.ChangePassword(ctx, user.Id, user.Username, param.PasswordHash)
+}
+
+// RevokeTokens 强制下线，吊销用户的所有令牌
+func (s *sSysUserLogic) RevokeTokens(ctx context.Context, id uint64) error {
+	if err := s.checkScope(ctx, id); err != nil {
+		return err
+	}
+	if err := s.checkSuperTarget(ctx, id); err != nil {
+		return err
+	}
+
+	return AuthLogic.RevokeUserTokens(ctx, id)
+}
+
+// SyncLdap 立即同步 LDAP 账号状态
+func (s *sSysUserLogic) SyncLdap(ctx context.Context) (*admin.LdapSyncRes, error) {
+	return AuthLogic.SyncLdapUsers(ctx)
+}
+
+// Unlock 解除锁定，同时清除登录失败次数
+func (s *sSysUserLogic) Unlock(ctx context.Context, id uint64) error {
+	if err := s.checkScope(ctx, id); err != nil {
+		return err
+	}
+	if err := s.checkSuperTarget(ctx, id); err != nil {
+		return err
+	}
+
+	return service.SysUserService.Unlock(ctx, id)
+}
+
+// DiagnosePermission 诊断用户能否访问接口
+func (s *sSysUserLogic) DiagnosePermission(ctx context.Context, req *admin.DiagnosePermissionReq) (*admin.DiagnosePermissionRes, error) {
+	if err := s.checkScope(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return AuthLogic.DiagnosePermission(ctx, req)
+}
+
+// ResetMfa 重置两步验证，用户需要重新绑定
+func (s *sSysUserLogic) ResetMfa(ctx context.Context, id uint64) error {
+	if err := s.checkScope(ctx, id); err != nil {
+		return err
+	}
+	if err := s.checkSuperTarget(ctx, id); err != nil {
+		return err
+	}
+
+	return AuthLogic.ResetMfa(ctx, id)
+}
+
+// Sessions 管理端会话列表，只返回数据权限范围内用户的会话
+func (s *sSysUserLogic) Sessions(ctx context.Context, param *admin.SysUserSessionListParam, currentSessionId string) (*admin.SysUserSessionListResult, error) {
+	scope, err := AuthLogic.DataScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	param.Scope = scope
+
+	return AuthLogic.Sessions(ctx, param, currentSessionId)
+}
+
+// TerminateSession 管理端结束会话，会话所属用户需要在数据权限范围内
+func (s *sSysUserLogic) TerminateSession(ctx context.Context, sessionId string) error {
+	session, err := service.SysUserSessionService.GetBySessionId(ctx, sessionId)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return errors.New("会话不存在")
+	}
+	if err = s.checkScope(ctx, session.UserId); err != nil {
+		return err
+	}
+	if err = s.checkSuperTarget(ctx, session.UserId); err != nil {
+		return err
+	}
+
+	return AuthLogic.TerminateSession(ctx, sessionId)
+}
+
+// LoginLogs 管理端登录日志，只返回数据权限范围内用户的日志
+func (s *sSysUserLogic) LoginLogs(ctx context.Context, param *admin.SysLoginLogListParam) (*admin.SysLoginLogListResult, error) {
+	scope, err := AuthLogic.DataScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	param.Scope = scope
+
+	return AuthLogic.LoginLogs(ctx, param)
+}
+
+// ApiKeys 管理端 API Key 列表，只返回数据权限范围内用户的 API Key
+func (s *sSysUserLogic) ApiKeys(ctx context.Context, param *admin.SysApiKeyListParam) (*admin.SysApiKeyListResult, error) {
+	scope, err := AuthLogic.DataScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	param.Scope = scope
+
+	return AuthLogic.ApiKeys(ctx, param)
+}
+
+// RevokeApiKey 管理端吊销 API Key，所属用户需要在数据权限范围内
+func (s *sSysUserLogic) RevokeApiKey(ctx context.Context, id uint64) error {
+	key, err := service.SysApiKeyService.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return errors.New("API Key 不存在")
+	}
+	if err = s.checkScope(ctx, key.UserId); err != nil {
+		return err
+	}
+	if err = s.checkSuperTarget(ctx, key.UserId); err != nil {
+		return err
+	}
+
+	return AuthLogic.RevokeApiKey(ctx, 0, id)
+}
+
+// checkScope 检查用户是否在当前用户的数据权限范围内
+func (s *sSysUserLogic) checkScope(ctx context.Context, id uint64) error {
+	return AuthLogic.checkDataScope(ctx, service.SysUserService.CheckInScope, id)
+}
+
+// checkDepartmentScope 检查用户所属部门是否在当前用户的数据权限范围内，防止把用户创建或移动到范围外
+// id 为 0 表示新用户，修改用户时部门没有变化不检查
+func (s *sSysUserLogic) checkDepartmentScope(ctx context.Context, id uint64, departmentId uint64) error {
+
+	scope, err := AuthLogic.DataScope(ctx)
+	if err != nil {
+		return err
+	}
+	if scope.All || slices.Contains(scope.DepartmentIds, departmentId) {
+		return nil
+	}
+
+	if id != 0 {
+		user, _, err := service.SysUserService.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if user != nil && user.DepartmentId == departmentId {
+			return nil
+		}
+	}
+
+	return errors.New("部门不在数据权限范围内")
+}
+
+// checkSuperTarget 只有超级管理员可以修改超级管理员的密码、重置两步验证、解锁和强制下线
+func (s *sSysUserLogic) checkSuperTarget(ctx context.Context, id uint64) error {
+
+	superIds, err := service.SysRoleService.GetSuperIds(ctx)
+	if err != nil {
+		return err
+	}
+	if len(superIds) == 0 {
+		return nil
+	}
+
+	_, roleIds, err := service.SysUserService.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(roleIds, func(roleId uint64) bool { return slices.Contains(superIds, roleId) }) {
+		return nil
+	}
+
+	isSuper, err := SysRoleLogic.IsSuperUser(ctx, auth.GetUserId(ctx))
+	if err != nil {
+		return err
+	}
+	if !isSuper {
+		return errors.New("只有超级管理员可以管理超级管理员")
+	}
+
+	return nil
+}
+
+// checkSuperRoles 检查超级管理员角色的变化，id 为 0 表示新用户
+// 只有超级管理员可以分配超级管理员角色；至少保留一个拥有超级管理员角色的正常用户
+func (s *sSysUserLogic) checkSuperRoles(ctx context.Context, id uint64, roleIds []uint64, status int) error {
+
+	superIds, err := service.SysRoleService.GetSuperIds(ctx)
+	if err != nil {
+		return err
+	}
+	if len(superIds) == 0 {
+		return nil
+	}
+
+	var currentIds []uint64
+	if id > 0 {
+		if _, currentIds, err = service.SysUserService.GetById(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	hasSuper := func(ids []uint64) bool {
+		for _, roleId := range ids {
+			if slices.Contains(superIds, roleId) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// 分配超级管理员角色
+	if hasSuper(roleIds) && !hasSuper(currentIds) {
+		isSuper, err := SysRoleLogic.IsSuperUser(ctx, auth.GetUserId(ctx))
+		if err != nil {
+			return err
+		}
+		if !isSuper {
+			return errors.New("只有超级管理员可以分配超级管理员角色")
+		}
+	}
+
+	// 移除超级管理员角色、禁用或删除超级管理员
+	if hasSuper(currentIds) && (!hasSuper(roleIds) || status != admin.UserStatusEnabled) {
+		count, err := service.SysUserService.CountSuperUsers(ctx, id)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.New("至少需要保留一个正常的超级管理员")
+		}
+	}
+
+	return nil
 }
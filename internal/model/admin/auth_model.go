@@ -2,24 +2,33 @@ package admin
 
 import (
 	"gf-ant-react/internal/model/entity"
+	"gf-ant-react/utility/jwt"
 	"time"
 )
 
 type CaptchaReq struct {
-	Width  int `json:"width"`
-	Height int `json:"height"`
-	Length int `json:"length"`
+	Type   string `json:"type"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Length int    `json:"length"`
 }
 
 type CaptchaRes struct {
 	Id     string `json:"id"`
+	Type   string `json:"type"`
 	Base64 string `json:"base64"`
 }
 
+// 网站设置中验证码类型的键名
+const SettingKeyCaptchaType = "captcha_type"
+
 type LoginReq struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
-	Ip       string `json:"ip"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	CaptchaId   string `json:"captchaId"`
+	CaptchaCode string `json:"captchaCode"`
+	Ip          string `json:"ip"`
+	UserAgent   string `json:"userAgent"`
 }
 
 type LoginRes struct {
@@ -28,22 +37,152 @@ type LoginRes struct {
 	RoleIds  []uint64           `json:"roleIds"`
 	Apis     []*entity.SysApis  `json:"apis"`
 	ApiCodes []string           `json:"apiCodes"`
-	Token    string             `json:"token"`
-	Expire   time.Time          `json:"expire"`
-	Refresh  time.Time          `json:"refresh"`
+	*TokenRes
+
+	// 密码已过期，需要修改密码
+	MustChangePassword bool `json:"mustChangePassword"`
+
+	// 开启两步验证时，密码校验通过后只返回以下字段，不签发令牌
+	MfaRequired bool         `json:"mfaRequired"`
+	MfaToken    string       `json:"mfaToken,omitempty"`
+	MfaSetup    *MfaSetupRes `json:"mfaSetup,omitempty"` // 角色要求两步验证但用户尚未绑定时返回
+
+	// 登录时首次绑定两步验证，返回恢复码，只显示一次
+	RecoveryCodes []string `json:"recoveryCodes,omitempty"`
+}
+
+// 两步验证登录
+type LoginMfaReq struct {
+	MfaToken     string `json:"mfaToken"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recoveryCode"`
+	Ip           string `json:"ip"`
+	UserAgent    string `json:"userAgent"`
+}
+
+// 单点登录回调
+type LoginOidcReq struct {
+	Code      string `json:"code"`
+	State     string `json:"state"`
+	Ip        string `json:"ip"`
+	UserAgent string `json:"userAgent"`
+}
+
+// 单点登录配置，登录页据此显示单点登录按钮
+type OidcConfigRes struct {
+	Enabled bool   `json:"enabled"`
+	Name    string `json:"name"`
+}
+
+// 两步验证绑定信息
+type MfaSetupRes struct {
+	Secret string `json:"secret"`
+	Uri    string `json:"uri"`
+}
+
+// 两步验证码校验
+type MfaCodeReq struct {
+	UserId uint64 `json:"userId"`
+	Code   string `json:"code"`
+}
+
+// 恢复码，只显示一次
+type MfaRecoveryCodesRes struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// 令牌信息
+type TokenRes struct {
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refreshToken"`
+	Expire       time.Time `json:"expire"`
+	Refresh      time.Time `json:"refresh"`
+}
+
+// 刷新令牌
+type RefreshReq struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// 退出登录
+type LogoutReq struct {
+	TokenId   string    `json:"tokenId"`
+	SessionId string    `json:"sessionId"`
+	ExpiresAt time.Time `json:"expiresAt"`
 }
 
 // 重置密码
 type ResetPasswordReq struct {
-	Id           uint64 `json:"id"`
-	PasswordHash string `json:"passwordHash"`
+	Id          uint64 `json:"id"`
+	OldPassword string `json:"oldPassword"`
+	Password    string `json:"password"`
+}
+
+// 找回密码
+type ForgotPasswordReq struct {
+	Account string `json:"account"` // 用户名或邮箱
+	Ip      string `json:"ip"`
+}
+
+// 通过重置链接设置新密码
+type ConfirmPasswordResetReq struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+	Ip       string `json:"ip"`
 }
 
 // 验证用户是否有权限访问接口
 type CheckPermissionReq struct {
-	UserId uint64 `json:"userId"`
-	Url    string `json:"url"`
-	Method string `json:"method"`
+	UserId uint64   `json:"userId"`
+	Url    string   `json:"url"`
+	Method string   `json:"method"`
+	Scopes []string `json:"scopes"` // 使用 API Key 访问时，限制可访问的权限码，为空时不限制
+}
+
+// 路由的鉴权方式
+const (
+	RouteTypeIgnore     = "ignore"     // 不需要鉴权
+	RouteTypePublic     = "public"     // 只需要登录不需要权限
+	RouteTypePermission = "permission" // 需要接口权限
+)
+
+// 权限诊断，给出请求或权限码之一
+type DiagnosePermissionReq struct {
+	UserId         uint64 `json:"userId"`
+	Method         string `json:"method"`
+	Url            string `json:"url"`
+	PermissionCode string `json:"permissionCode"`
+}
+
+// 权限诊断结果
+type DiagnosePermissionRes struct {
+	Method         string                    `json:"method"`
+	Url            string                    `json:"url"`
+	RouteType      string                    `json:"routeType"`      // 路由的鉴权方式
+	Api            *entity.SysApis           `json:"api"`            // 匹配的接口，没有匹配时为空
+	PermissionCode string                    `json:"permissionCode"` // 需要的权限码
+	User           *DiagnosePermissionUser   `json:"user"`
+	Roles          []*DiagnosePermissionRole `json:"roles"`
+	Allowed        bool                      `json:"allowed"` // 最终结果
+	Reason         string                    `json:"reason"`  // 结果说明
+}
+
+// 权限诊断中的用户
+type DiagnosePermissionUser struct {
+	Id       uint64 `json:"id"`
+	Username string `json:"username"`
+	Status   int    `json:"status"`
+	Error    string `json:"error"` // 账号禁用或锁定时的提示，正常时为空
+}
+
+// 权限诊断中的角色
+type DiagnosePermissionRole struct {
+	Id        uint64   `json:"id"`
+	Name      string   `json:"name"`
+	Status    bool     `json:"status"`
+	IsSuper   bool     `json:"isSuper"`
+	Granted   bool     `json:"granted"`   // 该角色是否授予权限码
+	GrantedBy []string `json:"grantedBy"` // 直接分配了该接口的角色，包括角色自己和继承的上级角色
 }
 
 // 个人中心
@@ -54,4 +193,39 @@ type ProfileReq struct {
 // 个人中心返回
 type ProfileRes struct {
 	User *entity.SysUsers `json:"user"`
+
+	// 最近的登录记录，包括失败的尝试
+	RecentLogins []*entity.SysLoginLogs `json:"recentLogins"`
+
+	// 代登录时为实际操作的用户，前端据此提示并提供结束代登录
+	Impersonator *Impersonator `json:"impersonator,omitempty"`
+}
+
+// 代登录的操作人
+type Impersonator struct {
+	UserId   uint64 `json:"userId"`
+	Username string `json:"username"`
+}
+
+// 代登录
+type ImpersonateReq struct {
+	UserId    uint64      `json:"userId"` // 被代登录的用户
+	Operator  *jwt.Claims `json:"-"`      // 操作人当前的令牌声明
+	Reason    string      `json:"reason"` // 代登录原因，记入登录日志
+	Ip        string      `json:"ip"`
+	UserAgent string      `json:"userAgent"`
+}
+
+// 当前用户的菜单，由接口树中是否为菜单的节点组成
+type MenuItem struct {
+	Id             uint64      `json:"id"`
+	ParentId       uint64      `json:"parentId"`
+	Name           string      `json:"name"`
+	PermissionCode string      `json:"permissionCode"`
+	Icon           string      `json:"icon"`
+	Route          string      `json:"route"`
+	Component      string      `json:"component"`
+	Sort           int         `json:"sort"`
+	Permissions    []string    `json:"permissions"` // 菜单下用户拥有的按钮（非菜单接口）权限标识
+	Children       []*MenuItem `json:"children,omitempty"`
 }
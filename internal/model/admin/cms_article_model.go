@@ -17,13 +17,14 @@ import (
 // 统一了参数验证和转换逻辑
 // 提高了代码的可维护性和可读性
 type ArticleSearchParams struct {
-	Title       string `json:"title" description:"文章标题（模糊搜索）"`
-	CategoryId  uint64 `json:"categoryId" description:"栏目ID"`
-	Status      *int   `json:"status" description:"状态: 0-草稿, 1-已发布"`
-	ArticleType string `json:"articleType" description:"文章类型: normal-普通, external-外链"`
-	IsTop       *int   `json:"isTop" description:"是否置顶: 0-不置顶, 1-置顶"`
-	IsHot       *int   `json:"isHot" description:"是否热门: 0-普通, 1-热门"`
-	IsRecommend *int   `json:"isRecommend" description:"是否推荐: 0-不推荐, 1-推荐"`
+	Title       string     `json:"title" description:"文章标题（模糊搜索）"`
+	CategoryId  uint64     `json:"categoryId" description:"栏目ID"`
+	Status      *int       `json:"status" description:"状态: 0-草稿, 1-已发布"`
+	ArticleType string     `json:"articleType" description:"文章类型: normal-普通, external-外链"`
+	IsTop       *int       `json:"isTop" description:"是否置顶: 0-不置顶, 1-置顶"`
+	IsHot       *int       `json:"isHot" description:"是否热门: 0-普通, 1-热门"`
+	IsRecommend *int       `json:"isRecommend" description:"是否推荐: 0-不推荐, 1-推荐"`
+	Scope       *DataScope `json:"-" description:"数据权限范围，为空时不限制"`
 }
 
 // ArticleCreateParams 文章创建参数结构体
@@ -42,6 +43,7 @@ type ArticleCreateParams struct {
 	ArticleType    string      `json:"articleType" description:"文章类型: normal-普通文章, external-外链文章"`
 	ExternalUrl    string      `json:"externalUrl" description:"外链地址，仅当文章类型为 external 时使用"`
 	CategoryId     uint64      `json:"categoryId" description:"所属栏目ID"`
+	AuthorId       string      `json:"authorId" description:"作者ID，创建文章的用户ID"`
 	AuthorName     string      `json:"authorName" description:"作者显示名称"`
 	CoverImage     string      `json:"coverImage" description:"文章封面图片URL"`
 	Status         bool        `json:"status" description:"发布状态: 1-已发布, 0-草稿/未发布"`
@@ -22,6 +22,7 @@ const (
 	MethodPATCH   = "PATCH"   // PATCH
 	MethodOPTIONS = "OPTIONS" // OPTIONS
 	MethodHEAD    = "HEAD"    // HEAD
+	MethodANY     = "ANY"     // 所有方法
 )
 
 var (
@@ -33,6 +34,7 @@ var (
 		MethodPATCH:   "PATCH",
 		MethodOPTIONS: "OPTIONS",
 		MethodHEAD:    "HEAD",
+		MethodANY:     "ANY",
 	}
 )
 
@@ -48,3 +50,19 @@ var (
 		IsMenuYes: "是",
 	}
 )
+
+// AuthType 鉴权方式：0=需要权限，1=只需要登录，2=不需要鉴权
+// 运行时以接口表为准，auth.yaml 中的 publicRoutes 和 ignoreRoutes 只用于初始化
+const (
+	AuthTypePermission = 0 // 需要权限
+	AuthTypePublic     = 1 // 只需要登录
+	AuthTypeIgnore     = 2 // 不需要鉴权
+)
+
+var (
+	AuthTypeMap = map[int]string{
+		AuthTypePermission: "需要权限",
+		AuthTypePublic:     "只需要登录",
+		AuthTypeIgnore:     "不需要鉴权",
+	}
+)
@@ -0,0 +1,43 @@
+package admin
+
+import (
+	"gf-ant-react/internal/model/entity"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// SysApiKeyCreateParam 创建 API Key 参数
+type SysApiKeyCreateParam struct {
+	UserId    uint64      `json:"userId"`
+	Name      string      `json:"name"`
+	Scopes    []string    `json:"scopes"`
+	ExpiresAt *gtime.Time `json:"expiresAt"`
+}
+
+// SysApiKeyCreateResult 创建 API Key 结果，Key 只返回这一次
+type SysApiKeyCreateResult struct {
+	Id     uint64 `json:"id"`
+	Key    string `json:"key"`
+	Prefix string `json:"prefix"`
+}
+
+// SysApiKeyListParam API Key 列表查询参数
+type SysApiKeyListParam struct {
+	Page        int        `json:"page"`
+	Size        int        `json:"size"`
+	UserId      uint64     `json:"userId"`
+	WithRevoked bool       `json:"withRevoked"`
+	Scope       *DataScope `json:"-"` // 数据权限范围，为空时不限制
+}
+
+// SysApiKeyItem API Key 列表项
+type SysApiKeyItem struct {
+	*entity.SysApiKeys
+	Username string `json:"username" description:"用户名"`
+}
+
+// SysApiKeyListResult API Key 列表结果
+type SysApiKeyListResult struct {
+	List  []*SysApiKeyItem `json:"list"`
+	Total int              `json:"total"`
+}
@@ -14,6 +14,10 @@ type SysApiCreateParam struct {
 	Sort           int    `json:"sort"`
 	Status         int    `json:"status"`
 	IsMenu         int    `json:"isMenu"`
+	AuthType       int    `json:"authType"`
+	Icon           string `json:"icon"`
+	Route          string `json:"route"`
+	Component      string `json:"component"`
 	Description    string `json:"description"`
 }
 
@@ -28,6 +32,10 @@ type SysApiUpdateParam struct {
 	Sort           int    `json:"sort"`
 	Status         int    `json:"status"`
 	IsMenu         int    `json:"isMenu"`
+	AuthType       int    `json:"authType"`
+	Icon           string `json:"icon"`
+	Route          string `json:"route"`
+	Component      string `json:"component"`
 	Description    string `json:"description"`
 }
 
@@ -47,3 +55,25 @@ type SysApiTreeResultItem struct {
 	*entity.SysApis
 	Children []*SysApiTreeResultItem `json:"children,omitempty"`
 }
+
+// SysApiRoute 服务中注册的路由
+type SysApiRoute struct {
+	Method  string `json:"method"`
+	Url     string `json:"url"`
+	Tag     string `json:"tag"`     // g.Meta 中的 tags
+	Summary string `json:"summary"` // g.Meta 中的 summary
+}
+
+// SysApiSyncParam 同步路由参数
+type SysApiSyncParam struct {
+	DryRun         bool `json:"dryRun"`         // 只比较不修改
+	DisableOrphans bool `json:"disableOrphans"` // 禁用没有对应路由的接口
+}
+
+// SysApiSyncResult 同步路由结果
+type SysApiSyncResult struct {
+	Created  []*SysApiCreateParam `json:"created"`  // 新增的接口
+	Orphans  []*entity.SysApis    `json:"orphans"`  // 没有对应路由的接口，不包括菜单和有下级的接口
+	Existing int                  `json:"existing"` // 已有接口的路由数量
+	Skipped  int                  `json:"skipped"`  // 不需要权限的路由数量
+}
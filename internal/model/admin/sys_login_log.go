@@ -0,0 +1,82 @@
+package admin
+
+import (
+	"gf-ant-react/internal/model/entity"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// sys_login_log Status 登录结果: 0=失败, 1=成功, 2=待两步验证
+const (
+	LoginStatusFailed     = 0 // 失败
+	LoginStatusSuccess    = 1 // 成功
+	LoginStatusMfaPending = 2 // 密码正确，待两步验证
+)
+
+// sys_login_log Method 登录方式
+const (
+	LoginMethodPassword    = "password"    // 密码
+	LoginMethodMfa         = "mfa"         // 两步验证
+	LoginMethodOidc        = "oidc"        // 单点登录
+	LoginMethodImpersonate = "impersonate" // 代登录
+)
+
+// sys_login_log Reason 结果原因
+const (
+	LoginReasonSuccess      = "success"        // 登录成功
+	LoginReasonMfaRequired  = "mfa_required"   // 需要两步验证
+	LoginReasonCaptcha      = "captcha"        // 验证码错误
+	LoginReasonUserNotFound = "user_not_found" // 用户不存在
+	LoginReasonBadPassword  = "bad_password"   // 密码错误
+	LoginReasonLockout      = "lockout"        // 密码错误次数过多，本次失败后锁定
+	LoginReasonLocked       = "locked"         // 账号锁定中
+	LoginReasonDisabled     = "disabled"       // 账号已禁用
+	LoginReasonMfaFailed    = "mfa_failed"     // 两步验证码错误
+	LoginReasonError        = "error"          // 其他错误
+)
+
+var (
+	LoginReasonMap = map[string]string{
+		LoginReasonSuccess:      "登录成功",
+		LoginReasonMfaRequired:  "需要两步验证",
+		LoginReasonCaptcha:      "验证码错误",
+		LoginReasonUserNotFound: "用户不存在",
+		LoginReasonBadPassword:  "密码错误",
+		LoginReasonLockout:      "失败次数过多已锁定",
+		LoginReasonLocked:       "账号锁定中",
+		LoginReasonDisabled:     "账号已禁用",
+		LoginReasonMfaFailed:    "两步验证码错误",
+		LoginReasonError:        "其他错误",
+	}
+)
+
+// LoginAttempt 一次登录尝试，登录过程中补充用户和结果原因，结束后写入登录日志
+type LoginAttempt struct {
+	UserId    uint64
+	Username  string
+	Method    string
+	Reason    string // 为空时按返回的错误判断
+	Ip        string
+	UserAgent string
+}
+
+// SysLoginLogListParam 登录日志查询参数
+type SysLoginLogListParam struct {
+	Page      int         `json:"page"`
+	Size      int         `json:"size"`
+	UserId    uint64      `json:"userId"`
+	Username  string      `json:"username"`
+	Method    string      `json:"method"`
+	Status    *int        `json:"status"`
+	Reason    string      `json:"reason"`
+	Ip        string      `json:"ip"`
+	StartTime *gtime.Time `json:"startTime"`
+	EndTime   *gtime.Time `json:"endTime"`
+	Scope     *DataScope  `json:"-"` // 数据权限范围，为空时不限制
+}
+
+// SysLoginLogListResult 登录日志列表结果
+type SysLoginLogListResult struct {
+	List  []*entity.SysLoginLogs `json:"list"`
+	Total int                    `json:"total"`
+}
@@ -0,0 +1,29 @@
+package admin
+
+// sys_role DataScope 数据权限范围: 1=全部, 2=本部门, 3=本部门及子部门, 4=仅本人, 5=自定义
+const (
+	DataScopeAll          = 1 // 全部
+	DataScopeDept         = 2 // 本部门
+	DataScopeDeptAndChild = 3 // 本部门及子部门
+	DataScopeSelf         = 4 // 仅本人
+	DataScopeCustom       = 5 // 自定义
+)
+
+var (
+	DataScopeMap = map[int]string{
+		DataScopeAll:          "全部",
+		DataScopeDept:         "本部门",
+		DataScopeDeptAndChild: "本部门及子部门",
+		DataScopeSelf:         "仅本人",
+		DataScopeCustom:       "自定义",
+	}
+)
+
+// DataScope 当前用户可以访问的数据范围，多个角色取并集
+// All 为 true 时不限制；否则可以访问本人的数据和 DepartmentIds 中部门的数据，两者都为空时不能访问任何数据
+type DataScope struct {
+	All           bool     `json:"all"`
+	UserId        uint64   `json:"userId"`
+	Self          bool     `json:"self"`
+	DepartmentIds []uint64 `json:"departmentIds"`
+}
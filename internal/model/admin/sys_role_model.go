@@ -6,23 +6,29 @@ import (
 
 // SysRoleCreateParam 创建角色参数
 type SysRoleCreateParam struct {
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	DataScope   int      `json:"dataScope"`
-	Sort        int      `json:"sort"`
-	Status      bool     `json:"status"`
-	ApiIds      []uint64 `json:"apiIds"`
+	ParentId      uint64   `json:"parentId"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	DataScope     int      `json:"dataScope"`
+	Sort          int      `json:"sort"`
+	Status        bool     `json:"status"`
+	RequireMfa    bool     `json:"requireMfa"`
+	ApiIds        []uint64 `json:"apiIds"`
+	DepartmentIds []uint64 `json:"departmentIds"`
 }
 
 // SysRoleUpdateParam 更新角色参数
 type SysRoleUpdateParam struct {
-	Id          uint64   `json:"id"`
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	DataScope   int      `json:"dataScope"`
-	Sort        int      `json:"sort"`
-	Status      bool     `json:"status"`
-	ApiIds      []uint64 `json:"apiIds"`
+	Id            uint64   `json:"id"`
+	ParentId      uint64   `json:"parentId"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	DataScope     int      `json:"dataScope"`
+	Sort          int      `json:"sort"`
+	Status        bool     `json:"status"`
+	RequireMfa    bool     `json:"requireMfa"`
+	ApiIds        []uint64 `json:"apiIds"`
+	DepartmentIds []uint64 `json:"departmentIds"`
 }
 
 // SysRoleListParam 角色列表查询参数
@@ -45,15 +51,26 @@ type SysRoleListResult struct {
 	Total int            `json:"total"`
 }
 
+// SysRoleInheritedApis 从上级角色继承的接口权限
+type SysRoleInheritedApis struct {
+	RoleId   uint64   `json:"roleId"`
+	RoleName string   `json:"roleName"`
+	ApiIds   []uint64 `json:"apiIds"`
+}
+
 // SysRoleDetailResult 角色详情结果
 type SysRoleDetailResult struct {
-	Id          uint64   `json:"id"`
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	DataScope   int      `json:"dataScope"`
-	Sort        int      `json:"sort"`
-	Status      bool     `json:"status"`
-	ApiIds      []uint64 `json:"apiIds"`
-	CreatedAt   string   `json:"createdAt"`
-	UpdatedAt   string   `json:"updatedAt"`
+	Id            uint64                  `json:"id"`
+	ParentId      uint64                  `json:"parentId"`
+	Name          string                  `json:"name"`
+	Description   string                  `json:"description"`
+	DataScope     int                     `json:"dataScope"`
+	Sort          int                     `json:"sort"`
+	Status        bool                    `json:"status"`
+	RequireMfa    bool                    `json:"requireMfa"`
+	ApiIds        []uint64                `json:"apiIds"`
+	DepartmentIds []uint64                `json:"departmentIds"`
+	InheritedApis []*SysRoleInheritedApis `json:"inheritedApis"`
+	CreatedAt     string                  `json:"createdAt"`
+	UpdatedAt     string                  `json:"updatedAt"`
 }
\ No newline at end of file
@@ -14,3 +14,9 @@ var (
 		UserStatusLocked:   "锁定",
 	}
 )
+
+// sys_user AuthSource 认证方式: local=本地密码, ldap=LDAP
+const (
+	AuthSourceLocal = "local" // 本地密码
+	AuthSourceLdap  = "ldap"  // LDAP
+)
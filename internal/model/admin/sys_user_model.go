@@ -2,23 +2,28 @@ package admin
 
 import (
 	"gf-ant-react/internal/model/entity"
+
+	"github.com/gogf/gf/v2/os/gtime"
 )
 
 // SysUserCreateParam 创建用户参数
 type SysUserCreateParam struct {
-	Username     string   `json:"username"`
-	PasswordHash string   `json:"passwordHash"`
-	Email        string   `json:"email"`
-	Mobile       string   `json:"mobile"`
-	DepartmentId uint64   `json:"departmentId"`
-	Status       int      `json:"status"`
-	RoleIds      []uint64 `json:"roleIds"`
+	Username          string      `json:"username"`
+	PasswordHash      string      `json:"passwordHash"`
+	PasswordChangedAt *gtime.Time `json:"passwordChangedAt"`
+	AuthSource        string      `json:"authSource"`
+	Email             string      `json:"email"`
+	Mobile            string      `json:"mobile"`
+	DepartmentId      uint64      `json:"departmentId"`
+	Status            int         `json:"status"`
+	RoleIds           []uint64    `json:"roleIds"`
 }
 
 // SysUserUpdateParam 更新用户参数
 type SysUserUpdateParam struct {
 	Id           uint64   `json:"id"`
 	Username     string   `json:"username"`
+	AuthSource   string   `json:"authSource"`
 	Email        string   `json:"email"`
 	Mobile       string   `json:"mobile"`
 	DepartmentId uint64   `json:"departmentId"`
@@ -28,11 +33,12 @@ type SysUserUpdateParam struct {
 
 // SysUserListParam 用户列表查询参数
 type SysUserListParam struct {
-	Page         int    `json:"page"`
-	Size         int    `json:"size"`
-	Username     string `json:"username"`
-	DepartmentId uint64 `json:"departmentId"`
-	Status       *int   `json:"status"`
+	Page         int        `json:"page"`
+	Size         int        `json:"size"`
+	Username     string     `json:"username"`
+	DepartmentId uint64     `json:"departmentId"`
+	Status       *int       `json:"status"`
+	Scope        *DataScope `json:"-"` // 数据权限范围，为空时不限制
 }
 
 // SysUserListResult 用户列表结果
@@ -54,3 +60,9 @@ type SysUserUpdatePasswordParam struct {
 	Id           uint64 `json:"id"`
 	PasswordHash string `json:"passwordHash"`
 }
+
+// LdapSyncRes LDAP 账号状态同步结果
+type LdapSyncRes struct {
+	Total    int      `json:"total"`    // LDAP 用户数量
+	Disabled []string `json:"disabled"` // 本次禁用的用户名
+}
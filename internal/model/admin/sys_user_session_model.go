@@ -0,0 +1,27 @@
+package admin
+
+import (
+	"gf-ant-react/internal/model/entity"
+)
+
+// SysUserSessionListParam 会话列表查询参数
+type SysUserSessionListParam struct {
+	Page     int        `json:"page"`
+	Size     int        `json:"size"`
+	UserId   uint64     `json:"userId"`
+	Username string     `json:"username"`
+	Scope    *DataScope `json:"-"` // 数据权限范围，为空时不限制
+}
+
+// SysUserSessionItem 会话列表项
+type SysUserSessionItem struct {
+	*entity.SysUserSessions
+	Username string `json:"username" description:"用户名"`
+	Current  bool   `json:"current" description:"是否为当前会话"`
+}
+
+// SysUserSessionListResult 会话列表结果
+type SysUserSessionListResult struct {
+	List  []*SysUserSessionItem `json:"list"`
+	Total int                   `json:"total"`
+}
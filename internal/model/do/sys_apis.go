@@ -21,6 +21,10 @@ type SysApis struct {
 	Sort           any         // 排序
 	Status         any         // 状态：0=禁用，1=启用
 	IsMenu         any         // 是否为菜单：0=否，1=是
+	AuthType       any         // 鉴权方式：0=需要权限，1=只需要登录，2=不需要鉴权，为空时按 auth.yaml 初始化
+	Icon           any         // 菜单图标
+	Route          any         // 菜单前端路由
+	Component      any         // 菜单前端组件
 	Description    any         // 描述
 	CreatedAt      *gtime.Time //
 	UpdatedAt      *gtime.Time //
@@ -13,11 +13,14 @@ import (
 type SysRoles struct {
 	g.Meta      `orm:"table:sys_roles, do:true"`
 	Id          any         // 角色ID
+	ParentId    any         // 上级角色ID，0表示没有上级，继承上级角色的接口权限
 	Name        any         // 角色名称 (兼具标识作用)
 	Description any         // 描述
 	DataScope   any         // 数据权限范围: 1=全部, 2=本部门, 3=本部门及子部门, 4=仅本人, 5=自定义
 	Sort        any         // 排序
 	Status      any         // 状态: 0=禁用, 1=启用
+	RequireMfa  any         // 是否要求两步验证: 0=否, 1=是
+	IsSuper     any         // 超级管理员: 0=否, 1=是，拥有所有接口权限，不能删除或禁用
 	CreatedAt   *gtime.Time //
 	UpdatedAt   *gtime.Time //
 	DeletedAt   *gtime.Time //
@@ -11,11 +11,14 @@ import (
 // SysRoles is the golang structure for table sys_roles.
 type SysRoles struct {
 	Id          uint64      `json:"id"          orm:"id"          description:"角色ID"`                                         // 角色ID
+	ParentId    uint64      `json:"parentId"    orm:"parent_id"   description:"上级角色ID，0表示没有上级，继承上级角色的接口权限"`                   // 上级角色ID，0表示没有上级，继承上级角色的接口权限
 	Name        string      `json:"name"        orm:"name"        description:"角色名称 (兼具标识作用)"`                                // 角色名称 (兼具标识作用)
 	Description string      `json:"description" orm:"description" description:"描述"`                                           // 描述
 	DataScope   int         `json:"dataScope"   orm:"data_scope"  description:"数据权限范围: 1=全部, 2=本部门, 3=本部门及子部门, 4=仅本人, 5=自定义"` // 数据权限范围: 1=全部, 2=本部门, 3=本部门及子部门, 4=仅本人, 5=自定义
 	Sort        int         `json:"sort"        orm:"sort"        description:"排序"`                                           // 排序
 	Status      bool        `json:"status"      orm:"status"      description:"状态: 0=禁用, 1=启用"`                               // 状态: 0=禁用, 1=启用
+	RequireMfa  bool        `json:"requireMfa"  orm:"require_mfa" description:"是否要求两步验证: 0=否, 1=是"`                           // 是否要求两步验证: 0=否, 1=是
+	IsSuper     bool        `json:"isSuper"     orm:"is_super"    description:"超级管理员: 0=否, 1=是，拥有所有接口权限，不能删除或禁用"`             // 超级管理员: 0=否, 1=是，拥有所有接口权限，不能删除或禁用
 	CreatedAt   *gtime.Time `json:"createdAt"   orm:"created_at"  description:""`                                             //
 	UpdatedAt   *gtime.Time `json:"updatedAt"   orm:"updated_at"  description:""`                                             //
 	DeletedAt   *gtime.Time `json:"deletedAt"   orm:"deleted_at"  description:""`                                             //
@@ -10,18 +10,22 @@ import (
 
 // SysUsers is the golang structure for table sys_users.
 type SysUsers struct {
-	Id            uint64      `json:"id"            orm:"id"             description:""`                     //
-	Username      string      `json:"username"      orm:"username"       description:"用户名"`                  // 用户名
-	PasswordHash  string      `json:"passwordHash"  orm:"password_hash"  description:"密码哈希"`                 // 密码哈希
-	Email         string      `json:"email"         orm:"email"          description:"邮箱"`                   // 邮箱
-	Mobile        string      `json:"mobile"        orm:"mobile"         description:"手机号"`                  // 手机号
-	DepartmentId  uint64      `json:"departmentId"  orm:"department_id"  description:"所属部门ID"`               // 所属部门ID
-	Status        int         `json:"status"        orm:"status"         description:"状态: 0=禁用, 1=正常, 2=锁定"` // 状态: 0=禁用, 1=正常, 2=锁定
-	LastLoginAt   *gtime.Time `json:"lastLoginAt"   orm:"last_login_at"  description:"最后登录时间"`               // 最后登录时间
-	LastLoginIp   string      `json:"lastLoginIp"   orm:"last_login_ip"  description:"最后登录IP"`               // 最后登录IP
-	LoginAttempts uint        `json:"loginAttempts" orm:"login_attempts" description:"登录失败次数"`               // 登录失败次数
-	LockedUntil   *gtime.Time `json:"lockedUntil"   orm:"locked_until"   description:"锁定到期时间"`               // 锁定到期时间
-	CreatedAt     *gtime.Time `json:"createdAt"     orm:"created_at"     description:""`                     //
-	UpdatedAt     *gtime.Time `json:"updatedAt"     orm:"updated_at"     description:""`                     //
-	DeletedAt     *gtime.Time `json:"deletedAt"     orm:"deleted_at"     description:"软删除时间 (NULL=未删除)"`     // 软删除时间 (NULL=未删除)
+	Id                uint64      `json:"id"                orm:"id"                  description:""`                            //
+	Username          string      `json:"username"          orm:"username"            description:"用户名"`                         // 用户名
+	PasswordHash      string      `json:"passwordHash"      orm:"password_hash"       description:"密码哈希"`                        // 密码哈希
+	PasswordChangedAt *gtime.Time `json:"passwordChangedAt" orm:"password_changed_at" description:"密码修改时间"`                      // 密码修改时间
+	AuthSource        string      `json:"authSource"        orm:"auth_source"         description:"认证方式: local=本地密码, ldap=LDAP"` // 认证方式: local=本地密码, ldap=LDAP
+	Email             string      `json:"email"             orm:"email"               description:"邮箱"`                          // 邮箱
+	Mobile            string      `json:"mobile"            orm:"mobile"              description:"手机号"`                         // 手机号
+	DepartmentId      uint64      `json:"departmentId"      orm:"department_id"       description:"所属部门ID"`                      // 所属部门ID
+	Status            int         `json:"status"            orm:"status"              description:"状态: 0=禁用, 1=正常, 2=锁定"`        // 状态: 0=禁用, 1=正常, 2=锁定
+	LastLoginAt       *gtime.Time `json:"lastLoginAt"       orm:"last_login_at"       description:"最后登录时间"`                      // 最后登录时间
+	LastLoginIp       string      `json:"lastLoginIp"       orm:"last_login_ip"       description:"最后登录IP"`                      // 最后登录IP
+	LoginAttempts     uint        `json:"loginAttempts"     orm:"login_attempts"      description:"登录失败次数"`                      // 登录失败次数
+	LockedUntil       *gtime.Time `json:"lockedUntil"       orm:"locked_until"        description:"锁定到期时间"`                      // 锁定到期时间
+	TotpSecret        string      `json:"totpSecret"        orm:"totp_secret"         description:"两步验证TOTP密钥"`                  // 两步验证TOTP密钥
+	TotpEnabled       bool        `json:"totpEnabled"       orm:"totp_enabled"        description:"两步验证: 0=未开启, 1=已开启"`          // 两步验证: 0=未开启, 1=已开启
+	CreatedAt         *gtime.Time `json:"createdAt"         orm:"created_at"          description:""`                            //
+	UpdatedAt         *gtime.Time `json:"updatedAt"         orm:"updated_at"          description:""`                            //
+	DeletedAt         *gtime.Time `json:"deletedAt"         orm:"deleted_at"          description:"软删除时间 (NULL=未删除)"`            // 软删除时间 (NULL=未删除)
 }
@@ -40,6 +40,7 @@ func (s *CmsArticle) CreateArticleWithParams(ctx context.Context, params *admin.
 		ArticleType:    params.ArticleType,
 		ExternalUrl:    params.ExternalUrl,
 		CategoryId:     params.CategoryId,
+		AuthorId:       params.AuthorId,
 		AuthorName:     params.AuthorName,
 		CoverImage:     params.CoverImage,
 		Status:         params.Status,
@@ -113,6 +114,12 @@ func (s *CmsArticle) GetArticleById(ctx context.Context, id uint64) (*entity.Cms
 	return article, err
 }
 
+// CheckInScope 检查文章是否存在并且在数据权限范围内
+func (s *CmsArticle) CheckInScope(ctx context.Context, id uint64, scope *admin.DataScope) (bool, error) {
+	model := dao.CmsArticle.Ctx(ctx).Where(dao.CmsArticle.Columns().Id, id)
+	return whereDataScope(ctx, model, scope, dao.CmsArticle.Columns().AuthorId, "").Exist()
+}
+
 // GetArticleList 获取文章列表
 func (s *CmsArticle) GetArticleList(ctx context.Context, page, size int, params *admin.ArticleSearchParams) ([]*entity.CmsArticle, int, error) {
 	model := dao.CmsArticle.Ctx(ctx)
@@ -140,6 +147,7 @@ func (s *CmsArticle) GetArticleList(ctx context.Context, page, size int, params
 		if params.IsRecommend != nil {
 			model = model.Where(dao.CmsArticle.Columns().IsRecommend, params.IsRecommend)
 		}
+		model = whereDataScope(ctx, model, params.Scope, dao.CmsArticle.Columns().AuthorId, "")
 	}
 
 	// 获取总数
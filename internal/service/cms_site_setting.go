@@ -88,6 +88,15 @@ func (s *CmsSiteSetting) CheckSettingKeyExists(ctx context.Context, settingKey s
 	return count > 0, nil
 }
 
+// GetSiteSettingByKey 根据键名获取网站设置
+func (s *CmsSiteSetting) GetSiteSettingByKey(ctx context.Context, settingKey string) (*entity.CmsSiteSetting, error) {
+	var setting *entity.CmsSiteSetting
+	err := dao.CmsSiteSetting.Ctx(ctx).
+		Where(dao.CmsSiteSetting.Columns().SettingKey, settingKey).
+		Scan(&setting)
+	return setting, err
+}
+
 // 分组获取网站设置列表
 func (s *CmsSiteSetting) GetSiteSettingGroups(ctx context.Context) ([]string, error) {
 	groups, err := dao.CmsSiteSetting.Ctx(ctx).
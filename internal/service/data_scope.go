@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"gf-ant-react/internal/dao"
+	"gf-ant-react/internal/model/admin"
+
+	"github.com/gogf/gf/v2/database/gdb"
+)
+
+// whereDataScope 按数据权限范围过滤，scope 为空或可以访问全部数据时不过滤
+// userColumn 为数据所属用户的字段；deptColumn 为数据所属部门的字段，为空时按所属用户的部门过滤
+func whereDataScope(ctx context.Context, model *gdb.Model, scope *admin.DataScope, userColumn, deptColumn string) *gdb.Model {
+
+	if scope == nil || scope.All {
+		return model
+	}
+	if (!scope.Self || scope.UserId == 0) && len(scope.DepartmentIds) == 0 {
+		return model.Where("1=0")
+	}
+
+	builder := model.Builder()
+	if scope.Self && scope.UserId > 0 {
+		builder = builder.WhereOr(userColumn, scope.UserId)
+	}
+	if len(scope.DepartmentIds) > 0 {
+		if deptColumn != "" {
+			builder = builder.WhereOrIn(deptColumn, scope.DepartmentIds)
+		} else {
+			users := dao.SysUsers.Ctx(ctx).Fields(dao.SysUsers.Columns().Id).WhereIn(dao.SysUsers.Columns().DepartmentId, scope.DepartmentIds)
+			builder = builder.WhereOr(userColumn+" IN (?)", users)
+		}
+	}
+
+	return model.Where(builder)
+}
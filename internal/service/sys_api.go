@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"errors"
 
 	"gf-ant-react/internal/dao"
 	"gf-ant-react/internal/model/admin"
@@ -18,17 +17,36 @@ func (s *SysApi) Create(ctx context.Context, data *admin.SysApiCreateParam) (uin
 	if err != nil {
 		return 0, err
 	}
+	SysPermissionCacheService.InvalidateApis(ctx)
 	return uint64(id), nil
 }
 
 func (s *SysApi) Update(ctx context.Context, data *admin.SysApiUpdateParam) error {
 	_, err := dao.SysApis.Ctx(ctx).Where(dao.SysApis.Columns().Id, data.Id).Update(data)
-	return err
+	if err != nil {
+		return err
+	}
+	SysPermissionCacheService.InvalidateApis(ctx)
+	return nil
 }
 
 func (s *SysApi) Delete(ctx context.Context, id uint64) error {
 	_, err := dao.SysApis.Ctx(ctx).Where(dao.SysApis.Columns().Id, id).Delete()
-	return err
+	if err != nil {
+		return err
+	}
+	SysPermissionCacheService.InvalidateApis(ctx)
+	return nil
+}
+
+// 更新状态
+func (s *SysApi) UpdateStatus(ctx context.Context, id uint64, status int) error {
+	_, err := dao.SysApis.Ctx(ctx).Where(dao.SysApis.Columns().Id, id).Data(dao.SysApis.Columns().Status, status).Update()
+	if err != nil {
+		return err
+	}
+	SysPermissionCacheService.InvalidateApis(ctx)
+	return nil
 }
 
 func (s *SysApi) GetAll(ctx context.Context) ([]*entity.SysApis, error) {
@@ -58,11 +76,19 @@ func (s *SysApi) GetByPermissionCode(ctx context.Context, permissionCode string)
 	return api, nil
 }
 
-// GetApisByRoleIds 根据角色ID数组获取所有API（去重）
+// GetApisByRoleIds 根据角色ID数组获取所有启用的API（去重），包括从上级角色继承的API
 func (s *SysApi) GetApisByRoleIds(ctx context.Context, roleIds []uint64) ([]*entity.SysApis, error) {
+	roleIds, err := SysRoleService.ExpandRoleIds(ctx, roleIds)
+	if err != nil {
+		return nil, err
+	}
+	if len(roleIds) == 0 {
+		return []*entity.SysApis{}, nil
+	}
+
 	// 查询与这些角色关联的所有API ID
 	var roleApis []*entity.SysRoleApis
-	err := dao.SysRoleApis.Ctx(ctx).
+	err = dao.SysRoleApis.Ctx(ctx).
 		Distinct().
 		Where(dao.SysRoleApis.Columns().RoleId, roleIds).
 		Fields(dao.SysRoleApis.Columns().ApiId).
@@ -81,29 +107,60 @@ func (s *SysApi) GetApisByRoleIds(ctx context.Context, roleIds []uint64) ([]*ent
 		return []*entity.SysApis{}, nil
 	}
 
-	return s.GetByIds(ctx, uniqueApiIds)
-}
-
-// GetByIds 批量获取API信息
-func (s *SysApi) GetByIds(ctx context.Context, ids []uint64) ([]*entity.SysApis, error) {
 	var apis []*entity.SysApis
-	err := dao.SysApis.Ctx(ctx).Where(dao.SysApis.Columns().Id, ids).Scan(&apis)
+	err = dao.SysApis.Ctx(ctx).WhereIn(dao.SysApis.Columns().Id, uniqueApiIds).Where(dao.SysApis.Columns().Status, admin.ApiStatusEnabled).Scan(&apis)
 	if err != nil {
 		return nil, err
 	}
 	return apis, nil
 }
 
-// 根据请求类型和路径获取权限code
-func (s *SysApi) GetPermissionCode(ctx context.Context, method, url string) (string, error) {
+// HasAuthTypeSet 是否有已设置鉴权方式的API，没有时表示接口表从未按配置初始化
+func (s *SysApi) HasAuthTypeSet(ctx context.Context) (bool, error) {
+	return dao.SysApis.Ctx(ctx).WhereNotNull(dao.SysApis.Columns().AuthType).Exist()
+}
 
-	var api *entity.SysApis
-	if err := dao.SysApis.Ctx(ctx).Fields(dao.SysApis.Columns().PermissionCode).Where(dao.SysApis.Columns().Url, url).Where(dao.SysApis.Columns().Method, method).Scan(&api); err != nil {
-		return "", err
+// GetAuthTypeUnsetIds 获取鉴权方式为空（尚未按配置初始化）的API ID
+func (s *SysApi) GetAuthTypeUnsetIds(ctx context.Context) ([]uint64, error) {
+	values, err := dao.SysApis.Ctx(ctx).Fields(dao.SysApis.Columns().Id).WhereNull(dao.SysApis.Columns().AuthType).Array()
+	if err != nil {
+		return nil, err
 	}
-	if api == nil {
-		return "", errors.New("接口不存在")
+
+	ids := make([]uint64, 0, len(values))
+	for _, value := range values {
+		ids = append(ids, value.Uint64())
+	}
+
+	return ids, nil
+}
+
+// UpdateAuthType 更新鉴权方式
+func (s *SysApi) UpdateAuthType(ctx context.Context, id uint64, authType int) error {
+	_, err := dao.SysApis.Ctx(ctx).Where(dao.SysApis.Columns().Id, id).Data(dao.SysApis.Columns().AuthType, authType).Update()
+	if err != nil {
+		return err
+	}
+	SysPermissionCacheService.InvalidateApis(ctx)
+	return nil
+}
+
+// GetEnabled 获取所有启用的API
+func (s *SysApi) GetEnabled(ctx context.Context) ([]*entity.SysApis, error) {
+	var apis []*entity.SysApis
+	err := dao.SysApis.Ctx(ctx).Where(dao.SysApis.Columns().Status, admin.ApiStatusEnabled).Order("sort DESC, id DESC").Scan(&apis)
+	if err != nil {
+		return nil, err
 	}
+	return apis, nil
+}
 
-	return api.PermissionCode, nil
+// GetByIds 批量获取API信息
+func (s *SysApi) GetByIds(ctx context.Context, ids []uint64) ([]*entity.SysApis, error) {
+	var apis []*entity.SysApis
+	err := dao.SysApis.Ctx(ctx).Where(dao.SysApis.Columns().Id, ids).Scan(&apis)
+	if err != nil {
+		return nil, err
+	}
+	return apis, nil
 }
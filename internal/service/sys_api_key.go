@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+
+	"gf-ant-react/internal/dao"
+	"gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type SysApiKey struct{}
+
+var SysApiKeyService = &SysApiKey{}
+
+// Create 保存 API Key
+func (s *SysApiKey) Create(ctx context.Context, data *entity.SysApiKeys) (uint64, error) {
+	id, err := dao.SysApiKeys.Ctx(ctx).FieldsEx(dao.SysApiKeys.Columns().Id).InsertAndGetId(data)
+	return uint64(id), err
+}
+
+// GetByHash 根据密钥哈希获取 API Key
+func (s *SysApiKey) GetByHash(ctx context.Context, hash string) (*entity.SysApiKeys, error) {
+	var key *entity.SysApiKeys
+	err := dao.SysApiKeys.Ctx(ctx).Where(dao.SysApiKeys.Columns().KeyHash, hash).Scan(&key)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// GetById 根据ID获取 API Key
+func (s *SysApiKey) GetById(ctx context.Context, id uint64) (*entity.SysApiKeys, error) {
+	var key *entity.SysApiKeys
+	err := dao.SysApiKeys.Ctx(ctx).Where(dao.SysApiKeys.Columns().Id, id).Scan(&key)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// GetList API Key 列表，不返回密钥哈希
+func (s *SysApiKey) GetList(ctx context.Context, param *admin.SysApiKeyListParam) ([]*admin.SysApiKeyItem, int, error) {
+	var list []*admin.SysApiKeyItem
+
+	model := dao.SysApiKeys.Ctx(ctx).As("k").
+		LeftJoin(dao.SysUsers.Table(), "u", "u.id = k.user_id")
+
+	if param.UserId > 0 {
+		model = model.Where("k."+dao.SysApiKeys.Columns().UserId, param.UserId)
+	}
+	if !param.WithRevoked {
+		model = model.WhereNull("k." + dao.SysApiKeys.Columns().RevokedAt)
+	}
+	model = whereDataScope(ctx, model, param.Scope, "k."+dao.SysApiKeys.Columns().UserId, "")
+
+	total, err := model.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if param.Size > 0 {
+		model = model.Page(param.Page, param.Size)
+	}
+
+	err = model.Fields("k.*, u.username").FieldsEx("k." + dao.SysApiKeys.Columns().KeyHash).OrderDesc("k." + dao.SysApiKeys.Columns().Id).Scan(&list)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+// Touch 记录最后使用时间和IP
+func (s *SysApiKey) Touch(ctx context.Context, id uint64, ip string) error {
+	_, err := dao.SysApiKeys.Ctx(ctx).Where(dao.SysApiKeys.Columns().Id, id).Data(map[string]interface{}{
+		dao.SysApiKeys.Columns().LastUsedAt: gtime.Now(),
+		dao.SysApiKeys.Columns().LastUsedIp: ip,
+	}).Update()
+	return err
+}
+
+// Revoke 吊销 API Key
+func (s *SysApiKey) Revoke(ctx context.Context, id uint64) error {
+	_, err := dao.SysApiKeys.Ctx(ctx).
+		Where(dao.SysApiKeys.Columns().Id, id).
+		WhereNull(dao.SysApiKeys.Columns().RevokedAt).
+		Data(dao.SysApiKeys.Columns().RevokedAt, gtime.Now()).
+		Update()
+	return err
+}
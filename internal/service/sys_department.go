@@ -6,6 +6,8 @@ import (
 	"gf-ant-react/internal/dao"
 	"gf-ant-react/internal/model/admin"
 	"gf-ant-react/internal/model/entity"
+
+	"github.com/gogf/gf/v2/database/gdb"
 )
 
 type SysDepartment struct{}
@@ -26,9 +28,18 @@ func (s *SysDepartment) Update(ctx context.Context, data *admin.SysDepartmentUpd
 	return err
 }
 
+// Delete 删除部门，同时从角色的自定义数据权限范围中移除
 func (s *SysDepartment) Delete(ctx context.Context, id uint64) error {
-	_, err := dao.SysDepartments.Ctx(ctx).Where(dao.SysDepartments.Columns().Id, id).Delete()
-	return err
+	return dao.SysDepartments.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+
+		_, err := dao.SysRoleDepartments.Ctx(ctx).Where(dao.SysRoleDepartments.Columns().DepartmentId, id).Delete()
+		if err != nil {
+			return err
+		}
+
+		_, err = dao.SysDepartments.Ctx(ctx).Where(dao.SysDepartments.Columns().Id, id).Delete()
+		return err
+	})
 }
 
 func (s *SysDepartment) GetAll(ctx context.Context) ([]*entity.SysDepartments, error) {
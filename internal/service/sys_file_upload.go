@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"gf-ant-react/internal/dao"
+	"gf-ant-react/internal/model/admin"
 	"gf-ant-react/internal/model/entity"
 
 	"github.com/gogf/gf/v2/os/gtime"
@@ -56,8 +57,14 @@ func (s *SysFileUpload) GetFileByMd5(ctx context.Context, md5 string) (*entity.S
 	return file, err
 }
 
-// GetFileList 获取文件列表，支持按业务类型搜索、文件名模糊检索和分页
-func (s *SysFileUpload) GetFileList(ctx context.Context, bizType string, fileName string, page int, pageSize int) ([]*entity.SysFileUpload, int, error) {
+// CheckInScope 检查文件是否存在并且在数据权限范围内
+func (s *SysFileUpload) CheckInScope(ctx context.Context, id uint64, scope *admin.DataScope) (bool, error) {
+	model := dao.SysFileUpload.Ctx(ctx).Where(dao.SysFileUpload.Columns().Id, id)
+	return whereDataScope(ctx, model, scope, dao.SysFileUpload.Columns().UploaderId, "").Exist()
+}
+
+// GetFileList 获取文件列表，支持按业务类型搜索、文件名模糊检索和分页，scope 为空时不限制数据权限范围
+func (s *SysFileUpload) GetFileList(ctx context.Context, bizType string, fileName string, page int, pageSize int, scope *admin.DataScope) ([]*entity.SysFileUpload, int, error) {
 	// 构建查询条件
 	model := dao.SysFileUpload.Ctx(ctx)
 
@@ -71,6 +78,9 @@ func (s *SysFileUpload) GetFileList(ctx context.Context, bizType string, fileNam
 		model = model.WhereLike(dao.SysFileUpload.Columns().FileName, "%"+fileName+"%")
 	}
 
+	// 数据权限范围
+	model = whereDataScope(ctx, model, scope, dao.SysFileUpload.Columns().UploaderId, "")
+
 	// 计算总数
 	var total int
 	var err error
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+
+	"gf-ant-react/internal/dao"
+	"gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type SysLoginLog struct{}
+
+var SysLoginLogService = &SysLoginLog{}
+
+// Create 保存登录日志
+func (s *SysLoginLog) Create(ctx context.Context, data *entity.SysLoginLogs) error {
+	_, err := dao.SysLoginLogs.Ctx(ctx).FieldsEx(dao.SysLoginLogs.Columns().Id).Insert(data)
+	return err
+}
+
+// GetList 登录日志列表，按时间倒序
+func (s *SysLoginLog) GetList(ctx context.Context, param *admin.SysLoginLogListParam) ([]*entity.SysLoginLogs, int, error) {
+	var list []*entity.SysLoginLogs
+	columns := dao.SysLoginLogs.Columns()
+	model := dao.SysLoginLogs.Ctx(ctx)
+
+	if param.UserId > 0 {
+		model = model.Where(columns.UserId, param.UserId)
+	}
+	if param.Username != "" {
+		model = model.WhereLike(columns.Username, "%"+param.Username+"%")
+	}
+	if param.Method != "" {
+		model = model.Where(columns.Method, param.Method)
+	}
+	if param.Status != nil {
+		model = model.Where(columns.Status, *param.Status)
+	}
+	if param.Reason != "" {
+		model = model.Where(columns.Reason, param.Reason)
+	}
+	if param.Ip != "" {
+		model = model.Where(columns.Ip, param.Ip)
+	}
+	if param.StartTime != nil {
+		model = model.WhereGTE(columns.CreatedAt, param.StartTime)
+	}
+	if param.EndTime != nil {
+		model = model.WhereLTE(columns.CreatedAt, param.EndTime)
+	}
+	model = whereDataScope(ctx, model, param.Scope, columns.UserId, "")
+
+	total, err := model.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = model.Page(param.Page, param.Size).OrderDesc(columns.Id).Scan(&list)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+// GetRecentByUserId 用户最近的登录记录
+func (s *SysLoginLog) GetRecentByUserId(ctx context.Context, userId uint64, limit int) ([]*entity.SysLoginLogs, error) {
+	var list []*entity.SysLoginLogs
+	err := dao.SysLoginLogs.Ctx(ctx).
+		Where(dao.SysLoginLogs.Columns().UserId, userId).
+		OrderDesc(dao.SysLoginLogs.Columns().Id).
+		Limit(limit).
+		Scan(&list)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// DeleteBefore 删除指定时间之前的登录日志，返回删除的数量
+func (s *SysLoginLog) DeleteBefore(ctx context.Context, before *gtime.Time) (int64, error) {
+	result, err := dao.SysLoginLogs.Ctx(ctx).WhereLT(dao.SysLoginLogs.Columns().CreatedAt, before).Delete()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
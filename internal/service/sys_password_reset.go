@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+
+	"gf-ant-react/internal/dao"
+	"gf-ant-react/internal/model/entity"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type SysPasswordReset struct{}
+
+var SysPasswordResetService = &SysPasswordReset{}
+
+// Create 保存找回密码令牌
+func (s *SysPasswordReset) Create(ctx context.Context, data *entity.SysPasswordResets) error {
+	_, err := dao.SysPasswordResets.Ctx(ctx).FieldsEx(dao.SysPasswordResets.Columns().Id).Insert(data)
+	return err
+}
+
+// GetByHash 根据令牌哈希获取找回密码令牌
+func (s *SysPasswordReset) GetByHash(ctx context.Context, hash string) (*entity.SysPasswordResets, error) {
+	var reset *entity.SysPasswordResets
+	err := dao.SysPasswordResets.Ctx(ctx).Where(dao.SysPasswordResets.Columns().TokenHash, hash).Scan(&reset)
+	if err != nil {
+		return nil, err
+	}
+	return reset, nil
+}
+
+// MarkUsed 标记令牌已使用
+// 只有尚未使用的令牌会被更新，返回 false 表示令牌已被其他请求抢先使用
+func (s *SysPasswordReset) MarkUsed(ctx context.Context, id uint64) (bool, error) {
+	result, err := dao.SysPasswordResets.Ctx(ctx).
+		Where(dao.SysPasswordResets.Columns().Id, id).
+		WhereNull(dao.SysPasswordResets.Columns().UsedAt).
+		Data(dao.SysPasswordResets.Columns().UsedAt, gtime.Now()).
+		Update()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// InvalidateByUserId 作废用户所有未使用的令牌
+func (s *SysPasswordReset) InvalidateByUserId(ctx context.Context, userId uint64) error {
+	_, err := dao.SysPasswordResets.Ctx(ctx).
+		Where(dao.SysPasswordResets.Columns().UserId, userId).
+		WhereNull(dao.SysPasswordResets.Columns().UsedAt).
+		Data(dao.SysPasswordResets.Columns().UsedAt, gtime.Now()).
+		Update()
+	return err
+}
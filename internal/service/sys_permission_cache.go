@@ -0,0 +1,460 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gf-ant-react/internal/dao"
+	"gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
+	"gf-ant-react/utility/route"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// 失效范围
+const (
+	permissionScopeUser  = "user"  // 单个用户的状态和角色
+	permissionScopeRoles = "roles" // 角色权限码
+	permissionScopeApis  = "apis"  // 接口路由和角色权限码
+	permissionScopeAll   = "all"   // 全部
+)
+
+// SysPermissionCache 鉴权缓存：接口路由到权限码和鉴权方式、启用的角色到权限码集合、用户到状态和角色
+// 角色、接口和用户角色变化时精确失效，多副本部署时通过 Redis 广播失效消息
+type SysPermissionCache struct {
+	enabled   bool
+	userTTL   time.Duration
+	broadcast bool
+	redis     string
+	channel   string
+	instance  string // 当前副本标识，忽略自己发出的广播
+
+	mu      sync.RWMutex
+	version uint64                     // 每次失效加一，加载期间发生失效时丢弃加载结果
+	routes  *SysPermissionRoutes       // 接口路由
+	roles   *SysPermissionRoles        // 启用的角色和权限码
+	users   map[uint64]*permissionUser // 用户ID => 用户状态和角色
+
+	hits   atomic.Int64
+	misses atomic.Int64
+
+	// 数据加载，默认查询数据库
+	LoadRoutes func(ctx context.Context) (*SysPermissionRoutes, error)
+	LoadRoles  func(ctx context.Context) (*SysPermissionRoles, error)
+	LoadUser   func(ctx context.Context, userId uint64) (*entity.SysUsers, []uint64, error)
+}
+
+// SysPermissionRoutes 接口路由，多条接口匹配时使用最具体的一条
+type SysPermissionRoutes struct {
+	Codes     *route.Table // 接口路由 => 权限码
+	AuthTypes *route.Table // 接口路由 => 鉴权方式，禁用的接口需要权限
+}
+
+// Len 已缓存的接口数
+func (r *SysPermissionRoutes) Len() int {
+	if r == nil {
+		return 0
+	}
+	return r.Codes.Len()
+}
+
+// SysPermissionRoles 启用的角色，禁用的角色和禁用的接口不授予权限
+type SysPermissionRoles struct {
+	Codes map[uint64]map[string]struct{} // 角色ID => 启用的接口权限码集合，包括继承的上级角色的权限码
+	Super map[uint64]struct{}            // 超级管理员角色ID
+}
+
+// Len 有权限的角色数
+func (r *SysPermissionRoles) Len() int {
+	if r == nil {
+		return 0
+	}
+	n := len(r.Codes)
+	for id := range r.Super {
+		if _, ok := r.Codes[id]; !ok {
+			n++
+		}
+	}
+	return n
+}
+
+type permissionUser struct {
+	user      *entity.SysUsers
+	roleIds   []uint64
+	expiresAt time.Time
+}
+
+// 广播的失效消息
+type permissionMessage struct {
+	Instance string `json:"instance"`
+	Scope    string `json:"scope"`
+	UserId   uint64 `json:"userId,omitempty"`
+}
+
+// SysPermissionCacheStats 缓存统计
+type SysPermissionCacheStats struct {
+	Enabled bool  `json:"enabled"`
+	Hits    int64 `json:"hits"`   // 命中次数
+	Misses  int64 `json:"misses"` // 查询数据库次数
+	Routes  int   `json:"routes"` // 已缓存的接口数
+	Roles   int   `json:"roles"`  // 已缓存的角色数
+	Users   int   `json:"users"`  // 已缓存的用户数
+}
+
+var SysPermissionCacheService = newSysPermissionCache(context.Background())
+
+func newSysPermissionCache(ctx context.Context) *SysPermissionCache {
+
+	cfg := g.Cfg("auth")
+	s := &SysPermissionCache{
+		enabled:   cfg.MustGet(ctx, "permissionCache.enabled", true).Bool(),
+		userTTL:   time.Duration(cfg.MustGet(ctx, "permissionCache.userTTL", 300).Int64()) * time.Second,
+		broadcast: cfg.MustGet(ctx, "permissionCache.broadcast", false).Bool(),
+		redis:     cfg.MustGet(ctx, "permissionCache.redis", "default").String(),
+		channel:   cfg.MustGet(ctx, "permissionCache.channel", "gf-ant-react:permission-cache").String(),
+		instance:  guid.S(),
+		users:     make(map[uint64]*permissionUser),
+	}
+	s.LoadRoutes = s.loadRoutes
+	s.LoadRoles = s.loadRoles
+	s.LoadUser = SysUserService.GetById
+
+	return s
+}
+
+// GetUser 获取用户及其角色ID，返回的用户信息是共享的，不能修改
+func (s *SysPermissionCache) GetUser(ctx context.Context, userId uint64) (*entity.SysUsers, []uint64, error) {
+
+	if !s.enabled {
+		return s.LoadUser(ctx, userId)
+	}
+
+	s.mu.RLock()
+	item, version := s.users[userId], s.version
+	s.mu.RUnlock()
+	if item != nil && time.Now().Before(item.expiresAt) {
+		s.hits.Add(1)
+		return item.user, item.roleIds, nil
+	}
+
+	s.misses.Add(1)
+	user, roleIds, err := s.LoadUser(ctx, userId)
+	if err != nil || user == nil {
+		return user, roleIds, err
+	}
+
+	s.mu.Lock()
+	if s.version == version {
+		s.users[userId] = &permissionUser{user: user, roleIds: roleIds, expiresAt: time.Now().Add(s.userTTL)}
+	}
+	s.mu.Unlock()
+
+	return user, roleIds, nil
+}
+
+// GetPermissionCode 根据请求类型和路径获取权限码，多条接口匹配时使用最具体的一条
+func (s *SysPermissionCache) GetPermissionCode(ctx context.Context, method, path string) (string, error) {
+
+	routes, _, err := s.tables(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	code, ok := routes.Codes.Lookup(method, path)
+	if !ok {
+		return "", errors.New("接口不存在")
+	}
+
+	return code, nil
+}
+
+// GetAuthType 根据请求类型和路径获取接口的鉴权方式，没有匹配的接口时需要权限
+func (s *SysPermissionCache) GetAuthType(ctx context.Context, method, path string) (int, error) {
+
+	routes, _, err := s.tables(ctx)
+	if err != nil {
+		return admin.AuthTypePermission, err
+	}
+
+	authType, ok := routes.AuthTypes.Lookup(method, path)
+	if !ok {
+		return admin.AuthTypePermission, nil
+	}
+
+	return gconv.Int(authType), nil
+}
+
+// CheckPermission 检查角色集合是否有权限码，超级管理员角色拥有所有权限
+func (s *SysPermissionCache) CheckPermission(ctx context.Context, roleIds []uint64, permissionCode string) (bool, error) {
+
+	if len(roleIds) == 0 {
+		return false, errors.New("角色不能为空")
+	}
+
+	_, roles, err := s.tables(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, roleId := range roleIds {
+		if _, ok := roles.Super[roleId]; ok {
+			return true, nil
+		}
+		if _, ok := roles.Codes[roleId][permissionCode]; ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// 获取接口路由和角色权限码，未缓存时从数据库加载
+func (s *SysPermissionCache) tables(ctx context.Context) (*SysPermissionRoutes, *SysPermissionRoles, error) {
+
+	s.mu.RLock()
+	routes, roles, version := s.routes, s.roles, s.version
+	s.mu.RUnlock()
+	if s.enabled && routes != nil && roles != nil {
+		s.hits.Add(1)
+		return routes, roles, nil
+	}
+
+	s.misses.Add(1)
+	var err error
+	if routes == nil || !s.enabled {
+		if routes, err = s.LoadRoutes(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+	if roles == nil || !s.enabled {
+		if roles, err = s.LoadRoles(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if s.enabled {
+		s.mu.Lock()
+		if s.version == version {
+			s.routes, s.roles = routes, roles
+		}
+		s.mu.Unlock()
+	}
+
+	return routes, roles, nil
+}
+
+func (s *SysPermissionCache) loadRoutes(ctx context.Context) (*SysPermissionRoutes, error) {
+
+	apis, err := SysApiService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 保存时已检查冲突，直接修改数据库造成的冲突只保留其中一条
+	routes := &SysPermissionRoutes{
+		Codes:     route.NewTable(),
+		AuthTypes: route.NewTable(),
+	}
+	for _, api := range apis {
+		if err = routes.Codes.Add(api.Method, api.Url, api.PermissionCode); err != nil {
+			g.Log().Warningf(ctx, "接口 %d 的路由规则无效: %v", api.Id, err)
+			continue
+		}
+		// 禁用的接口不放开鉴权
+		authType := api.AuthType
+		if api.Status != admin.ApiStatusEnabled {
+			authType = admin.AuthTypePermission
+		}
+		_ = routes.AuthTypes.Add(api.Method, api.Url, gconv.String(authType))
+	}
+
+	return routes, nil
+}
+
+func (s *SysPermissionCache) loadRoles(ctx context.Context) (*SysPermissionRoles, error) {
+
+	var all []*entity.SysRoles
+	err := dao.SysRoles.Ctx(ctx).Fields(dao.SysRoles.Columns().Id, dao.SysRoles.Columns().ParentId, dao.SysRoles.Columns().Status, dao.SysRoles.Columns().IsSuper).Scan(&all)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := &SysPermissionRoles{
+		Codes: make(map[uint64]map[string]struct{}),
+		Super: make(map[uint64]struct{}),
+	}
+	var roleIds []uint64
+	for _, role := range all {
+		if !role.Status {
+			continue
+		}
+		if role.IsSuper {
+			roles.Super[role.Id] = struct{}{}
+		}
+		roleIds = append(roleIds, role.Id)
+	}
+	if len(roleIds) == 0 {
+		return roles, nil
+	}
+
+	// 只加载启用的接口
+	apis := dao.SysApis.Ctx(ctx).Fields(dao.SysApis.Columns().Id).Where(dao.SysApis.Columns().Status, admin.ApiStatusEnabled)
+	var roleApis []*entity.SysRoleApis
+	err = dao.SysRoleApis.Ctx(ctx).Fields(dao.SysRoleApis.Columns().RoleId, dao.SysRoleApis.Columns().PermissionCode).
+		WhereIn(dao.SysRoleApis.Columns().RoleId, roleIds).
+		WhereIn(dao.SysRoleApis.Columns().ApiId, apis).
+		Scan(&roleApis)
+	if err != nil {
+		return nil, err
+	}
+
+	direct := make(map[uint64][]string)
+	for _, roleApi := range roleApis {
+		direct[roleApi.RoleId] = append(direct[roleApi.RoleId], roleApi.PermissionCode)
+	}
+
+	// 角色的权限为上级角色链上所有启用角色的权限之和
+	tree := newRoleTree(all)
+	for _, roleId := range roleIds {
+		for _, id := range tree.chain(roleId) {
+			for _, code := range direct[id] {
+				if roles.Codes[roleId] == nil {
+					roles.Codes[roleId] = make(map[string]struct{})
+				}
+				roles.Codes[roleId][code] = struct{}{}
+			}
+		}
+	}
+
+	return roles, nil
+}
+
+// InvalidateUser 用户状态或角色变化
+func (s *SysPermissionCache) InvalidateUser(ctx context.Context, userId uint64) {
+	s.invalidate(permissionScopeUser, userId)
+	s.publish(ctx, permissionScopeUser, userId)
+}
+
+// InvalidateRoles 角色或角色权限变化
+func (s *SysPermissionCache) InvalidateRoles(ctx context.Context) {
+	s.invalidate(permissionScopeRoles, 0)
+	s.publish(ctx, permissionScopeRoles, 0)
+}
+
+// InvalidateApis 接口变化或启用状态变化，角色中保存的权限码同时失效
+func (s *SysPermissionCache) InvalidateApis(ctx context.Context) {
+	s.invalidate(permissionScopeApis, 0)
+	s.publish(ctx, permissionScopeApis, 0)
+}
+
+// InvalidateAll 清空缓存
+func (s *SysPermissionCache) InvalidateAll(ctx context.Context) {
+	s.invalidate(permissionScopeAll, 0)
+	s.publish(ctx, permissionScopeAll, 0)
+}
+
+func (s *SysPermissionCache) invalidate(scope string, userId uint64) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.version++
+	switch scope {
+	case permissionScopeUser:
+		delete(s.users, userId)
+	case permissionScopeRoles:
+		s.roles = nil
+	case permissionScopeApis:
+		s.routes, s.roles = nil, nil
+	default:
+		s.routes, s.roles = nil, nil
+		s.users = make(map[uint64]*permissionUser)
+	}
+}
+
+// Stats 缓存统计
+func (s *SysPermissionCache) Stats() *SysPermissionCacheStats {
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return &SysPermissionCacheStats{
+		Enabled: s.enabled,
+		Hits:    s.hits.Load(),
+		Misses:  s.misses.Load(),
+		Routes:  s.routes.Len(),
+		Roles:   s.roles.Len(),
+		Users:   len(s.users),
+	}
+}
+
+// 向其他副本广播失效消息，失败时只记录日志，其他副本的用户缓存最迟在 userTTL 后过期
+func (s *SysPermissionCache) publish(ctx context.Context, scope string, userId uint64) {
+
+	if !s.broadcast {
+		return
+	}
+
+	message := gjson.MustEncodeString(&permissionMessage{Instance: s.instance, Scope: scope, UserId: userId})
+	if _, err := g.Redis(s.redis).Publish(ctx, s.channel, message); err != nil {
+		g.Log().Warningf(ctx, "广播权限缓存失效消息失败: scope=%s userId=%d err=%v", scope, userId, err)
+	}
+}
+
+// StartBroadcast 订阅其他副本的失效消息，连接断开后自动重连
+func (s *SysPermissionCache) StartBroadcast(ctx context.Context) {
+
+	if !s.enabled || !s.broadcast {
+		return
+	}
+
+	go func() {
+		for {
+			if err := s.subscribe(ctx); err != nil {
+				g.Log().Warningf(ctx, "订阅权限缓存失效消息失败，5 秒后重试: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+	}()
+}
+
+func (s *SysPermissionCache) subscribe(ctx context.Context) error {
+
+	conn, _, err := g.Redis(s.redis).Subscribe(ctx, s.channel)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+
+	// 断开期间可能错过了消息
+	s.invalidate(permissionScopeAll, 0)
+
+	for {
+		msg, err := conn.ReceiveMessage(ctx)
+		if err != nil {
+			return err
+		}
+
+		message := &permissionMessage{}
+		if err = gjson.DecodeTo(msg.Payload, message); err != nil {
+			g.Log().Warningf(ctx, "权限缓存失效消息格式错误: %s", msg.Payload)
+			continue
+		}
+		if message.Instance == s.instance {
+			continue
+		}
+
+		s.invalidate(message.Scope, message.UserId)
+	}
+}
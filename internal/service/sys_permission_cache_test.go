@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
+	"gf-ant-react/utility/route"
+)
+
+// 鉴权热路径：缓存预热后，用户、路由和角色权限的查询都不访问数据库
+func BenchmarkCheckPermission(b *testing.B) {
+
+	ctx := context.Background()
+
+	var loads atomic.Int64
+	s := &SysPermissionCache{
+		enabled: true,
+		userTTL: time.Hour,
+		users:   make(map[uint64]*permissionUser),
+	}
+	s.LoadRoutes = func(ctx context.Context) (*SysPermissionRoutes, error) {
+		loads.Add(1)
+		routes := &SysPermissionRoutes{Codes: route.NewTable(), AuthTypes: route.NewTable()}
+		_ = routes.Codes.Add("GET", "/sys/user/detail/:id", "sys.user.detail")
+		_ = routes.AuthTypes.Add("GET", "/sys/user/detail/:id", "0")
+		return routes, nil
+	}
+	s.LoadRoles = func(ctx context.Context) (*SysPermissionRoles, error) {
+		loads.Add(1)
+		return &SysPermissionRoles{
+			Codes: map[uint64]map[string]struct{}{2: {"sys.user.detail": {}}},
+			Super: map[uint64]struct{}{},
+		}, nil
+	}
+	s.LoadUser = func(ctx context.Context, userId uint64) (*entity.SysUsers, []uint64, error) {
+		loads.Add(1)
+		return &entity.SysUsers{Id: userId, Status: admin.UserStatusEnabled}, []uint64{2}, nil
+	}
+
+	// 预热缓存
+	check := func() {
+		_, roleIds, err := s.GetUser(ctx, 1)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err = s.GetAuthType(ctx, "GET", "/sys/user/detail/1"); err != nil {
+			b.Fatal(err)
+		}
+		code, err := s.GetPermissionCode(ctx, "GET", "/sys/user/detail/1")
+		if err != nil {
+			b.Fatal(err)
+		}
+		ok, err := s.CheckPermission(ctx, roleIds, code)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if !ok {
+			b.Fatal("角色应当拥有权限")
+		}
+	}
+	check()
+
+	misses, loaded := s.misses.Load(), loads.Load()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		check()
+	}
+	b.StopTimer()
+
+	if n := s.misses.Load() - misses; n != 0 {
+		b.Fatalf("热路径未命中缓存 %d 次", n)
+	}
+	if n := loads.Load() - loaded; n != 0 {
+		b.Fatalf("热路径访问数据库 %d 次", n)
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	"gf-ant-react/internal/dao"
+	"gf-ant-react/internal/model/entity"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type SysRefreshToken struct{}
+
+var SysRefreshTokenService = &SysRefreshToken{}
+
+// Create 保存刷新令牌
+func (s *SysRefreshToken) Create(ctx context.Context, data *entity.SysRefreshTokens) error {
+	_, err := dao.SysRefreshTokens.Ctx(ctx).FieldsEx(dao.SysRefreshTokens.Columns().Id).Insert(data)
+	return err
+}
+
+// GetByHash 根据令牌哈希获取刷新令牌
+func (s *SysRefreshToken) GetByHash(ctx context.Context, hash string) (*entity.SysRefreshTokens, error) {
+	var token *entity.SysRefreshTokens
+	err := dao.SysRefreshTokens.Ctx(ctx).Where(dao.SysRefreshTokens.Columns().TokenHash, hash).Scan(&token)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// MarkUsed 标记刷新令牌已使用
+// 只有尚未使用的令牌会被更新，返回 false 表示令牌已被其他请求抢先使用
+func (s *SysRefreshToken) MarkUsed(ctx context.Context, id uint64) (bool, error) {
+	result, err := dao.SysRefreshTokens.Ctx(ctx).
+		Where(dao.SysRefreshTokens.Columns().Id, id).
+		WhereNull(dao.SysRefreshTokens.Columns().UsedAt).
+		Data(dao.SysRefreshTokens.Columns().UsedAt, gtime.Now()).
+		Update()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// RevokeByUserId 吊销用户的所有刷新令牌
+func (s *SysRefreshToken) RevokeByUserId(ctx context.Context, userId uint64) error {
+	_, err := dao.SysRefreshTokens.Ctx(ctx).
+		Where(dao.SysRefreshTokens.Columns().UserId, userId).
+		WhereNull(dao.SysRefreshTokens.Columns().RevokedAt).
+		Data(dao.SysRefreshTokens.Columns().RevokedAt, gtime.Now()).
+		Update()
+	return err
+}
+
+// RevokeFamily 吊销整个令牌族
+func (s *SysRefreshToken) RevokeFamily(ctx context.Context, familyId string) error {
+	_, err := dao.SysRefreshTokens.Ctx(ctx).
+		Where(dao.SysRefreshTokens.Columns().FamilyId, familyId).
+		WhereNull(dao.SysRefreshTokens.Columns().RevokedAt).
+		Data(dao.SysRefreshTokens.Columns().RevokedAt, gtime.Now()).
+		Update()
+	return err
+}
@@ -2,12 +2,15 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"slices"
 
 	"gf-ant-react/internal/dao"
 	"gf-ant-react/internal/model/admin"
 	"gf-ant-react/internal/model/entity"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/os/gtime"
 )
 
 type SysRole struct{}
@@ -65,10 +68,16 @@ func (s *SysRole) Create(ctx context.Context, data *admin.SysRoleCreateParam) (u
 		}
 	}
 
+	// 创建角色部门关联
+	if err = s.saveDepartments(ctx, tx, uint64(roleId), data.DataScope, data.DepartmentIds); err != nil {
+		return 0, err
+	}
+
 	// 提交事务
 	if err := tx.Commit(); err != nil {
 		return 0, err
 	}
+	SysPermissionCacheService.InvalidateRoles(ctx)
 
 	return uint64(roleId), nil
 }
@@ -133,10 +142,21 @@ func (s *SysRole) Update(ctx context.Context, data *admin.SysRoleUpdateParam) er
 		}
 	}
 
+	// 删除原有的角色部门关联后重新创建
+	_, err = tx.Model(dao.SysRoleDepartments.Table()).Ctx(ctx).
+		Where(dao.SysRoleDepartments.Columns().RoleId, data.Id).Delete()
+	if err != nil {
+		return err
+	}
+	if err = s.saveDepartments(ctx, tx, data.Id, data.DataScope, data.DepartmentIds); err != nil {
+		return err
+	}
+
 	// 提交事务
 	err = tx.Commit()
 	if err == nil {
 		tx = nil // 提交成功后将tx置为nil，避免defer执行回滚
+		SysPermissionCacheService.InvalidateRoles(ctx)
 	}
 	return err
 }
@@ -162,6 +182,13 @@ func (s *SysRole) Delete(ctx context.Context, id uint64) error {
 		return err
 	}
 
+	// 删除角色部门关联
+	_, err = tx.Model(dao.SysRoleDepartments.Table()).Ctx(ctx).
+		Where(dao.SysRoleDepartments.Columns().RoleId, id).Delete()
+	if err != nil {
+		return err
+	}
+
 	// 删除角色
 	_, err = tx.Model(dao.SysRoles.Table()).Ctx(ctx).
 		Where(dao.SysRoles.Columns().Id, id).Delete()
@@ -173,10 +200,30 @@ func (s *SysRole) Delete(ctx context.Context, id uint64) error {
 	err = tx.Commit()
 	if err == nil {
 		tx = nil // 提交成功后将tx置为nil，避免defer执行回滚
+		SysPermissionCacheService.InvalidateRoles(ctx)
 	}
 	return err
 }
 
+// saveDepartments 保存自定义数据权限范围的部门，其他范围不需要保存部门
+func (s *SysRole) saveDepartments(ctx context.Context, tx gdb.TX, roleId uint64, dataScope int, departmentIds []uint64) error {
+	if dataScope != admin.DataScopeCustom || len(departmentIds) == 0 {
+		return nil
+	}
+
+	roleDepartments := make([]entity.SysRoleDepartments, 0, len(departmentIds))
+	for _, departmentId := range departmentIds {
+		roleDepartments = append(roleDepartments, entity.SysRoleDepartments{
+			RoleId:       roleId,
+			DepartmentId: departmentId,
+			CreatedAt:    gtime.Now(),
+		})
+	}
+
+	_, err := tx.Model(dao.SysRoleDepartments.Table()).Ctx(ctx).FieldsEx(dao.SysRoleDepartments.Columns().Id).Insert(roleDepartments)
+	return err
+}
+
 func (s *SysRole) GetList(ctx context.Context, page, size int, name string, status *bool) ([]*SysRoleItem, int, error) {
 	var roles []*entity.SysRoles
 	model := dao.SysRoles.Ctx(ctx)
@@ -264,6 +311,29 @@ func (s *SysRole) GetById(ctx context.Context, id uint64) (*entity.SysRoles, []u
 	return role, apiIds, nil
 }
 
+// GetDepartmentIds 获取角色自定义数据权限范围的部门ID列表，多个角色时去重
+func (s *SysRole) GetDepartmentIds(ctx context.Context, roleIds ...uint64) ([]uint64, error) {
+	if len(roleIds) == 0 {
+		return nil, nil
+	}
+
+	values, err := dao.SysRoleDepartments.Ctx(ctx).
+		Fields(dao.SysRoleDepartments.Columns().DepartmentId).
+		WhereIn(dao.SysRoleDepartments.Columns().RoleId, roleIds).
+		Distinct().
+		Array()
+	if err != nil {
+		return nil, err
+	}
+
+	departmentIds := make([]uint64, 0, len(values))
+	for _, value := range values {
+		departmentIds = append(departmentIds, value.Uint64())
+	}
+
+	return departmentIds, nil
+}
+
 // GetAll 获取所有角色
 func (s *SysRole) GetAll(ctx context.Context) ([]*entity.SysRoles, error) {
 	var roles []*entity.SysRoles
@@ -274,33 +344,150 @@ func (s *SysRole) GetAll(ctx context.Context) ([]*entity.SysRoles, error) {
 	return roles, nil
 }
 
-// GetUserRoles 获取用户角色及权限信息
-func (s *SysRole) GetUserRoles(ctx context.Context, userId uint64) ([]*entity.SysRoles, error) {
+// roleTree 角色的上级关系，用于计算继承的接口权限
+type roleTree struct {
+	parents map[uint64]uint64 // 角色ID => 上级角色ID
+	enabled map[uint64]bool   // 角色ID => 是否启用
+}
+
+func newRoleTree(roles []*entity.SysRoles) *roleTree {
+	t := &roleTree{
+		parents: make(map[uint64]uint64, len(roles)),
+		enabled: make(map[uint64]bool, len(roles)),
+	}
+	for _, role := range roles {
+		t.parents[role.Id] = role.ParentId
+		t.enabled[role.Id] = role.Status
+	}
+	return t
+}
+
+// chain 角色及其上级角色链，从自己开始，遇到禁用或不存在的角色时停止，数据错误形成环时也停止
+func (t *roleTree) chain(roleId uint64) []uint64 {
+	var ids []uint64
+	seen := make(map[uint64]bool)
+	for id := roleId; id != 0 && !seen[id] && t.enabled[id]; id = t.parents[id] {
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// 加载所有角色的上级关系
+func (s *SysRole) loadTree(ctx context.Context) (*roleTree, error) {
 	var roles []*entity.SysRoles
-	// 通过 sys_user_roles 表关联用户和角色，并获取完整的角色信息
-	err := dao.SysRoles.Ctx(ctx).Fields(fmt.Sprintf("%s.*", dao.SysRoles.Table())).
-		InnerJoin(dao.SysUserRoles.Table(), fmt.Sprintf("%s.%s = %s.%s", dao.SysRoles.Table(), dao.SysRoles.Columns().Id, dao.SysUserRoles.Table(), dao.SysUserRoles.Columns().RoleId)).
-		Where(fmt.Sprintf("%s.%s", dao.SysUserRoles.Table(), dao.SysUserRoles.Columns().UserId), userId).
-		Scan(&roles)
+	err := dao.SysRoles.Ctx(ctx).Fields(dao.SysRoles.Columns().Id, dao.SysRoles.Columns().ParentId, dao.SysRoles.Columns().Status).Scan(&roles)
 	if err != nil {
 		return nil, err
 	}
-	return roles, nil
+	return newRoleTree(roles), nil
 }
 
-// 检查角色集合是否可以访问权限
-func (s *SysRole) CheckPermission(ctx context.Context, roleIds []uint64, permissionCode string) (bool, error) {
+// ExpandRoleIds 角色及其继承的上级角色，只包括启用的角色
+func (s *SysRole) ExpandRoleIds(ctx context.Context, roleIds []uint64) ([]uint64, error) {
+	if len(roleIds) == 0 {
+		return nil, nil
+	}
 
-	// 检查角色是否为空
+	tree, err := s.loadTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []uint64
+	seen := make(map[uint64]bool)
+	for _, roleId := range roleIds {
+		for _, id := range tree.chain(roleId) {
+			if !seen[id] {
+				seen[id] = true
+				result = append(result, id)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// GetAncestors 角色继承的上级角色，从直接上级开始，只包括启用的角色
+func (s *SysRole) GetAncestors(ctx context.Context, parentId uint64) ([]*entity.SysRoles, error) {
+	tree, err := s.loadTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := tree.chain(parentId)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var roles []*entity.SysRoles
+	if err = dao.SysRoles.Ctx(ctx).WhereIn(dao.SysRoles.Columns().Id, ids).Scan(&roles); err != nil {
+		return nil, err
+	}
+
+	// 按继承顺序排列
+	index := make(map[uint64]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+	slices.SortFunc(roles, func(a, b *entity.SysRoles) int {
+		return index[a.Id] - index[b.Id]
+	})
+
+	return roles, nil
+}
+
+// GetApiIdsByRoleIds 获取多个角色各自直接关联的API ID
+func (s *SysRole) GetApiIdsByRoleIds(ctx context.Context, roleIds []uint64) (map[uint64][]uint64, error) {
+	result := make(map[uint64][]uint64, len(roleIds))
 	if len(roleIds) == 0 {
-		return false, errors.New("角色不能为空")
+		return result, nil
+	}
+
+	var roleApis []*entity.SysRoleApis
+	err := dao.SysRoleApis.Ctx(ctx).Fields(dao.SysRoleApis.Columns().RoleId, dao.SysRoleApis.Columns().ApiId).WhereIn(dao.SysRoleApis.Columns().RoleId, roleIds).Scan(&roleApis)
+	if err != nil {
+		return nil, err
 	}
 
-	// 检查角色是否有访问接口的权限
-	count, err := dao.SysRoleApis.Ctx(ctx).Where(dao.SysRoleApis.Columns().RoleId, roleIds).Where(dao.SysRoleApis.Columns().PermissionCode, permissionCode).Count()
+	for _, roleApi := range roleApis {
+		result[roleApi.RoleId] = append(result[roleApi.RoleId], roleApi.ApiId)
+	}
+
+	return result, nil
+}
+
+// HasChildren 是否有下级角色
+func (s *SysRole) HasChildren(ctx context.Context, id uint64) (bool, error) {
+	return dao.SysRoles.Ctx(ctx).Where(dao.SysRoles.Columns().ParentId, id).Exist()
+}
+
+// GetSuperIds 获取超级管理员角色ID
+func (s *SysRole) GetSuperIds(ctx context.Context) ([]uint64, error) {
+	values, err := dao.SysRoles.Ctx(ctx).Fields(dao.SysRoles.Columns().Id).Where(dao.SysRoles.Columns().IsSuper, true).Array()
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	ids := make([]uint64, 0, len(values))
+	for _, value := range values {
+		ids = append(ids, value.Uint64())
 	}
 
-	return count > 0, nil
+	return ids, nil
+}
+
+// GetUserRoles 获取用户启用的角色，禁用的角色不授予任何权限
+func (s *SysRole) GetUserRoles(ctx context.Context, userId uint64) ([]*entity.SysRoles, error) {
+	var roles []*entity.SysRoles
+	// 通过 sys_user_roles 表关联用户和角色，并获取完整的角色信息
+	err := dao.SysRoles.Ctx(ctx).Fields(fmt.Sprintf("%s.*", dao.SysRoles.Table())).
+		InnerJoin(dao.SysUserRoles.Table(), fmt.Sprintf("%s.%s = %s.%s", dao.SysRoles.Table(), dao.SysRoles.Columns().Id, dao.SysUserRoles.Table(), dao.SysUserRoles.Columns().RoleId)).
+		Where(fmt.Sprintf("%s.%s", dao.SysUserRoles.Table(), dao.SysUserRoles.Columns().UserId), userId).
+		Where(fmt.Sprintf("%s.%s", dao.SysRoles.Table(), dao.SysRoles.Columns().Status), true).
+		Scan(&roles)
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
 }
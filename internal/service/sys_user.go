@@ -9,6 +9,7 @@ import (
 	"gf-ant-react/internal/model/entity"
 
 	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/os/gtime"
 )
 
 type SysUser struct{}
@@ -105,13 +106,18 @@ func (s *SysUser) Update(ctx context.Context, data *admin.SysUserUpdateParam) er
 	if err != nil {
 		return err
 	}
+	SysPermissionCacheService.InvalidateUser(ctx, data.Id)
 
 	return nil
 }
 
 func (s *SysUser) Delete(ctx context.Context, id uint64) error {
 	_, err := dao.SysUsers.Ctx(ctx).Where(dao.SysUsers.Columns().Id, id).Delete()
-	return err
+	if err != nil {
+		return err
+	}
+	SysPermissionCacheService.InvalidateUser(ctx, id)
+	return nil
 }
 
 func (s *SysUser) GetList(ctx context.Context, param *admin.SysUserListParam) ([]*admin.SysUserListResultItem, int, error) {
@@ -127,6 +133,7 @@ func (s *SysUser) GetList(ctx context.Context, param *admin.SysUserListParam) ([
 	if param.Status != nil {
 		model = model.Where(dao.SysUsers.Columns().Status, *param.Status)
 	}
+	model = whereDataScope(ctx, model, param.Scope, dao.SysUsers.Columns().Id, dao.SysUsers.Columns().DepartmentId)
 
 	// 获取总数
 	total, err := model.Count()
@@ -135,7 +142,7 @@ func (s *SysUser) GetList(ctx context.Context, param *admin.SysUserListParam) ([
 	}
 
 	// 获取分页数据
-	err = model.FieldsEx(dao.SysUsers.Columns().PasswordHash).Page(param.Page, param.Size).Order("id DESC").ScanList(&users, "User")
+	err = model.FieldsEx(dao.SysUsers.Columns().PasswordHash, dao.SysUsers.Columns().TotpSecret).Page(param.Page, param.Size).Order("id DESC").ScanList(&users, "User")
 	if err != nil {
 		return nil, 0, err
 	}
@@ -152,7 +159,7 @@ func (s *SysUser) GetList(ctx context.Context, param *admin.SysUserListParam) ([
 
 func (s *SysUser) GetById(ctx context.Context, id uint64) (*entity.SysUsers, []uint64, error) {
 	var user *entity.SysUsers
-	err := dao.SysUsers.Ctx(ctx).FieldsEx(dao.SysUsers.Columns().PasswordHash).Where(dao.SysUsers.Columns().Id, id).Scan(&user)
+	err := dao.SysUsers.Ctx(ctx).FieldsEx(dao.SysUsers.Columns().PasswordHash, dao.SysUsers.Columns().TotpSecret).Where(dao.SysUsers.Columns().Id, id).Scan(&user)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -178,6 +185,7 @@ func (s *SysUser) UpdateColumns(ctx context.Context, id uint64, data interface{}
 	if _, err := dao.SysUsers.Ctx(ctx).FieldsEx(dao.SysUsers.Columns().Id).Where(dao.SysUsers.Columns().Id, id).Update(data); err != nil {
 		return err
 	}
+	SysPermissionCacheService.InvalidateUser(ctx, id)
 
 	return nil
 }
@@ -192,22 +200,183 @@ func (s *SysUser) GetByUsername(ctx context.Context, username string) (*entity.S
 	return user, nil
 }
 
-// 重置密码
-func (s *SysUser) ResetPassword(ctx context.Context, id uint64, data *admin.ResetPasswordReq) error {
-	_, err := dao.SysUsers.Ctx(ctx).FieldsEx(dao.SysUsers.Columns().Id).Where(dao.SysUsers.Columns().Id, id).Data(data).Update()
+// 修改密码，同时记录修改时间
+func (s *SysUser) UpdatePassword(ctx context.Context, id uint64, passwordHash string) error {
+	_, err := dao.SysUsers.Ctx(ctx).Where(dao.SysUsers.Columns().Id, id).Data(map[string]interface{}{
+		dao.SysUsers.Columns().PasswordHash:      passwordHash,
+		dao.SysUsers.Columns().PasswordChangedAt: gtime.Now(),
+	}).Update()
 	return err
 }
 
+// 更新密码哈希，用于按新算法重新计算哈希，不改变密码修改时间
+func (s *SysUser) UpdatePasswordHash(ctx context.Context, id uint64, passwordHash string) error {
+	_, err := dao.SysUsers.Ctx(ctx).Where(dao.SysUsers.Columns().Id, id).Data(dao.SysUsers.Columns().PasswordHash, passwordHash).Update()
+	return err
+}
+
+// 获取密码哈希
+func (s *SysUser) GetPasswordHash(ctx context.Context, id uint64) (string, error) {
+	hash, err := dao.SysUsers.Ctx(ctx).Where(dao.SysUsers.Columns().Id, id).Value(dao.SysUsers.Columns().PasswordHash)
+	if err != nil {
+		return "", err
+	}
+	return hash.String(), nil
+}
+
+// 根据认证方式获取用户列表
+func (s *SysUser) GetByAuthSource(ctx context.Context, authSource string) ([]*entity.SysUsers, error) {
+	var users []*entity.SysUsers
+	err := dao.SysUsers.Ctx(ctx).FieldsEx(dao.SysUsers.Columns().PasswordHash, dao.SysUsers.Columns().TotpSecret).Where(dao.SysUsers.Columns().AuthSource, authSource).Scan(&users)
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// 根据邮箱获取用户信息
+func (s *SysUser) GetByEmail(ctx context.Context, email string) (*entity.SysUsers, error) {
+	var user *entity.SysUsers
+	err := dao.SysUsers.Ctx(ctx).Where(dao.SysUsers.Columns().Email, email).Scan(&user)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// 设置用户角色，替换原有角色
+func (s *SysUser) UpdateRoles(ctx context.Context, id uint64, roleIds []uint64) error {
+	defer SysPermissionCacheService.InvalidateUser(ctx, id)
+	return dao.SysUserRoles.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+
+		_, err := dao.SysUserRoles.Ctx(ctx).Where(dao.SysUserRoles.Columns().UserId, id).Delete()
+		if err != nil {
+			return err
+		}
+
+		if len(roleIds) == 0 {
+			return nil
+		}
+
+		var userRoles []*entity.SysUserRoles
+		for _, roleId := range roleIds {
+			userRoles = append(userRoles, &entity.SysUserRoles{
+				UserId: id,
+				RoleId: roleId,
+			})
+		}
+
+		_, err = dao.SysUserRoles.Ctx(ctx).FieldsEx(dao.SysUserRoles.Columns().CreatedAt).Save(userRoles)
+		return err
+	})
+}
+
 // 根据id检查用户是否存在
 func (s *SysUser) CheckById(ctx context.Context, id uint64) (bool, error) {
 	return dao.SysUsers.Ctx(ctx).Where(dao.SysUsers.Columns().Id, id).Exist()
 }
 
-// 更新登录时间和登录IP
+// 检查用户是否存在并且在数据权限范围内
+func (s *SysUser) CheckInScope(ctx context.Context, id uint64, scope *admin.DataScope) (bool, error) {
+	model := dao.SysUsers.Ctx(ctx).Where(dao.SysUsers.Columns().Id, id)
+	return whereDataScope(ctx, model, scope, dao.SysUsers.Columns().Id, dao.SysUsers.Columns().DepartmentId).Exist()
+}
+
+// CountSuperUsers 统计拥有超级管理员角色的正常用户数，不包括 excludeId
+func (s *SysUser) CountSuperUsers(ctx context.Context, excludeId uint64) (int, error) {
+	superRoles := dao.SysRoles.Ctx(ctx).Fields(dao.SysRoles.Columns().Id).Where(dao.SysRoles.Columns().IsSuper, true).Where(dao.SysRoles.Columns().Status, true)
+	userIds := dao.SysUserRoles.Ctx(ctx).Fields(dao.SysUserRoles.Columns().UserId).WhereIn(dao.SysUserRoles.Columns().RoleId, superRoles)
+
+	return dao.SysUsers.Ctx(ctx).
+		WhereIn(dao.SysUsers.Columns().Id, userIds).
+		WhereNot(dao.SysUsers.Columns().Id, excludeId).
+		Where(dao.SysUsers.Columns().Status, admin.UserStatusEnabled).
+		Count()
+}
+
+// 更新登录时间和登录IP，同时清除登录失败记录
 func (s *SysUser) UpdateLoginInfo(ctx context.Context, id uint64, ip string) error {
 	_, err := dao.SysUsers.Ctx(ctx).FieldsEx(dao.SysUsers.Columns().Id).Where(dao.SysUsers.Columns().Id, id).Data(map[string]interface{}{
-		dao.SysUsers.Columns().LastLoginIp: ip,
-		dao.SysUsers.Columns().LastLoginAt: time.Now(),
+		dao.SysUsers.Columns().LastLoginIp:   ip,
+		dao.SysUsers.Columns().LastLoginAt:   time.Now(),
+		dao.SysUsers.Columns().LoginAttempts: 0,
+		dao.SysUsers.Columns().LockedUntil:   nil,
+	}).Update()
+	if err != nil {
+		return err
+	}
+
+	// 锁定到期的账号恢复正常
+	_, err = dao.SysUsers.Ctx(ctx).Where(dao.SysUsers.Columns().Id, id).Where(dao.SysUsers.Columns().Status, admin.UserStatusLocked).Data(dao.SysUsers.Columns().Status, admin.UserStatusEnabled).Update()
+	SysPermissionCacheService.InvalidateUser(ctx, id)
+	return err
+}
+
+// 登录失败次数加一，返回累计失败次数
+func (s *SysUser) IncreaseLoginAttempts(ctx context.Context, id uint64) (uint, error) {
+	if _, err := dao.SysUsers.Ctx(ctx).Where(dao.SysUsers.Columns().Id, id).Increment(dao.SysUsers.Columns().LoginAttempts, 1); err != nil {
+		return 0, err
+	}
+
+	attempts, err := dao.SysUsers.Ctx(ctx).Where(dao.SysUsers.Columns().Id, id).Value(dao.SysUsers.Columns().LoginAttempts)
+	if err != nil {
+		return 0, err
+	}
+
+	return attempts.Uint(), nil
+}
+
+// 锁定用户到指定时间
+func (s *SysUser) Lock(ctx context.Context, id uint64, until *gtime.Time) error {
+	_, err := dao.SysUsers.Ctx(ctx).Where(dao.SysUsers.Columns().Id, id).Data(map[string]interface{}{
+		dao.SysUsers.Columns().Status:      admin.UserStatusLocked,
+		dao.SysUsers.Columns().LockedUntil: until,
+	}).Update()
+	if err != nil {
+		return err
+	}
+	SysPermissionCacheService.InvalidateUser(ctx, id)
+	return nil
+}
+
+// 更新用户状态
+func (s *SysUser) UpdateStatus(ctx context.Context, id uint64, status int) error {
+	_, err := dao.SysUsers.Ctx(ctx).Where(dao.SysUsers.Columns().Id, id).Data(dao.SysUsers.Columns().Status, status).Update()
+	if err != nil {
+		return err
+	}
+	SysPermissionCacheService.InvalidateUser(ctx, id)
+	return nil
+}
+
+// 解除锁定
+func (s *SysUser) Unlock(ctx context.Context, id uint64) error {
+	_, err := dao.SysUsers.Ctx(ctx).Where(dao.SysUsers.Columns().Id, id).Data(map[string]interface{}{
+		dao.SysUsers.Columns().Status:        admin.UserStatusEnabled,
+		dao.SysUsers.Columns().LoginAttempts: 0,
+		dao.SysUsers.Columns().LockedUntil:   nil,
+	}).Update()
+	if err != nil {
+		return err
+	}
+	SysPermissionCacheService.InvalidateUser(ctx, id)
+	return nil
+}
+
+// 获取两步验证密钥
+func (s *SysUser) GetTotpSecret(ctx context.Context, id uint64) (string, error) {
+	secret, err := dao.SysUsers.Ctx(ctx).Where(dao.SysUsers.Columns().Id, id).Value(dao.SysUsers.Columns().TotpSecret)
+	if err != nil {
+		return "", err
+	}
+	return secret.String(), nil
+}
+
+// 更新两步验证密钥和开启状态
+func (s *SysUser) UpdateTotp(ctx context.Context, id uint64, secret string, enabled bool) error {
+	_, err := dao.SysUsers.Ctx(ctx).Where(dao.SysUsers.Columns().Id, id).Data(map[string]interface{}{
+		dao.SysUsers.Columns().TotpSecret:  secret,
+		dao.SysUsers.Columns().TotpEnabled: enabled,
 	}).Update()
 	return err
 }
@@ -215,7 +384,7 @@ func (s *SysUser) UpdateLoginInfo(ctx context.Context, id uint64, ip string) err
 // 个人中心
 func (s *SysUser) Profile(ctx context.Context, id uint64) (*entity.SysUsers, error) {
 	var user *entity.SysUsers
-	err := dao.SysUsers.Ctx(ctx).FieldsEx(dao.SysUsers.Columns().PasswordHash).Where(dao.SysUsers.Columns().Id, id).Scan(&user)
+	err := dao.SysUsers.Ctx(ctx).FieldsEx(dao.SysUsers.Columns().PasswordHash, dao.SysUsers.Columns().TotpSecret).Where(dao.SysUsers.Columns().Id, id).Scan(&user)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"gf-ant-react/internal/dao"
+	"gf-ant-react/internal/model/entity"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type SysUserIdentity struct{}
+
+var SysUserIdentityService = &SysUserIdentity{}
+
+// Create 关联外部身份
+func (s *SysUserIdentity) Create(ctx context.Context, data *entity.SysUserIdentities) error {
+	_, err := dao.SysUserIdentities.Ctx(ctx).FieldsEx(dao.SysUserIdentities.Columns().Id).Insert(data)
+	return err
+}
+
+// GetBySubject 根据身份提供方和唯一标识获取外部身份
+func (s *SysUserIdentity) GetBySubject(ctx context.Context, provider string, subject string) (*entity.SysUserIdentities, error) {
+	var identity *entity.SysUserIdentities
+	err := dao.SysUserIdentities.Ctx(ctx).
+		Where(dao.SysUserIdentities.Columns().Provider, provider).
+		Where(dao.SysUserIdentities.Columns().Subject, subject).
+		Scan(&identity)
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+// UpdateLogin 记录登录时间和最新的邮箱
+func (s *SysUserIdentity) UpdateLogin(ctx context.Context, id uint64, email string) error {
+	_, err := dao.SysUserIdentities.Ctx(ctx).Where(dao.SysUserIdentities.Columns().Id, id).Data(map[string]interface{}{
+		dao.SysUserIdentities.Columns().Email:       email,
+		dao.SysUserIdentities.Columns().LastLoginAt: gtime.Now(),
+	}).Update()
+	return err
+}
+
+// Delete 删除外部身份
+func (s *SysUserIdentity) Delete(ctx context.Context, id uint64) error {
+	_, err := dao.SysUserIdentities.Ctx(ctx).Where(dao.SysUserIdentities.Columns().Id, id).Delete()
+	return err
+}
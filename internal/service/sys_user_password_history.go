@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+
+	"gf-ant-react/internal/dao"
+	"gf-ant-react/internal/model/entity"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type SysUserPasswordHistory struct{}
+
+var SysUserPasswordHistoryService = &SysUserPasswordHistory{}
+
+// Create 记录密码，只保留最近 keep 条
+func (s *SysUserPasswordHistory) Create(ctx context.Context, userId uint64, passwordHash string, keep int) error {
+	_, err := dao.SysUserPasswordHistories.Ctx(ctx).FieldsEx(dao.SysUserPasswordHistories.Columns().Id).Insert(&entity.SysUserPasswordHistories{
+		UserId:       userId,
+		PasswordHash: passwordHash,
+		CreatedAt:    gtime.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// 删除超出保留数量的旧记录
+	ids, err := dao.SysUserPasswordHistories.Ctx(ctx).
+		Where(dao.SysUserPasswordHistories.Columns().UserId, userId).
+		OrderDesc(dao.SysUserPasswordHistories.Columns().Id).
+		Offset(keep).Limit(1000).
+		Array(dao.SysUserPasswordHistories.Columns().Id)
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	_, err = dao.SysUserPasswordHistories.Ctx(ctx).WhereIn(dao.SysUserPasswordHistories.Columns().Id, ids).Delete()
+	return err
+}
+
+// GetRecent 获取最近使用过的 limit 个密码哈希
+func (s *SysUserPasswordHistory) GetRecent(ctx context.Context, userId uint64, limit int) ([]string, error) {
+	hashes, err := dao.SysUserPasswordHistories.Ctx(ctx).
+		Where(dao.SysUserPasswordHistories.Columns().UserId, userId).
+		OrderDesc(dao.SysUserPasswordHistories.Columns().Id).
+		Limit(limit).
+		Array(dao.SysUserPasswordHistories.Columns().PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, hash := range hashes {
+		result = append(result, hash.String())
+	}
+
+	return result, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+
+	"gf-ant-react/internal/dao"
+	"gf-ant-react/internal/model/entity"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type SysUserRecoveryCode struct{}
+
+var SysUserRecoveryCodeService = &SysUserRecoveryCode{}
+
+// Replace 替换用户的恢复码，旧的恢复码全部失效
+func (s *SysUserRecoveryCode) Replace(ctx context.Context, userId uint64, hashes []string) error {
+	return dao.SysUserRecoveryCodes.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+
+		_, err := tx.Model(dao.SysUserRecoveryCodes.Table()).Ctx(ctx).Where(dao.SysUserRecoveryCodes.Columns().UserId, userId).Delete()
+		if err != nil {
+			return err
+		}
+
+		if len(hashes) == 0 {
+			return nil
+		}
+
+		var codes []*entity.SysUserRecoveryCodes
+		for _, hash := range hashes {
+			codes = append(codes, &entity.SysUserRecoveryCodes{
+				UserId:    userId,
+				CodeHash:  hash,
+				CreatedAt: gtime.Now(),
+			})
+		}
+
+		_, err = tx.Model(dao.SysUserRecoveryCodes.Table()).Ctx(ctx).FieldsEx(dao.SysUserRecoveryCodes.Columns().Id).Insert(codes)
+		return err
+	})
+}
+
+// Use 使用恢复码，每个恢复码只能使用一次
+// 返回 false 表示恢复码不存在或已使用
+func (s *SysUserRecoveryCode) Use(ctx context.Context, userId uint64, hash string) (bool, error) {
+	result, err := dao.SysUserRecoveryCodes.Ctx(ctx).
+		Where(dao.SysUserRecoveryCodes.Columns().UserId, userId).
+		Where(dao.SysUserRecoveryCodes.Columns().CodeHash, hash).
+		WhereNull(dao.SysUserRecoveryCodes.Columns().UsedAt).
+		Data(dao.SysUserRecoveryCodes.Columns().UsedAt, gtime.Now()).
+		Update()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// CountUnused 统计未使用的恢复码数量
+func (s *SysUserRecoveryCode) CountUnused(ctx context.Context, userId uint64) (int, error) {
+	return dao.SysUserRecoveryCodes.Ctx(ctx).
+		Where(dao.SysUserRecoveryCodes.Columns().UserId, userId).
+		WhereNull(dao.SysUserRecoveryCodes.Columns().UsedAt).
+		Count()
+}
+
+// DeleteByUserId 删除用户的所有恢复码
+func (s *SysUserRecoveryCode) DeleteByUserId(ctx context.Context, userId uint64) error {
+	_, err := dao.SysUserRecoveryCodes.Ctx(ctx).Where(dao.SysUserRecoveryCodes.Columns().UserId, userId).Delete()
+	return err
+}
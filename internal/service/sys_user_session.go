@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+
+	"gf-ant-react/internal/dao"
+	"gf-ant-react/internal/model/admin"
+	"gf-ant-react/internal/model/entity"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type SysUserSession struct{}
+
+var SysUserSessionService = &SysUserSession{}
+
+// Create 保存登录会话
+func (s *SysUserSession) Create(ctx context.Context, data *entity.SysUserSessions) error {
+	_, err := dao.SysUserSessions.Ctx(ctx).FieldsEx(dao.SysUserSessions.Columns().Id).Insert(data)
+	return err
+}
+
+// GetBySessionId 根据会话ID获取会话
+func (s *SysUserSession) GetBySessionId(ctx context.Context, sessionId string) (*entity.SysUserSessions, error) {
+	var session *entity.SysUserSessions
+	err := dao.SysUserSessions.Ctx(ctx).Where(dao.SysUserSessions.Columns().SessionId, sessionId).Scan(&session)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetList 会话列表，只返回未结束且未过期的会话
+func (s *SysUserSession) GetList(ctx context.Context, param *admin.SysUserSessionListParam) ([]*admin.SysUserSessionItem, int, error) {
+	var list []*admin.SysUserSessionItem
+
+	model := dao.SysUserSessions.Ctx(ctx).As("s").
+		LeftJoin(dao.SysUsers.Table(), "u", "u.id = s.user_id").
+		WhereNull("s."+dao.SysUserSessions.Columns().RevokedAt).
+		WhereGT("s."+dao.SysUserSessions.Columns().ExpiresAt, gtime.Now())
+
+	if param.UserId > 0 {
+		model = model.Where("s."+dao.SysUserSessions.Columns().UserId, param.UserId)
+	}
+	if param.Username != "" {
+		model = model.WhereLike("u."+dao.SysUsers.Columns().Username, "%"+param.Username+"%")
+	}
+	model = whereDataScope(ctx, model, param.Scope, "s."+dao.SysUserSessions.Columns().UserId, "")
+
+	total, err := model.Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if param.Size > 0 {
+		model = model.Page(param.Page, param.Size)
+	}
+
+	err = model.Fields("s.*, u.username").OrderDesc("s." + dao.SysUserSessions.Columns().LastSeenAt).Scan(&list)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+// Touch 更新最后访问时间和IP
+func (s *SysUserSession) Touch(ctx context.Context, sessionId string, ip string) error {
+	_, err := dao.SysUserSessions.Ctx(ctx).Where(dao.SysUserSessions.Columns().SessionId, sessionId).Data(map[string]interface{}{
+		dao.SysUserSessions.Columns().LastSeenAt: gtime.Now(),
+		dao.SysUserSessions.Columns().LastSeenIp: ip,
+	}).Update()
+	return err
+}
+
+// Extend 刷新令牌时延长会话过期时间
+func (s *SysUserSession) Extend(ctx context.Context, sessionId string, expiresAt *gtime.Time) error {
+	_, err := dao.SysUserSessions.Ctx(ctx).Where(dao.SysUserSessions.Columns().SessionId, sessionId).Data(map[string]interface{}{
+		dao.SysUserSessions.Columns().ExpiresAt:  expiresAt,
+		dao.SysUserSessions.Columns().LastSeenAt: gtime.Now(),
+	}).Update()
+	return err
+}
+
+// Revoke 结束会话
+func (s *SysUserSession) Revoke(ctx context.Context, sessionId string) error {
+	_, err := dao.SysUserSessions.Ctx(ctx).
+		Where(dao.SysUserSessions.Columns().SessionId, sessionId).
+		WhereNull(dao.SysUserSessions.Columns().RevokedAt).
+		Data(dao.SysUserSessions.Columns().RevokedAt, gtime.Now()).
+		Update()
+	return err
+}
+
+// RevokeByUserId 结束用户的所有会话
+func (s *SysUserSession) RevokeByUserId(ctx context.Context, userId uint64) error {
+	_, err := dao.SysUserSessions.Ctx(ctx).
+		Where(dao.SysUserSessions.Columns().UserId, userId).
+		WhereNull(dao.SysUserSessions.Columns().RevokedAt).
+		Data(dao.SysUserSessions.Columns().RevokedAt, gtime.Now()).
+		Update()
+	return err
+}
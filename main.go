@@ -4,6 +4,7 @@ import (
 	_ "gf-ant-react/internal/packed"
 
 	_ "github.com/gogf/gf/contrib/drivers/mysql/v2"
+	_ "github.com/gogf/gf/contrib/nosql/redis/v2"
 
 	"github.com/gogf/gf/v2/os/gctx"
 
@@ -11,5 +12,13 @@ import (
 )
 
 func main() {
+	// 同步路由到接口表
+	if err := cmd.Main.AddCommand(&cmd.SyncApis); err != nil {
+		panic(err)
+	}
+	// 开发用子命令，使用 -tags dev 编译时才包含
+	if err := cmd.Main.AddCommand(cmd.DevCommands...); err != nil {
+		panic(err)
+	}
 	cmd.Main.Run(gctx.GetInitCtx())
 }
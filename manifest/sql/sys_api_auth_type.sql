@@ -0,0 +1,4 @@
+-- 接口的鉴权方式，运行时以数据库为准，修改后无需重启
+-- 为空的接口在服务启动和同步路由时按 auth.yaml 中的 ignoreRoutes 和 publicRoutes 初始化
+ALTER TABLE `sys_apis`
+  ADD COLUMN `auth_type` tinyint NULL DEFAULT NULL COMMENT '鉴权方式：0=需要权限，1=只需要登录，2=不需要鉴权，为空时按 auth.yaml 初始化' AFTER `is_menu`;
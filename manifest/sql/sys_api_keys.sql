@@ -0,0 +1,17 @@
+-- API Key：供 CI、导入脚本等无人值守的客户端使用，密钥只保存哈希
+CREATE TABLE IF NOT EXISTS `sys_api_keys` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+  `user_id` bigint unsigned NOT NULL COMMENT '所属用户ID，以该用户的权限访问接口',
+  `name` varchar(100) NOT NULL COMMENT '名称',
+  `prefix` varchar(16) NOT NULL COMMENT '密钥前缀，用于识别',
+  `key_hash` char(64) NOT NULL COMMENT '密钥SHA256哈希',
+  `scopes` varchar(2000) NOT NULL DEFAULT '' COMMENT '允许的权限码，逗号分隔，为空时不限制',
+  `expires_at` datetime DEFAULT NULL COMMENT '过期时间 (NULL=永不过期)',
+  `last_used_at` datetime DEFAULT NULL COMMENT '最后使用时间',
+  `last_used_ip` varchar(45) NOT NULL DEFAULT '' COMMENT '最后使用IP',
+  `revoked_at` datetime DEFAULT NULL COMMENT '吊销时间 (NULL=未吊销)',
+  `created_at` datetime DEFAULT NULL COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_key_hash` (`key_hash`),
+  KEY `idx_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='API Key';
@@ -0,0 +1,5 @@
+-- 菜单元数据：是否为菜单的接口节点同时保存前端菜单的图标、路由和组件，菜单结构完全由接口树维护
+ALTER TABLE `sys_apis`
+  ADD COLUMN `icon` varchar(50) NOT NULL DEFAULT '' COMMENT '菜单图标' AFTER `is_menu`,
+  ADD COLUMN `route` varchar(200) NOT NULL DEFAULT '' COMMENT '菜单前端路由' AFTER `icon`,
+  ADD COLUMN `component` varchar(200) NOT NULL DEFAULT '' COMMENT '菜单前端组件' AFTER `route`;
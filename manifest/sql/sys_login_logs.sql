@@ -0,0 +1,19 @@
+-- 登录日志表：记录每一次登录尝试，包括失败原因，按 auth.yaml 中 loginLog.retentionDays 定期清理
+CREATE TABLE IF NOT EXISTS `sys_login_logs` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+  `user_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT '用户ID (0=用户不存在)',
+  `username` varchar(50) NOT NULL DEFAULT '' COMMENT '登录用户名',
+  `method` varchar(20) NOT NULL DEFAULT '' COMMENT '登录方式: password=密码, mfa=两步验证, oidc=单点登录, impersonate=代登录',
+  `status` tinyint NOT NULL DEFAULT 0 COMMENT '登录结果: 0=失败, 1=成功, 2=待两步验证',
+  `reason` varchar(30) NOT NULL DEFAULT '' COMMENT '结果原因',
+  `message` varchar(255) NOT NULL DEFAULT '' COMMENT '错误信息',
+  `ip` varchar(45) NOT NULL DEFAULT '' COMMENT '登录IP',
+  `device` varchar(100) NOT NULL DEFAULT '' COMMENT '设备',
+  `user_agent` varchar(500) NOT NULL DEFAULT '' COMMENT 'User-Agent',
+  `created_at` datetime NOT NULL COMMENT '登录时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_user_id_created_at` (`user_id`, `created_at`),
+  KEY `idx_username` (`username`),
+  KEY `idx_ip` (`ip`),
+  KEY `idx_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='登录日志';
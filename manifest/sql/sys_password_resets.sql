@@ -0,0 +1,13 @@
+-- 找回密码令牌表：邮件中的重置链接只保存哈希，只能使用一次
+CREATE TABLE IF NOT EXISTS `sys_password_resets` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+  `user_id` bigint unsigned NOT NULL COMMENT '用户ID',
+  `token_hash` char(64) NOT NULL COMMENT '重置令牌哈希 (SHA-256)',
+  `ip` varchar(45) NOT NULL DEFAULT '' COMMENT '申请IP',
+  `expires_at` datetime NOT NULL COMMENT '过期时间',
+  `used_at` datetime DEFAULT NULL COMMENT '使用时间 (NULL=未使用)',
+  `created_at` datetime NOT NULL COMMENT '申请时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_token_hash` (`token_hash`),
+  KEY `idx_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='找回密码令牌';
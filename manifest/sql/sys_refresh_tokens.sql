@@ -0,0 +1,15 @@
+-- 刷新令牌表：每次刷新都会签发新的刷新令牌，旧令牌被标记为已使用
+CREATE TABLE IF NOT EXISTS `sys_refresh_tokens` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+  `user_id` bigint unsigned NOT NULL COMMENT '用户ID',
+  `family_id` varchar(64) NOT NULL COMMENT '令牌族ID，同一次登录轮换出的刷新令牌共享',
+  `token_hash` char(64) NOT NULL COMMENT '刷新令牌SHA256哈希',
+  `expires_at` datetime NOT NULL COMMENT '过期时间',
+  `used_at` datetime DEFAULT NULL COMMENT '使用时间 (NULL=未使用)',
+  `revoked_at` datetime DEFAULT NULL COMMENT '吊销时间 (NULL=未吊销)',
+  `created_at` datetime DEFAULT NULL COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_token_hash` (`token_hash`),
+  KEY `idx_family_id` (`family_id`),
+  KEY `idx_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='刷新令牌';
@@ -0,0 +1,10 @@
+-- 角色部门关联表：数据权限范围为自定义 (data_scope=5) 的角色可以访问的部门
+CREATE TABLE IF NOT EXISTS `sys_role_departments` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+  `role_id` bigint unsigned NOT NULL COMMENT '角色ID',
+  `department_id` bigint unsigned NOT NULL COMMENT '部门ID',
+  `created_at` datetime DEFAULT NULL COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_role_department` (`role_id`, `department_id`),
+  KEY `idx_department_id` (`department_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='角色部门关联';
@@ -0,0 +1,4 @@
+-- 角色继承：角色拥有上级角色链上所有启用角色的接口权限
+ALTER TABLE `sys_roles`
+  ADD COLUMN `parent_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT '上级角色ID，0表示没有上级，继承上级角色的接口权限' AFTER `id`,
+  ADD KEY `idx_parent_id` (`parent_id`);
@@ -0,0 +1,16 @@
+-- 超级管理员角色：拥有所有接口权限，不需要逐个分配，不能删除、禁用或修改数据权限范围
+ALTER TABLE `sys_roles`
+  ADD COLUMN `is_super` tinyint(1) NOT NULL DEFAULT 0 COMMENT '超级管理员: 0=否, 1=是，拥有所有接口权限，不能删除或禁用' AFTER `require_mfa`;
+
+INSERT INTO `sys_roles` (`name`, `description`, `data_scope`, `sort`, `status`, `require_mfa`, `is_super`, `created_at`, `updated_at`)
+SELECT '超级管理员', '内置角色，拥有所有接口权限', 1, 9999, 1, 0, 1, NOW(), NOW()
+FROM DUAL
+WHERE NOT EXISTS (SELECT 1 FROM `sys_roles` WHERE `is_super` = 1);
+
+-- 初始管理员账号 admin 分配超级管理员角色
+INSERT INTO `sys_user_roles` (`user_id`, `role_id`)
+SELECT u.`id`, r.`id`
+FROM `sys_users` u
+INNER JOIN `sys_roles` r ON r.`is_super` = 1 AND r.`deleted_at` IS NULL
+WHERE u.`username` = 'admin' AND u.`deleted_at` IS NULL
+  AND NOT EXISTS (SELECT 1 FROM `sys_user_roles` ur WHERE ur.`user_id` = u.`id` AND ur.`role_id` = r.`id`);
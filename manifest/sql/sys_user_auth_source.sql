@@ -0,0 +1,3 @@
+-- 用户认证方式：本地密码或 LDAP，本地账号与 LDAP 账号可以同时存在
+ALTER TABLE `sys_users`
+  ADD COLUMN `auth_source` varchar(20) NOT NULL DEFAULT 'local' COMMENT '认证方式: local=本地密码, ldap=LDAP' AFTER `password_changed_at`;
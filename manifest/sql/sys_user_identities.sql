@@ -0,0 +1,13 @@
+-- 外部身份表：单点登录等外部身份提供方的账号与本地用户的关联
+CREATE TABLE IF NOT EXISTS `sys_user_identities` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+  `user_id` bigint unsigned NOT NULL COMMENT '用户ID',
+  `provider` varchar(50) NOT NULL COMMENT '身份提供方，如：oidc',
+  `subject` varchar(255) NOT NULL COMMENT '身份提供方中的唯一标识 (sub)',
+  `email` varchar(255) NOT NULL DEFAULT '' COMMENT '身份提供方中的邮箱',
+  `last_login_at` datetime DEFAULT NULL COMMENT '最后登录时间',
+  `created_at` datetime DEFAULT NULL COMMENT '关联时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_provider_subject` (`provider`, `subject`),
+  KEY `idx_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='外部身份';
@@ -0,0 +1,18 @@
+-- 两步验证 (TOTP)
+ALTER TABLE `sys_users`
+  ADD COLUMN `totp_secret` varchar(64) NOT NULL DEFAULT '' COMMENT '两步验证TOTP密钥' AFTER `locked_until`,
+  ADD COLUMN `totp_enabled` tinyint(1) NOT NULL DEFAULT 0 COMMENT '两步验证: 0=未开启, 1=已开启' AFTER `totp_secret`;
+
+ALTER TABLE `sys_roles`
+  ADD COLUMN `require_mfa` tinyint(1) NOT NULL DEFAULT 0 COMMENT '是否要求两步验证: 0=否, 1=是' AFTER `status`;
+
+-- 两步验证恢复码，只保存哈希
+CREATE TABLE IF NOT EXISTS `sys_user_recovery_codes` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+  `user_id` bigint unsigned NOT NULL COMMENT '用户ID',
+  `code_hash` char(64) NOT NULL COMMENT '恢复码SHA256哈希',
+  `used_at` datetime DEFAULT NULL COMMENT '使用时间 (NULL=未使用)',
+  `created_at` datetime DEFAULT NULL COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='两步验证恢复码';
@@ -0,0 +1,15 @@
+-- 密码策略：记录密码修改时间用于判断过期，保存历史密码哈希防止重复使用
+ALTER TABLE `sys_users`
+  ADD COLUMN `password_changed_at` datetime DEFAULT NULL COMMENT '密码修改时间' AFTER `password_hash`;
+
+-- 已有用户从上线时开始计算密码有效期
+UPDATE `sys_users` SET `password_changed_at` = NOW() WHERE `password_changed_at` IS NULL;
+
+CREATE TABLE IF NOT EXISTS `sys_user_password_histories` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+  `user_id` bigint unsigned NOT NULL COMMENT '用户ID',
+  `password_hash` varchar(255) NOT NULL COMMENT '密码哈希',
+  `created_at` datetime DEFAULT NULL COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='历史密码';
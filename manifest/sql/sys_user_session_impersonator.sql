@@ -0,0 +1,3 @@
+-- 登录会话增加代登录的操作人，代登录会话在用户的会话列表中可以看到
+ALTER TABLE `sys_user_sessions`
+  ADD COLUMN `impersonator_id` bigint unsigned NOT NULL DEFAULT 0 COMMENT '代登录的操作人ID (0=本人登录)' AFTER `user_id`;
@@ -0,0 +1,17 @@
+-- 登录会话表：每次登录一条记录，会话ID与令牌中的 sid 及刷新令牌族ID一致
+CREATE TABLE IF NOT EXISTS `sys_user_sessions` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+  `session_id` varchar(64) NOT NULL COMMENT '会话ID',
+  `user_id` bigint unsigned NOT NULL COMMENT '用户ID',
+  `device` varchar(100) NOT NULL DEFAULT '' COMMENT '设备',
+  `ip` varchar(45) NOT NULL DEFAULT '' COMMENT '登录IP',
+  `user_agent` varchar(500) NOT NULL DEFAULT '' COMMENT 'User-Agent',
+  `last_seen_ip` varchar(45) NOT NULL DEFAULT '' COMMENT '最后访问IP',
+  `last_seen_at` datetime DEFAULT NULL COMMENT '最后访问时间',
+  `expires_at` datetime NOT NULL COMMENT '过期时间，随刷新令牌延长',
+  `revoked_at` datetime DEFAULT NULL COMMENT '结束时间 (NULL=未结束)',
+  `created_at` datetime DEFAULT NULL COMMENT '登录时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_session_id` (`session_id`),
+  KEY `idx_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='登录会话';
@@ -0,0 +1,35 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// Prefix API Key 固定前缀，便于在日志和代码仓库中识别泄露的密钥
+const Prefix = "gak_"
+
+// 展示用前缀的长度，包含固定前缀
+const displayLength = 12
+
+// Generate 生成 API Key
+// 返回: (完整密钥，只显示一次, 展示用前缀, 密钥哈希)
+func Generate() (key string, prefix string, hash string, err error) {
+
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", "", err
+	}
+
+	key = Prefix + base64.RawURLEncoding.EncodeToString(buf)
+
+	return key, key[:displayLength], Hash(key), nil
+}
+
+// Hash 计算 API Key 的哈希
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(key)))
+	return hex.EncodeToString(sum[:])
+}
@@ -3,6 +3,8 @@ package auth
 import (
 	"context"
 
+	"gf-ant-react/utility/jwt"
+
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
 )
@@ -14,3 +16,13 @@ func GetUserId(ctx context.Context) uint64 {
 
 	return r.GetCtxVar(g.Cfg("auth").MustGet(r.Context(), "CtxUserKey").String()).Uint64()
 }
+
+// 获取当前请求的令牌声明
+func GetClaims(ctx context.Context) *jwt.Claims {
+
+	r := ghttp.RequestFromCtx(ctx)
+
+	claims, _ := r.GetCtxVar(g.Cfg("auth").MustGet(r.Context(), "CtxClaimsKey").String()).Val().(*jwt.Claims)
+
+	return claims
+}
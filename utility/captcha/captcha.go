@@ -1,27 +1,73 @@
 package captcha
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/gogf/gf/v2/container/gvar"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcache"
 	"github.com/mojocn/base64Captcha"
 )
 
-var CaptchaUtility = &Captcha{}
+// 验证码类型
+const (
+	TypeDigit  = "digit"  // 数字
+	TypeMath   = "math"   // 算术
+	TypeString = "string" // 字符
+	TypeAudio  = "audio"  // 语音
+)
+
+// 验证码存储类型
+const (
+	StoreMemory = "memory" // 进程内存储
+	StoreRedis  = "redis"  // Redis，多副本部署时使用
+)
+
+var CaptchaUtility = newCaptcha(context.Background())
+
+// Store 验证码存储，实现该接口即可替换默认存储
+type Store = base64Captcha.Store
 
 type Captcha struct {
+	store Store
+	// 登录失败标记，开启 onlyAfterFailure 时使用
+	failures *gcache.Cache
+}
+
+func newCaptcha(ctx context.Context) *Captcha {
+
+	cache := gcache.New()
+
+	// 根据配置选择存储
+	if g.Cfg("captcha").MustGet(ctx, "store", StoreMemory).String() == StoreRedis {
+		cache.SetAdapter(gcache.NewAdapterRedis(g.Redis(g.Cfg("captcha").MustGet(ctx, "redis", "default").String())))
+	}
+
+	return &Captcha{
+		store:    newCacheStore(cache, time.Duration(g.Cfg("captcha").MustGet(ctx, "expire", 300).Int64())*time.Second),
+		failures: cache,
+	}
+}
+
+// SetStore 替换验证码存储
+func (c *Captcha) SetStore(store Store) {
+	c.store = store
 }
 
 // GenerateCaptchaHandler 生成验证码
-// 返回: (验证码ID, 验证码图片base64, 错误)
-func (c *Captcha) GenerateCaptchaHandler(width, height, length int) (string, string, error) {
-	// 使用数字验证码驱动，v1.3.8版本的正确参数格式
-	driver := base64Captcha.NewDriverDigit(height, width, length, 0.5, 10)
+// 返回: (验证码ID, 验证码图片或语音base64, 错误)
+func (c *Captcha) GenerateCaptchaHandler(captchaType string, width, height, length int) (string, string, error) {
 
-	// 创建存储
-	store := base64Captcha.DefaultMemStore
+	driver, err := c.driver(captchaType, width, height, length)
+	if err != nil {
+		return "", "", err
+	}
 
 	// 创建验证码实例
-	captcha := base64Captcha.NewCaptcha(driver, store)
+	captcha := base64Captcha.NewCaptcha(driver, c.store)
 
 	// 生成验证码 - v1.3.8版本返回4个值
 	id, b64s, _, err := captcha.Generate()
@@ -39,5 +85,109 @@ func (c *Captcha) VerifyCaptchaHandler(id, answer string) bool {
 	if id == "" || answer == "" {
 		return false
 	}
-	return base64Captcha.DefaultMemStore.Verify(id, answer, true)
+	return c.store.Verify(id, answer, true)
+}
+
+// RecordFailure 记录登录失败，标记在 failureExpire 内有效
+func (c *Captcha) RecordFailure(ctx context.Context, keys ...string) error {
+	expire := time.Duration(g.Cfg("captcha").MustGet(ctx, "failureExpire", 3600).Int64()) * time.Second
+	for _, key := range keys {
+		if err := c.failures.Set(ctx, c.failureKey(key), 1, expire); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClearFailure 清除登录失败标记
+func (c *Captcha) ClearFailure(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		if _, err := c.failures.Remove(ctx, c.failureKey(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsRequired 是否需要验证码
+// 未开启 onlyAfterFailure 时总是需要，开启后只有存在登录失败标记时需要
+func (c *Captcha) IsRequired(ctx context.Context, keys ...string) (bool, error) {
+
+	if !g.Cfg("captcha").MustGet(ctx, "onlyAfterFailure", false).Bool() {
+		return true, nil
+	}
+
+	for _, key := range keys {
+		ok, err := c.failures.Contains(ctx, c.failureKey(key))
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+
+	return false, nil
+}
+
+// driver 根据类型创建验证码驱动
+func (c *Captcha) driver(captchaType string, width, height, length int) (base64Captcha.Driver, error) {
+	switch captchaType {
+	case TypeDigit, "":
+		return base64Captcha.NewDriverDigit(height, width, length, 0.5, 10), nil
+	case TypeMath:
+		return base64Captcha.NewDriverMath(height, width, 0, base64Captcha.OptionShowHollowLine, nil, nil, nil), nil
+	case TypeString:
+		return base64Captcha.NewDriverString(height, width, 0, base64Captcha.OptionShowHollowLine, length, base64Captcha.TxtSimpleCharaters, nil, nil, nil), nil
+	case TypeAudio:
+		return base64Captcha.NewDriverAudio(length, "zh"), nil
+	}
+	return nil, fmt.Errorf("不支持的验证码类型: %s", captchaType)
+}
+
+func (c *Captcha) failureKey(key string) string {
+	return fmt.Sprintf("captcha:failure:%s", key)
+}
+
+// cacheStore 基于 gcache 的验证码存储，适配器为 Redis 时可在多副本间共享
+type cacheStore struct {
+	cache  *gcache.Cache
+	expire time.Duration
+}
+
+func newCacheStore(cache *gcache.Cache, expire time.Duration) *cacheStore {
+	return &cacheStore{
+		cache:  cache,
+		expire: expire,
+	}
+}
+
+// Set 保存验证码答案
+func (s *cacheStore) Set(id string, value string) error {
+	return s.cache.Set(context.Background(), s.key(id), value, s.expire)
+}
+
+// Get 获取验证码答案，clear 为 true 时同时删除
+func (s *cacheStore) Get(id string, clear bool) string {
+	var (
+		ctx   = context.Background()
+		value *gvar.Var
+		err   error
+	)
+	if clear {
+		value, err = s.cache.Remove(ctx, s.key(id))
+	} else {
+		value, err = s.cache.Get(ctx, s.key(id))
+	}
+	if err != nil || value.IsNil() {
+		return ""
+	}
+	return value.String()
+}
+
+// Verify 校验验证码答案，字符验证码不区分大小写
+func (s *cacheStore) Verify(id, answer string, clear bool) bool {
+	value := s.Get(id, clear)
+	return value != "" && strings.EqualFold(value, strings.TrimSpace(answer))
+}
+
+func (s *cacheStore) key(id string) string {
+	return fmt.Sprintf("captcha:%s", id)
 }
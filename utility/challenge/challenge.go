@@ -0,0 +1,100 @@
+package challenge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// 存储类型
+const (
+	StoreMemory = "memory" // 进程内缓存
+	StoreRedis  = "redis"  // Redis，多副本部署时使用
+)
+
+var ChallengeUtility = newChallenge(context.Background())
+
+// Challenge 登录挑战，密码校验通过后等待第二步验证
+type Challenge struct {
+	UserId   uint64 `json:"userId"`
+	Secret   string `json:"secret"`   // 待绑定的TOTP密钥，首次绑定时使用
+	Failures int    `json:"failures"` // 验证失败次数
+}
+
+type challenge struct {
+	cache *gcache.Cache
+}
+
+func newChallenge(ctx context.Context) *challenge {
+
+	c := &challenge{
+		cache: gcache.New(),
+	}
+
+	// 根据配置选择存储
+	if g.Cfg("auth").MustGet(ctx, "mfa.store", StoreMemory).String() == StoreRedis {
+		c.SetAdapter(gcache.NewAdapterRedis(g.Redis(g.Cfg("auth").MustGet(ctx, "mfa.redis", "default").String())))
+	}
+
+	return c
+}
+
+// SetAdapter 设置存储适配器
+func (c *challenge) SetAdapter(adapter gcache.Adapter) {
+	c.cache.SetAdapter(adapter)
+}
+
+// Create 创建挑战，返回挑战令牌
+func (c *challenge) Create(ctx context.Context, data *Challenge, expire time.Duration) (string, error) {
+	token := guid.S()
+	if err := c.cache.Set(ctx, c.key(token), data, expire); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Get 获取挑战，不存在或已过期时返回 nil
+func (c *challenge) Get(ctx context.Context, token string) (*Challenge, error) {
+
+	if token == "" {
+		return nil, nil
+	}
+
+	value, err := c.cache.Get(ctx, c.key(token))
+	if err != nil || value.IsNil() {
+		return nil, err
+	}
+
+	var data *Challenge
+	if err = value.Scan(&data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Update 更新挑战内容，不改变过期时间
+func (c *challenge) Update(ctx context.Context, token string, data *Challenge) error {
+	_, _, err := c.cache.Update(ctx, c.key(token), data)
+	return err
+}
+
+// Remove 删除挑战，挑战只能成功使用一次
+func (c *challenge) Remove(ctx context.Context, token string) error {
+	_, err := c.cache.Remove(ctx, c.key(token))
+	return err
+}
+
+// MarkOnce 标记某个值在 ttl 内只能使用一次，返回 false 表示已使用过
+// 用于防止同一个TOTP验证码被重复使用
+func (c *challenge) MarkOnce(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return c.cache.SetIfNotExist(ctx, fmt.Sprintf("challenge:once:%s", key), 1, ttl)
+}
+
+func (c *challenge) key(token string) string {
+	return fmt.Sprintf("challenge:%s", token)
+}
@@ -13,9 +13,22 @@ const (
 const (
 	ErrorUserDisabledCode = 1
 	ErrorUserLockedCode   = 2
+	ErrorLoginFailedCode  = 3
+	ErrorMfaCodeCode      = 4
 )
 
 var (
 	ErrorUserDisabled = gerror.NewCode(gcode.New(ErrorUserDisabledCode, "用户已禁用", nil))
 	ErrorUserLocked   = gerror.NewCode(gcode.New(ErrorUserLockedCode, "用户已被锁定", nil))
+	ErrorLoginFailed  = gerror.NewCode(gcode.New(ErrorLoginFailedCode, "用户名或者密码错误", nil))
+)
+
+var (
+	ErrorRefreshTokenInvalid = gerror.NewCode(gcode.New(CodeNoLogin, "刷新令牌无效或已过期", nil))
+	ErrorRefreshTokenReused  = gerror.NewCode(gcode.New(CodeNoLogin, "刷新令牌已被使用，请重新登录", nil))
+)
+
+var (
+	ErrorMfaChallengeInvalid = gerror.NewCode(gcode.New(CodeNoLogin, "两步验证已过期，请重新登录", nil))
+	ErrorMfaCodeInvalid      = gerror.NewCode(gcode.New(ErrorMfaCodeCode, "两步验证码错误", nil))
 )
@@ -2,24 +2,30 @@ package jwt
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/guid"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var JwtUtility = &jwtUtility{
-	Secret:        g.Cfg("jwt").MustGet(context.Background(), "secret").String(),
-	Expire:        g.Cfg("jwt").MustGet(context.Background(), "expire").Int64(),
-	RefreshExpire: g.Cfg("jwt").MustGet(context.Background(), "refresh_expire").Int64(),
-	Issuer:        g.Cfg("jwt").MustGet(context.Background(), "issuer").String(),
+var JwtUtility = newJwt(context.Background())
+
+// 签发时间精确到毫秒，用户吊销后同一秒内重新登录签发的令牌不会被误判为已吊销
+func init() {
+	jwt.TimePrecision = time.Millisecond
 }
 
 // 定义一些错误常量
 var (
-	errorTokenInvalid        = errors.New("token is invalid")
-	errorRefreshTokenExpired = errors.New("refresh token has expired")
+	errorTokenInvalid = errors.New("token is invalid")
 )
 
 type jwtUtility struct {
@@ -27,103 +33,160 @@ type jwtUtility struct {
 	Expire        int64
 	RefreshExpire int64
 	Issuer        string
+	// 签名密钥，为 nil 时使用 Secret 以 HS256 签名
+	signingKey *Key
+	// 所有可用于校验的密钥，按 kid 索引
+	keys map[string]*Key
 }
 
-// Claims 自定义声明结构体
-// 注意：这个结构体需要与项目中其他地方使用的 JWT Claims 兼容
-type Claims struct {
-	UserID      uint64    `json:"user_id"`
-	Username    string    `json:"username"`
-	RefreshTime time.Time `json:"refresh_time"` // 刷新时间
-	jwt.RegisteredClaims
-}
+func newJwt(ctx context.Context) *jwtUtility {
 
-// GenerateToken 生成 JWT 令牌
-// secret: 密钥
-// expire: 过期时间（秒）
-func (j *jwtUtility) GenerateToken(userID uint64, username string) (string, error) {
-
-	// 设置令牌过期时间
-	expirationTime := time.Now().Add(time.Duration(j.Expire) * time.Second)
-	// 刷新令牌过期时间
-	refreshExpirationTime := expirationTime.Add(time.Duration(j.RefreshExpire) * time.Second)
-
-	// 创建声明
-	claims := &Claims{
-		UserID:      userID,
-		Username:    username,
-		RefreshTime: refreshExpirationTime,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
-			Issuer:    j.Issuer,
-		},
+	j := &jwtUtility{
+		Secret:        g.Cfg("jwt").MustGet(ctx, "secret").String(),
+		Expire:        g.Cfg("jwt").MustGet(ctx, "expire").Int64(),
+		RefreshExpire: g.Cfg("jwt").MustGet(ctx, "refresh_expire").Int64(),
+		Issuer:        g.Cfg("jwt").MustGet(ctx, "issuer").String(),
 	}
 
-	// 创建令牌
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	// 签名并获取完整的编码后的字符串令牌
-	tokenString, err := token.SignedString([]byte(j.Secret))
+	// 密钥配置错误时无法签发和校验令牌，启动时直接报错
+	keys, err := loadKeys(ctx)
 	if err != nil {
-		return "", err
+		panic(err)
+	}
+	if err = j.SetKeys(keys, g.Cfg("jwt").MustGet(ctx, "signingKey").String()); err != nil {
+		panic(err)
 	}
 
-	return tokenString, nil
+	return j
 }
 
-// RefreshToken 刷新 JWT 令牌
-// token: 当前令牌
-// secret: 密钥
-// expire: 新令牌的过期时间（秒）
-func (j *jwtUtility) RefreshToken(tokenString string) (string, error) {
+// SetKeys 设置校验密钥和签名密钥
+// signingKid 为空时使用 Secret 以 HS256 签名
+func (j *jwtUtility) SetKeys(keys map[string]*Key, signingKid string) error {
 
-	// 解析令牌
-	claims := &Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		// 验证签名算法
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errorTokenInvalid
+	var signingKey *Key
+	if signingKid != "" {
+		signingKey = keys[signingKid]
+		if signingKey == nil {
+			return fmt.Errorf("jwt 签名密钥不存在: %s", signingKid)
 		}
-		return []byte(j.Secret), nil
-	})
+		if signingKey.PrivateKey == nil {
+			return fmt.Errorf("jwt 签名密钥缺少私钥: %s", signingKid)
+		}
+	} else if j.Secret == "" {
+		return errors.New("jwt 未配置 secret 或 signingKey")
+	}
 
-	// 检查令牌是否有效
-	if err != nil {
+	j.keys = keys
+	j.signingKey = signingKey
 
-		// 如果令牌过期，这是正常的刷新情况
-		if errors.Is(err, jwt.ErrTokenExpired) || errors.Is(err, jwt.ErrTokenNotValidYet) {
+	return nil
+}
+
+// Jwks 返回所有校验密钥的公钥，供其他服务校验令牌
+func (j *jwtUtility) Jwks() *Jwks {
 
-			// 检查刷新时间
-			if time.Now().After(claims.RefreshTime) {
-				return "", errorRefreshTokenExpired
-			}
+	jwks := &Jwks{Keys: make([]*Jwk, 0, len(j.keys))}
+	for _, key := range j.keys {
+		jwks.Keys = append(jwks.Keys, key.jwk())
+	}
 
-			// 生成新令牌
-			return j.GenerateToken(claims.UserID, claims.Username)
+	// 签名密钥排在最前，其余按 kid 排序，保证输出稳定
+	sort.Slice(jwks.Keys, func(a, b int) bool {
+		if j.signingKey != nil && (jwks.Keys[a].Kid == j.signingKey.Kid) != (jwks.Keys[b].Kid == j.signingKey.Kid) {
+			return jwks.Keys[a].Kid == j.signingKey.Kid
 		}
-		return "", err
+		return jwks.Keys[a].Kid < jwks.Keys[b].Kid
+	})
+
+	return jwks
+}
+
+// Claims 自定义声明结构体
+// 注意：这个结构体需要与项目中其他地方使用的 JWT Claims 兼容
+type Claims struct {
+	UserID       uint64        `json:"user_id"`
+	Username     string        `json:"username"`
+	SessionID    string        `json:"sid"`                    // 登录会话ID，与刷新令牌族ID一致
+	Impersonator *Impersonator `json:"impersonator,omitempty"` // 代登录时为实际操作的用户
+	jwt.RegisteredClaims
+}
+
+// Impersonator 代登录的操作人
+type Impersonator struct {
+	UserID    uint64 `json:"user_id"`
+	Username  string `json:"username"`
+	SessionID string `json:"sid"` // 操作人自己的登录会话，结束后代登录同时失效
+}
+
+// GenerateToken 生成 JWT 令牌
+// sessionID: 登录会话ID，退出登录时用于吊销对应的刷新令牌
+func (j *jwtUtility) GenerateToken(userID uint64, username string, sessionID string) (string, error) {
+	return j.generate(&Claims{
+		UserID:    userID,
+		Username:  username,
+		SessionID: sessionID,
+	}, time.Now().Add(time.Duration(j.Expire)*time.Second))
+}
+
+// GenerateImpersonationToken 生成代登录令牌，令牌以 userID 的身份访问，同时记录实际操作人
+func (j *jwtUtility) GenerateImpersonationToken(userID uint64, username string, sessionID string, impersonator *Impersonator, expiresAt time.Time) (string, error) {
+	return j.generate(&Claims{
+		UserID:       userID,
+		Username:     username,
+		SessionID:    sessionID,
+		Impersonator: impersonator,
+	}, expiresAt)
+}
+
+// generate 补充标准声明并签名
+func (j *jwtUtility) generate(claims *Claims, expiresAt time.Time) (string, error) {
+
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        guid.S(),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		NotBefore: jwt.NewNumericDate(time.Now()),
+		Issuer:    j.Issuer,
 	}
 
-	// 如果令牌仍然有效，也可以刷新（可选）
-	if token.Valid {
-		return j.GenerateToken(claims.UserID, claims.Username)
+	// 未配置非对称密钥时使用 HS256
+	if j.signingKey == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(j.Secret))
 	}
 
-	return "", errorTokenInvalid
+	// 在头部写入 kid，校验方据此选择公钥
+	token := jwt.NewWithClaims(j.signingKey.signingMethod(), claims)
+	token.Header["kid"] = j.signingKey.Kid
+
+	return token.SignedString(j.signingKey.PrivateKey)
+}
+
+// GenerateRefreshToken 生成刷新令牌
+// 刷新令牌为随机字符串，只返回给客户端一次，服务端仅保存其哈希
+func (j *jwtUtility) GenerateRefreshToken() (token string, hash string, err error) {
+
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(buf)
+
+	return token, j.HashRefreshToken(token), nil
+}
+
+// HashRefreshToken 计算刷新令牌的哈希
+func (j *jwtUtility) HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
 // ParseToken 解析 JWT 令牌
 func (j *jwtUtility) ParseToken(tokenString string) (*Claims, error) {
 
 	claims := &Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errorTokenInvalid
-		}
-		return []byte(j.Secret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, claims, j.keyFunc, jwt.WithValidMethods([]string{AlgorithmHS256, AlgorithmRS256, AlgorithmEdDSA}))
 
 	if err != nil {
 		return nil, err
@@ -135,3 +198,23 @@ func (j *jwtUtility) ParseToken(tokenString string) (*Claims, error) {
 
 	return claims, nil
 }
+
+// keyFunc 根据令牌头部选择校验密钥
+// 切换到非对称签名后，配置了 Secret 时仍可校验切换前签发的 HS256 令牌
+func (j *jwtUtility) keyFunc(token *jwt.Token) (interface{}, error) {
+
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if j.Secret == "" {
+			return nil, errorTokenInvalid
+		}
+		return []byte(j.Secret), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key := j.keys[kid]
+	if key == nil || key.Algorithm != token.Method.Alg() {
+		return nil, errorTokenInvalid
+	}
+
+	return key.PublicKey, nil
+}
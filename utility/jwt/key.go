@@ -0,0 +1,174 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 签名算法
+const (
+	AlgorithmHS256 = "HS256" // 对称签名，使用 secret
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA" // Ed25519
+)
+
+// Key 非对称签名密钥
+// 只用于校验的旧密钥可以没有私钥
+type Key struct {
+	Kid        string
+	Algorithm  string
+	PrivateKey crypto.Signer
+	PublicKey  crypto.PublicKey
+}
+
+// keyConfig jwt.yaml 中的密钥配置
+type keyConfig struct {
+	Kid        string `json:"kid"`
+	Algorithm  string `json:"algorithm"`
+	PrivateKey string `json:"privateKey"` // PEM 文件路径或 PEM 内容
+	PublicKey  string `json:"publicKey"`  // PEM 文件路径或 PEM 内容，为空时从私钥导出
+}
+
+// loadKeys 读取配置中的所有密钥
+func loadKeys(ctx context.Context) (map[string]*Key, error) {
+
+	var configs []*keyConfig
+	if err := g.Cfg("jwt").MustGet(ctx, "keys").Scan(&configs); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*Key, len(configs))
+	for _, config := range configs {
+		if config.Kid == "" {
+			return nil, fmt.Errorf("jwt 密钥缺少 kid")
+		}
+		if _, ok := keys[config.Kid]; ok {
+			return nil, fmt.Errorf("jwt 密钥 kid 重复: %s", config.Kid)
+		}
+
+		key, err := parseKey(config)
+		if err != nil {
+			return nil, fmt.Errorf("jwt 密钥 %s 无效: %v", config.Kid, err)
+		}
+		keys[key.Kid] = key
+	}
+
+	return keys, nil
+}
+
+// parseKey 解析 PEM 格式的密钥
+func parseKey(config *keyConfig) (*Key, error) {
+
+	key := &Key{Kid: config.Kid, Algorithm: config.Algorithm}
+
+	privatePem, err := readPem(config.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	publicPem, err := readPem(config.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if privatePem == nil && publicPem == nil {
+		return nil, fmt.Errorf("未配置 privateKey 或 publicKey")
+	}
+
+	switch config.Algorithm {
+	case AlgorithmRS256:
+		if privatePem != nil {
+			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privatePem)
+			if err != nil {
+				return nil, err
+			}
+			key.PrivateKey, key.PublicKey = privateKey, &privateKey.PublicKey
+		}
+		if publicPem != nil {
+			if key.PublicKey, err = jwt.ParseRSAPublicKeyFromPEM(publicPem); err != nil {
+				return nil, err
+			}
+		}
+	case AlgorithmEdDSA:
+		if privatePem != nil {
+			privateKey, err := jwt.ParseEdPrivateKeyFromPEM(privatePem)
+			if err != nil {
+				return nil, err
+			}
+			key.PrivateKey, key.PublicKey = privateKey.(ed25519.PrivateKey), privateKey.(ed25519.PrivateKey).Public()
+		}
+		if publicPem != nil {
+			if key.PublicKey, err = jwt.ParseEdPublicKeyFromPEM(publicPem); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("不支持的签名算法: %s", config.Algorithm)
+	}
+
+	return key, nil
+}
+
+// readPem 读取 PEM 内容，value 可以是文件路径或 PEM 内容本身
+func readPem(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	if strings.HasPrefix(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	if !gfile.Exists(value) {
+		return nil, fmt.Errorf("密钥文件不存在: %s", value)
+	}
+	return gfile.GetBytes(value), nil
+}
+
+// signingMethod 密钥对应的签名方法
+func (k *Key) signingMethod() jwt.SigningMethod {
+	if k.Algorithm == AlgorithmEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+// Jwk RFC 7517 JSON Web Key，只包含公钥
+type Jwk struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+// Jwks JSON Web Key Set
+type Jwks struct {
+	Keys []*Jwk `json:"keys"`
+}
+
+// jwk 导出公钥
+func (k *Key) jwk() *Jwk {
+	jwk := &Jwk{Use: "sig", Alg: k.Algorithm, Kid: k.Kid}
+	switch publicKey := k.PublicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+	}
+	return jwk
+}
@@ -0,0 +1,296 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+var LdapUtility = newLdap(context.Background())
+
+// Config LDAP 配置，对应 ldap.yaml
+type Config struct {
+	Enabled            bool   `json:"enabled"`
+	Url                string `json:"url"`                // ldap:// 或 ldaps://
+	StartTls           bool   `json:"startTls"`           // ldap:// 连接后升级为 TLS
+	CaFile             string `json:"caFile"`             // 校验服务端证书的 CA 证书文件
+	InsecureSkipVerify bool   `json:"insecureSkipVerify"` // 跳过证书校验，仅用于测试
+	Timeout            int64  `json:"timeout"`            // 连接超时（秒）
+	BindDn             string `json:"bindDn"`             // 查找用户的服务账号
+	BindPassword       string `json:"bindPassword"`
+	BaseDn             string `json:"baseDn"`     // 查找用户的基准DN
+	UserFilter         string `json:"userFilter"` // 查找用户的过滤条件，%s 替换为用户名
+	SyncFilter         string `json:"syncFilter"` // 同步时查找所有用户的过滤条件
+	Attributes         struct {
+		Username string `json:"username"`
+		Email    string `json:"email"`
+		Mobile   string `json:"mobile"`
+		Groups   string `json:"groups"`
+	} `json:"attributes"` // 用户信息对应的属性
+	Disabled struct {
+		Attribute string `json:"attribute"`
+		Value     string `json:"value"`
+		Mask      int64  `json:"mask"`
+	} `json:"disabled"` // 账号禁用判断
+	Provision struct {
+		Enabled      bool     `json:"enabled"`
+		DepartmentId uint64   `json:"departmentId"`
+		RoleIds      []uint64 `json:"roleIds"`
+	} `json:"provision"` // 即时创建用户
+	GroupRoles   map[string][]uint64 `json:"groupRoles"`   // 组DN映射角色，不区分大小写
+	SyncRoles    bool                `json:"syncRoles"`    // 每次登录按组映射重新设置角色
+	SyncInterval int64               `json:"syncInterval"` // 同步禁用账号的间隔（分钟），0=不同步
+}
+
+// Entry 目录中的用户
+type Entry struct {
+	Dn       string   `json:"dn"`
+	Username string   `json:"username"`
+	Email    string   `json:"email"`
+	Mobile   string   `json:"mobile"`
+	Groups   []string `json:"groups"` // 组DN，已转为小写
+	Disabled bool     `json:"disabled"`
+}
+
+type ldapUtility struct {
+	Config *Config
+}
+
+func newLdap(ctx context.Context) *ldapUtility {
+
+	config := &Config{
+		Timeout:    5,
+		UserFilter: "(&(objectClass=person)(uid=%s))",
+		SyncFilter: "(objectClass=person)",
+	}
+	config.Attributes.Username = "uid"
+	config.Attributes.Email = "mail"
+	config.Attributes.Mobile = "mobile"
+	config.Attributes.Groups = "memberOf"
+
+	if err := gconv.Struct(g.Cfg("ldap").MustData(ctx), config); err != nil {
+		panic(err)
+	}
+
+	// 组DN不区分大小写
+	groupRoles := make(map[string][]uint64, len(config.GroupRoles))
+	for group, roleIds := range config.GroupRoles {
+		groupRoles[strings.ToLower(group)] = roleIds
+	}
+	config.GroupRoles = groupRoles
+
+	return &ldapUtility{Config: config}
+}
+
+// Authenticate 使用用户名和密码认证
+// 用户不存在或密码错误时返回 nil，连接或查询失败时返回错误
+func (l *ldapUtility) Authenticate(ctx context.Context, username string, password string) (*Entry, error) {
+
+	if !l.Config.Enabled {
+		return nil, errors.New("未开启 LDAP 认证")
+	}
+
+	// 空密码会被服务端当作匿名绑定，直接视为密码错误
+	if username == "" || password == "" {
+		return nil, nil
+	}
+
+	conn, err := l.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	entries, err := l.search(conn, fmt.Sprintf(l.Config.UserFilter, ldap.EscapeFilter(username)), 2)
+	if err != nil {
+		return nil, err
+	}
+
+	// 用户不存在或用户名不唯一
+	if len(entries) != 1 {
+		return nil, nil
+	}
+
+	// 使用用户DN和密码绑定校验密码
+	if err = conn.Bind(entries[0].Dn, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return entries[0], nil
+}
+
+// Users 获取目录中的所有用户，用于同步账号状态
+func (l *ldapUtility) Users(ctx context.Context) ([]*Entry, error) {
+
+	if !l.Config.Enabled {
+		return nil, errors.New("未开启 LDAP 认证")
+	}
+
+	conn, err := l.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return l.search(conn, l.Config.SyncFilter, 0)
+}
+
+// connect 连接并使用服务账号绑定
+func (l *ldapUtility) connect() (*ldap.Conn, error) {
+
+	tlsConfig, err := l.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := time.Duration(l.Config.Timeout) * time.Second
+	conn, err := ldap.DialURL(l.Config.Url,
+		ldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+		ldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("连接 LDAP 失败: %w", err)
+	}
+	conn.SetTimeout(timeout)
+
+	if l.Config.StartTls {
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("LDAP StartTLS 失败: %w", err)
+		}
+	}
+
+	if l.Config.BindDn != "" {
+		if err = conn.Bind(l.Config.BindDn, l.Config.BindPassword); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("LDAP 服务账号绑定失败: %w", err)
+		}
+	}
+
+	return conn, nil
+}
+
+// tlsConfig ldaps:// 和 StartTLS 使用的 TLS 配置
+func (l *ldapUtility) tlsConfig() (*tls.Config, error) {
+
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: l.Config.InsecureSkipVerify,
+	}
+
+	if u, err := url.Parse(l.Config.Url); err == nil {
+		config.ServerName = u.Hostname()
+	}
+
+	if l.Config.CaFile != "" {
+		pem, err := os.ReadFile(l.Config.CaFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("LDAP CA 证书无效: %s", l.Config.CaFile)
+		}
+	}
+
+	return config, nil
+}
+
+// search 查找用户，sizeLimit 为0时使用分页获取全部
+func (l *ldapUtility) search(conn *ldap.Conn, filter string, sizeLimit int) ([]*Entry, error) {
+
+	var attributes []string
+	for _, attribute := range []string{
+		l.Config.Attributes.Username,
+		l.Config.Attributes.Email,
+		l.Config.Attributes.Mobile,
+		l.Config.Attributes.Groups,
+		l.Config.Disabled.Attribute,
+	} {
+		if attribute != "" {
+			attributes = append(attributes, attribute)
+		}
+	}
+
+	request := ldap.NewSearchRequest(l.Config.BaseDn, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		sizeLimit, int(l.Config.Timeout), false, filter, attributes, nil)
+
+	var (
+		result *ldap.SearchResult
+		err    error
+	)
+	if sizeLimit > 0 {
+		result, err = conn.Search(request)
+	} else {
+		result, err = conn.SearchWithPaging(request, 500)
+	}
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("LDAP 查询失败: %w", err)
+	}
+	if result == nil {
+		return nil, nil
+	}
+
+	entries := make([]*Entry, 0, len(result.Entries))
+	for _, item := range result.Entries {
+		entry := &Entry{
+			Dn:       item.DN,
+			Username: l.attribute(item, l.Config.Attributes.Username),
+			Email:    l.attribute(item, l.Config.Attributes.Email),
+			Mobile:   l.attribute(item, l.Config.Attributes.Mobile),
+			Disabled: l.disabled(item),
+		}
+		if l.Config.Attributes.Groups != "" {
+			for _, group := range item.GetAttributeValues(l.Config.Attributes.Groups) {
+				entry.Groups = append(entry.Groups, strings.ToLower(group))
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (l *ldapUtility) attribute(entry *ldap.Entry, name string) string {
+	if name == "" {
+		return ""
+	}
+	return entry.GetAttributeValue(name)
+}
+
+// disabled 判断账号是否禁用
+// 配置 mask 时按位判断（如 AD 的 userAccountControl），否则判断属性值是否等于 value
+func (l *ldapUtility) disabled(entry *ldap.Entry) bool {
+
+	rule := l.Config.Disabled
+	if rule.Attribute == "" {
+		return false
+	}
+
+	value := entry.GetAttributeValue(rule.Attribute)
+	if value == "" {
+		return false
+	}
+
+	if rule.Mask > 0 {
+		flags, err := strconv.ParseInt(value, 10, 64)
+		return err == nil && flags&rule.Mask != 0
+	}
+
+	return strings.EqualFold(value, rule.Value)
+}
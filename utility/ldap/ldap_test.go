@@ -0,0 +1,119 @@
+package ldap
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+
+	"gf-ant-react/utility/ldap/ldaptest"
+)
+
+// 启动模拟的目录服务，返回连接到它的客户端，配置与 ldap.yaml 的默认配置一致
+func newTestLdap(t *testing.T) *ldapUtility {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go ldaptest.Serve(context.Background(), listener)
+
+	config := &Config{
+		Enabled:      true,
+		Url:          "ldap://" + listener.Addr().String(),
+		Timeout:      5,
+		BindDn:       "cn=admin," + ldaptest.BaseDn,
+		BindPassword: "admin",
+		BaseDn:       "ou=people," + ldaptest.BaseDn,
+		UserFilter:   "(&(objectClass=person)(uid=%s))",
+		SyncFilter:   "(objectClass=person)",
+	}
+	config.Attributes.Username = "uid"
+	config.Attributes.Email = "mail"
+	config.Attributes.Mobile = "mobile"
+	config.Attributes.Groups = "memberOf"
+	config.Disabled.Attribute = "nsAccountLock"
+	config.Disabled.Value = "TRUE"
+
+	return &ldapUtility{Config: config}
+}
+
+func TestAuthenticate(t *testing.T) {
+
+	ctx := context.Background()
+	l := newTestLdap(t)
+
+	entry, err := l.Authenticate(ctx, "alice", "alice123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry == nil {
+		t.Fatal("用户名和密码正确时应当认证成功")
+	}
+	if entry.Dn != "uid=alice,ou=people,dc=example,dc=com" || entry.Username != "alice" ||
+		entry.Email != "alice@example.com" || entry.Mobile != "13800000001" || entry.Disabled {
+		t.Fatalf("用户信息不正确: %+v", entry)
+	}
+	if strings.Join(entry.Groups, ",") != "cn=admins,ou=groups,dc=example,dc=com" {
+		t.Fatalf("组不正确: %v", entry.Groups)
+	}
+
+	// 禁用的账号仍然可以认证，由调用方处理
+	entry, err = l.Authenticate(ctx, "carol", "carol123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry == nil || !entry.Disabled {
+		t.Fatalf("carol 应当是禁用的账号: %+v", entry)
+	}
+}
+
+func TestAuthenticateFailed(t *testing.T) {
+
+	ctx := context.Background()
+	l := newTestLdap(t)
+
+	tests := []struct {
+		username string
+		password string
+	}{
+		{"alice", "wrong"}, // 密码错误
+		{"alice", ""},      // 空密码不能匿名绑定
+		{"nobody", "nobody"},
+		{"*", "alice123"}, // 用户名中的过滤条件需要转义
+	}
+	for _, tt := range tests {
+		entry, err := l.Authenticate(ctx, tt.username, tt.password)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.username, err)
+		}
+		if entry != nil {
+			t.Fatalf("%s/%s 不应当认证成功", tt.username, tt.password)
+		}
+	}
+}
+
+func TestUsers(t *testing.T) {
+
+	l := newTestLdap(t)
+
+	entries, err := l.Users(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var users []string
+	for _, entry := range entries {
+		name := entry.Username
+		if entry.Disabled {
+			name += "(disabled)"
+		}
+		users = append(users, name)
+	}
+	sort.Strings(users)
+	if strings.Join(users, ",") != "alice,bob,carol(disabled)" {
+		t.Fatalf("用户不正确: %v", users)
+	}
+}
@@ -0,0 +1,355 @@
+// Package ldaptest 模拟 LDAP 目录，用于开发联调和测试，不要在生产环境使用
+// 只支持简单绑定和查询，目录内容固定
+package ldaptest
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// BaseDn 目录的基准DN
+const BaseDn = "dc=example,dc=com"
+
+// Users 目录中的用户，与 ldap.yaml 的默认配置对应
+const Users = "alice/alice123 (admins), bob/bob123 (editors), carol/carol123 (disabled)"
+
+// 目录中的条目
+type record struct {
+	Dn         string
+	Password   string
+	Attributes map[string][]string
+}
+
+var entries = []*record{
+	{
+		Dn:       "cn=admin,dc=example,dc=com",
+		Password: "admin",
+		Attributes: map[string][]string{
+			"objectClass": {"organizationalRole"},
+			"cn":          {"admin"},
+		},
+	},
+	{
+		Dn:       "uid=alice,ou=people,dc=example,dc=com",
+		Password: "alice123",
+		Attributes: map[string][]string{
+			"objectClass": {"person", "inetOrgPerson"},
+			"uid":         {"alice"},
+			"cn":          {"Alice"},
+			"mail":        {"alice@example.com"},
+			"mobile":      {"13800000001"},
+			"memberOf":    {"cn=admins,ou=groups,dc=example,dc=com"},
+		},
+	},
+	{
+		Dn:       "uid=bob,ou=people,dc=example,dc=com",
+		Password: "bob123",
+		Attributes: map[string][]string{
+			"objectClass": {"person", "inetOrgPerson"},
+			"uid":         {"bob"},
+			"cn":          {"Bob"},
+			"mail":        {"bob@example.com"},
+			"memberOf":    {"cn=editors,ou=groups,dc=example,dc=com"},
+		},
+	},
+	{
+		Dn:       "uid=carol,ou=people,dc=example,dc=com",
+		Password: "carol123",
+		Attributes: map[string][]string{
+			"objectClass":   {"person", "inetOrgPerson"},
+			"uid":           {"carol"},
+			"cn":            {"Carol"},
+			"mail":          {"carol@example.com"},
+			"nsAccountLock": {"TRUE"},
+		},
+	},
+}
+
+// LDAP 协议操作和结果码
+const (
+	opBindRequest           = 0
+	opBindResponse          = 1
+	opUnbindRequest         = 2
+	opSearchRequest         = 3
+	opSearchEntry           = 4
+	opSearchDone            = 5
+	opExtendedRequest       = 23
+	opExtendedResponse      = 24
+	resultSuccess           = 0
+	resultProtocolError     = 2
+	resultNoSuchObject      = 32
+	resultInvalidCredential = 49
+	resultUnwilling         = 53
+)
+
+// Serve 接受连接并处理请求，直到 listener 关闭
+func Serve(ctx context.Context, listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go serve(ctx, conn)
+	}
+}
+
+// serve 处理一个连接上的请求，按顺序逐个处理
+func serve(ctx context.Context, conn net.Conn) {
+
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	for {
+		packet, err := ber.ReadPacket(reader)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+
+		messageId, _ := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+
+		var responses []*ber.Packet
+		switch op.Tag {
+		case opBindRequest:
+			responses = append(responses, bind(op))
+		case opUnbindRequest:
+			return
+		case opSearchRequest:
+			responses = search(op)
+		case opExtendedRequest:
+			// 不支持 StartTLS 等扩展操作
+			responses = append(responses, resultPacket(opExtendedResponse, resultProtocolError, "extended operations are not supported"))
+		default:
+			g.Log().Debugf(ctx, "mock ldap: unsupported operation %d", op.Tag)
+			return
+		}
+
+		for _, response := range responses {
+			message := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+			message.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageId, "Message ID"))
+			message.AppendChild(response)
+			if _, err = conn.Write(message.Bytes()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// bind 简单绑定，空密码视为匿名绑定
+func bind(op *ber.Packet) *ber.Packet {
+
+	if len(op.Children) < 3 || op.Children[2].Tag != 0 {
+		return resultPacket(opBindResponse, resultUnwilling, "only simple bind is supported")
+	}
+
+	dn := stringValue(op.Children[1])
+	password := stringValue(op.Children[2])
+	if password == "" {
+		return resultPacket(opBindResponse, resultSuccess, "")
+	}
+
+	for _, entry := range entries {
+		if strings.EqualFold(entry.Dn, dn) && entry.Password == password {
+			return resultPacket(opBindResponse, resultSuccess, "")
+		}
+	}
+
+	return resultPacket(opBindResponse, resultInvalidCredential, "invalid credentials")
+}
+
+// search 查询条目，支持 base/one/sub 范围，忽略分页控制
+func search(op *ber.Packet) []*ber.Packet {
+
+	if len(op.Children) < 8 {
+		return []*ber.Packet{resultPacket(opSearchDone, resultProtocolError, "invalid search request")}
+	}
+
+	baseDn := strings.ToLower(stringValue(op.Children[0]))
+	scope, _ := op.Children[1].Value.(int64)
+	sizeLimit, _ := op.Children[3].Value.(int64)
+	filter := op.Children[6]
+
+	var attributes []string
+	for _, attribute := range op.Children[7].Children {
+		attributes = append(attributes, stringValue(attribute))
+	}
+
+	if baseDn != BaseDn && !strings.HasSuffix(baseDn, ","+BaseDn) {
+		return []*ber.Packet{resultPacket(opSearchDone, resultNoSuchObject, "no such object")}
+	}
+
+	var responses []*ber.Packet
+	for _, entry := range entries {
+
+		dn := strings.ToLower(entry.Dn)
+		switch scope {
+		case 0:
+			if dn != baseDn {
+				continue
+			}
+		case 1:
+			if parts := strings.SplitN(dn, ",", 2); len(parts) != 2 || parts[1] != baseDn {
+				continue
+			}
+		default:
+			if dn != baseDn && !strings.HasSuffix(dn, ","+baseDn) {
+				continue
+			}
+		}
+
+		if !match(entry, filter) {
+			continue
+		}
+
+		if sizeLimit > 0 && int64(len(responses)) >= sizeLimit {
+			responses = append(responses, resultPacket(opSearchDone, 4, "size limit exceeded"))
+			return responses
+		}
+
+		responses = append(responses, entryPacket(entry, attributes))
+	}
+
+	return append(responses, resultPacket(opSearchDone, resultSuccess, ""))
+}
+
+// match 判断条目是否满足过滤条件，支持 and/or/not/equality/substrings/present
+func match(entry *record, filter *ber.Packet) bool {
+
+	switch filter.Tag {
+	case 0:
+		for _, child := range filter.Children {
+			if !match(entry, child) {
+				return false
+			}
+		}
+		return true
+	case 1:
+		for _, child := range filter.Children {
+			if match(entry, child) {
+				return true
+			}
+		}
+		return false
+	case 2:
+		return len(filter.Children) == 1 && !match(entry, filter.Children[0])
+	case 3:
+		if len(filter.Children) != 2 {
+			return false
+		}
+		value := stringValue(filter.Children[1])
+		for _, item := range attributeValues(entry, stringValue(filter.Children[0])) {
+			if strings.EqualFold(item, value) {
+				return true
+			}
+		}
+		return false
+	case 4:
+		if len(filter.Children) != 2 {
+			return false
+		}
+		for _, item := range attributeValues(entry, stringValue(filter.Children[0])) {
+			if substrings(strings.ToLower(item), filter.Children[1].Children) {
+				return true
+			}
+		}
+		return false
+	case 7:
+		return len(attributeValues(entry, stringValue(filter))) > 0
+	}
+
+	return false
+}
+
+// substrings 按顺序匹配 initial/any/final
+func substrings(value string, parts []*ber.Packet) bool {
+	for _, part := range parts {
+		sub := strings.ToLower(stringValue(part))
+		switch part.Tag {
+		case 0:
+			if !strings.HasPrefix(value, sub) {
+				return false
+			}
+			value = value[len(sub):]
+		case 1:
+			index := strings.Index(value, sub)
+			if index < 0 {
+				return false
+			}
+			value = value[index+len(sub):]
+		case 2:
+			if !strings.HasSuffix(value, sub) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// attributeValues 属性值，属性名不区分大小写
+func attributeValues(entry *record, name string) []string {
+	for key, values := range entry.Attributes {
+		if strings.EqualFold(key, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+// entryPacket 查询结果条目，只返回请求的属性，未指定时返回全部
+func entryPacket(entry *record, attributes []string) *ber.Packet {
+
+	packet := ber.Encode(ber.ClassApplication, ber.TypeConstructed, opSearchEntry, nil, "Search Result Entry")
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.Dn, "Object Name"))
+
+	list := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for key, values := range entry.Attributes {
+		if len(attributes) > 0 && !wanted(attributes, key) {
+			continue
+		}
+		attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, key, "Type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, value := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "Value"))
+		}
+		attribute.AppendChild(set)
+		list.AppendChild(attribute)
+	}
+	packet.AppendChild(list)
+
+	return packet
+}
+
+func wanted(attributes []string, name string) bool {
+	for _, attribute := range attributes {
+		if attribute == "*" || strings.EqualFold(attribute, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// resultPacket 操作结果
+func resultPacket(op ber.Tag, code int64, message string) *ber.Packet {
+	packet := ber.Encode(ber.ClassApplication, ber.TypeConstructed, op, nil, "Result")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "Result Code"))
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, message, "Diagnostic Message"))
+	return packet
+}
+
+// stringValue 读取字符串值，上下文类型的字段没有解析后的 Value
+func stringValue(packet *ber.Packet) string {
+	if value, ok := packet.Value.(string); ok {
+		return value
+	}
+	if packet.Data != nil {
+		return packet.Data.String()
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// fileSender 把邮件保存为 .eml 文件，可以用邮件客户端打开查看
+type fileSender struct {
+	path string
+}
+
+func (s *fileSender) Send(ctx context.Context, message *Message) error {
+
+	content, err := encode(message)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(s.path, 0o755); err != nil {
+		return err
+	}
+
+	name := filepath.Join(s.path, fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102150405"), grand.S(6)))
+	if err = os.WriteFile(name, content, 0o600); err != nil {
+		return err
+	}
+
+	g.Log().Infof(ctx, "邮件已保存: %s", name)
+	return nil
+}
@@ -0,0 +1,151 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// 发送方式
+const (
+	DriverLog  = "log"  // 只写日志，开发时使用
+	DriverFile = "file" // 保存为 .eml 文件，开发和测试时使用
+	DriverSmtp = "smtp" // SMTP 发送
+)
+
+var MailUtility = newMail(context.Background())
+
+// Config 邮件配置，对应 mail.yaml
+type Config struct {
+	Driver string `json:"driver"`
+	From   string `json:"from"` // 发件人，如 "系统通知 <noreply@example.com>"
+	File   struct {
+		Path string `json:"path"` // 邮件文件保存目录
+	} `json:"file"`
+	Smtp SmtpConfig `json:"smtp"`
+}
+
+// Message 邮件内容，正文为纯文本
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Sender 邮件发送方式，实现该接口即可替换默认的发送方式
+type Sender interface {
+	Send(ctx context.Context, message *Message) error
+}
+
+type mailUtility struct {
+	Config *Config
+	sender Sender
+}
+
+func newMail(ctx context.Context) *mailUtility {
+
+	config := &Config{
+		Driver: DriverLog,
+		From:   "noreply@localhost",
+	}
+	config.File.Path = "temp/mail"
+	config.Smtp.Port = 587
+	config.Smtp.Tls = SmtpTlsStartTls
+	config.Smtp.Timeout = 10
+
+	if err := gconv.Struct(g.Cfg("mail").MustData(ctx), config); err != nil {
+		panic(err)
+	}
+
+	m := &mailUtility{Config: config}
+
+	switch config.Driver {
+	case DriverFile:
+		m.sender = &fileSender{path: config.File.Path}
+	case DriverSmtp:
+		m.sender = &smtpSender{config: &config.Smtp}
+	default:
+		m.sender = &logSender{}
+	}
+
+	return m
+}
+
+// SetSender 替换发送方式
+func (m *mailUtility) SetSender(sender Sender) {
+	m.sender = sender
+}
+
+// Send 发送邮件，未指定发件人时使用配置的发件人
+func (m *mailUtility) Send(ctx context.Context, message *Message) error {
+
+	if len(message.To) == 0 {
+		return errors.New("收件人不能为空")
+	}
+	if message.From == "" {
+		message.From = m.Config.From
+	}
+
+	return m.sender.Send(ctx, message)
+}
+
+// logSender 只把邮件内容写入日志
+type logSender struct{}
+
+func (s *logSender) Send(ctx context.Context, message *Message) error {
+	g.Log().Infof(ctx, "邮件: to=%s subject=%s\n%s", strings.Join(message.To, ","), message.Subject, message.Body)
+	return nil
+}
+
+// encode 生成 RFC 5322 格式的邮件
+func encode(message *Message) ([]byte, error) {
+
+	from, err := mail.ParseAddress(message.From)
+	if err != nil {
+		return nil, fmt.Errorf("发件人地址无效: %w", err)
+	}
+
+	to := make([]string, 0, len(message.To))
+	for _, item := range message.To {
+		address, err := mail.ParseAddress(item)
+		if err != nil {
+			return nil, fmt.Errorf("收件人地址无效: %w", err)
+		}
+		to = append(to, address.String())
+	}
+
+	var buf bytes.Buffer
+	header := func(key, value string) {
+		buf.WriteString(key + ": " + value + "\r\n")
+	}
+	header("From", from.String())
+	header("To", strings.Join(to, ", "))
+	header("Subject", mime.BEncoding.Encode("UTF-8", message.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", fmt.Sprintf("<%s@%s>", guid.S(), domain(from.Address)))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=UTF-8")
+	header("Content-Transfer-Encoding", "8bit")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(message.Body, "\r\n", "\n"), "\n", "\r\n"))
+
+	return buf.Bytes(), nil
+}
+
+// 地址中的域名
+func domain(address string) string {
+	if index := strings.LastIndex(address, "@"); index >= 0 {
+		return address[index+1:]
+	}
+	return "localhost"
+}
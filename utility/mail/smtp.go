@@ -0,0 +1,121 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTP 加密方式
+const (
+	SmtpTlsNone     = "none"     // 不加密，仅用于本地测试
+	SmtpTlsStartTls = "starttls" // 连接后升级为 TLS，通常使用 587 端口
+	SmtpTlsSsl      = "ssl"      // 直接使用 TLS 连接，通常使用 465 端口
+)
+
+// SmtpConfig SMTP 配置
+type SmtpConfig struct {
+	Host               string `json:"host"`
+	Port               int    `json:"port"`
+	Username           string `json:"username"`
+	Password           string `json:"password"`
+	Tls                string `json:"tls"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify"` // 跳过证书校验，仅用于测试
+	Timeout            int64  `json:"timeout"`            // 超时（秒）
+}
+
+// smtpSender 通过 SMTP 发送邮件
+type smtpSender struct {
+	config *SmtpConfig
+}
+
+func (s *smtpSender) Send(ctx context.Context, message *Message) error {
+
+	content, err := encode(message)
+	if err != nil {
+		return err
+	}
+
+	client, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if s.config.Username != "" {
+		if err = client.Auth(smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)); err != nil {
+			return fmt.Errorf("SMTP 认证失败: %w", err)
+		}
+	}
+
+	from, _ := mail.ParseAddress(message.From)
+	if err = client.Mail(from.Address); err != nil {
+		return err
+	}
+	for _, item := range message.To {
+		to, _ := mail.ParseAddress(item)
+		if err = client.Rcpt(to.Address); err != nil {
+			return err
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = writer.Write(content); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// dial 连接服务器并按配置启用 TLS
+func (s *smtpSender) dial(ctx context.Context) (*smtp.Client, error) {
+
+	timeout := time.Duration(s.config.Timeout) * time.Second
+	address := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+	tlsConfig := &tls.Config{
+		ServerName:         s.config.Host,
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: s.config.InsecureSkipVerify,
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	var (
+		conn net.Conn
+		err  error
+	)
+	if s.config.Tls == SmtpTlsSsl {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("连接 SMTP 服务器失败: %w", err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if s.config.Tls == SmtpTlsStartTls {
+		if err = client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("SMTP StartTLS 失败: %w", err)
+		}
+	}
+
+	return client, nil
+}
@@ -0,0 +1,441 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/guid"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 存储类型
+const (
+	StoreMemory = "memory" // 进程内缓存
+	StoreRedis  = "redis"  // Redis，多副本部署时使用
+)
+
+var OidcUtility = newOidc(context.Background())
+
+// Config OIDC 配置，对应 oidc.yaml
+type Config struct {
+	Enabled      bool     `json:"enabled"`
+	Name         string   `json:"name"`         // 登录页显示的名称
+	Issuer       string   `json:"issuer"`       // 身份提供方地址，用于自动发现
+	ClientId     string   `json:"clientId"`     // 客户端ID
+	ClientSecret string   `json:"clientSecret"` // 客户端密钥，公开客户端留空
+	RedirectUri  string   `json:"redirectUri"`  // 回调地址
+	Scopes       []string `json:"scopes"`       // 申请的 scope
+	StateExpire  int64    `json:"stateExpire"`  // 登录流程有效期（秒）
+	Store        string   `json:"store"`        // 登录流程存储
+	Redis        string   `json:"redis"`        // store 为 redis 时使用的 redis 配置分组
+	Claims       struct {
+		Username string `json:"username"`
+		Email    string `json:"email"`
+		Groups   string `json:"groups"`
+	} `json:"claims"` // 用户信息对应的 claim 名称
+	LinkByEmail bool `json:"linkByEmail"` // 按已验证的邮箱关联已存在的用户
+	Provision   struct {
+		Enabled      bool     `json:"enabled"`
+		DepartmentId uint64   `json:"departmentId"`
+		RoleIds      []uint64 `json:"roleIds"`
+	} `json:"provision"` // 即时创建用户
+	GroupRoles map[string][]uint64 `json:"groupRoles"` // 组映射角色
+	SyncRoles  bool                `json:"syncRoles"`  // 每次登录按组映射重新设置角色
+}
+
+// Identity 身份提供方返回的用户信息
+type Identity struct {
+	Subject       string   `json:"subject"`
+	Username      string   `json:"username"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"emailVerified"`
+	Groups        []string `json:"groups"`
+}
+
+// 登录流程状态，以 state 为键保存
+type flow struct {
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+// 自动发现文档
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksUri               string `json:"jwks_uri"`
+}
+
+// 令牌响应
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IdToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type oidc struct {
+	Config *Config
+	client *http.Client
+	cache  *gcache.Cache
+
+	mu        sync.Mutex
+	discovery *discovery
+	keys      map[string]interface{}
+	keysAt    time.Time
+}
+
+func newOidc(ctx context.Context) *oidc {
+
+	config := &Config{
+		Name:        "单点登录",
+		Scopes:      []string{"openid", "profile", "email"},
+		StateExpire: 600,
+		Store:       StoreMemory,
+		Redis:       "default",
+	}
+	config.Claims.Username = "preferred_username"
+	config.Claims.Email = "email"
+	config.Claims.Groups = "groups"
+
+	if err := gconv.Struct(g.Cfg("oidc").MustData(ctx), config); err != nil {
+		panic(err)
+	}
+
+	o := &oidc{
+		Config: config,
+		client: &http.Client{Timeout: 10 * time.Second},
+		cache:  gcache.New(),
+	}
+
+	// 根据配置选择存储
+	if config.Store == StoreRedis {
+		o.cache.SetAdapter(gcache.NewAdapterRedis(g.Redis(config.Redis)))
+	}
+
+	return o
+}
+
+// SetHttpClient 设置访问身份提供方使用的 HTTP 客户端
+func (o *oidc) SetHttpClient(client *http.Client) {
+	o.client = client
+}
+
+// AuthorizeUrl 开始登录流程，返回跳转到身份提供方的授权地址
+// 使用授权码模式 + PKCE (S256)，state 和 nonce 只能使用一次
+func (o *oidc) AuthorizeUrl(ctx context.Context) (string, error) {
+
+	if !o.Config.Enabled {
+		return "", errors.New("未开启单点登录")
+	}
+
+	d, err := o.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	verifier, err := randomString(32)
+	if err != nil {
+		return "", err
+	}
+
+	state := guid.S()
+	data := &flow{
+		Nonce:    guid.S(),
+		Verifier: verifier,
+	}
+	if err = o.cache.Set(ctx, o.stateKey(state), data, time.Duration(o.Config.StateExpire)*time.Second); err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", o.Config.ClientId)
+	query.Set("redirect_uri", o.Config.RedirectUri)
+	query.Set("scope", strings.Join(o.Config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", data.Nonce)
+	query.Set("code_challenge", CodeChallenge(verifier))
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return d.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// Exchange 使用授权码换取令牌并校验 ID Token，返回用户信息
+func (o *oidc) Exchange(ctx context.Context, code string, state string) (*Identity, error) {
+
+	if !o.Config.Enabled {
+		return nil, errors.New("未开启单点登录")
+	}
+
+	// 取出并删除登录流程，防止重放
+	value, err := o.cache.Remove(ctx, o.stateKey(state))
+	if err != nil {
+		return nil, err
+	}
+	if value.IsNil() {
+		return nil, errors.New("登录已过期，请重新登录")
+	}
+
+	var data *flow
+	if err = value.Scan(&data); err != nil {
+		return nil, err
+	}
+
+	d, err := o.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", o.Config.RedirectUri)
+	form.Set("client_id", o.Config.ClientId)
+	form.Set("code_verifier", data.Verifier)
+	if o.Config.ClientSecret != "" {
+		form.Set("client_secret", o.Config.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token tokenResponse
+	if err = o.do(req, &token); err != nil && token.Error == "" {
+		return nil, err
+	}
+	if token.Error != "" {
+		return nil, fmt.Errorf("单点登录失败: %s %s", token.Error, token.ErrorDescription)
+	}
+	if token.IdToken == "" {
+		return nil, errors.New("身份提供方没有返回 id_token")
+	}
+
+	return o.VerifyIdToken(ctx, token.IdToken, data.Nonce)
+}
+
+// VerifyIdToken 校验 ID Token 的签名、签发者、受众、有效期和 nonce
+func (o *oidc) VerifyIdToken(ctx context.Context, raw string, nonce string) (*Identity, error) {
+
+	d, err := o.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return o.getKey(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithIssuer(d.Issuer),
+		jwt.WithAudience(o.Config.ClientId),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("id_token 校验失败: %w", err)
+	}
+
+	if value, _ := claims["nonce"].(string); value != nonce {
+		return nil, errors.New("id_token 校验失败: nonce 不匹配")
+	}
+
+	identity := &Identity{
+		Subject:  stringClaim(claims, "sub"),
+		Username: stringClaim(claims, o.Config.Claims.Username),
+		Email:    stringClaim(claims, o.Config.Claims.Email),
+		Groups:   stringsClaim(claims, o.Config.Claims.Groups),
+	}
+	if identity.Subject == "" {
+		return nil, errors.New("id_token 校验失败: 缺少 sub")
+	}
+
+	// email_verified 可能是布尔值或字符串
+	switch value := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = value
+	case string:
+		identity.EmailVerified = value == "true"
+	}
+
+	return identity, nil
+}
+
+// getDiscovery 获取自动发现文档，成功后缓存
+func (o *oidc) getDiscovery(ctx context.Context) (*discovery, error) {
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.discovery != nil {
+		return o.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(o.Config.Issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var d discovery
+	if err = o.do(req, &d); err != nil {
+		return nil, fmt.Errorf("获取身份提供方配置失败: %w", err)
+	}
+
+	// 自动发现文档中的签发者必须与配置一致
+	if strings.TrimRight(d.Issuer, "/") != strings.TrimRight(o.Config.Issuer, "/") {
+		return nil, fmt.Errorf("身份提供方签发者不匹配: %s", d.Issuer)
+	}
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JwksUri == "" {
+		return nil, errors.New("身份提供方配置不完整")
+	}
+
+	o.discovery = &d
+	return o.discovery, nil
+}
+
+// getKey 根据 kid 获取签名公钥
+// 找不到时重新获取 JWKS 以支持身份提供方轮换密钥，每分钟最多获取一次
+func (o *oidc) getKey(ctx context.Context, kid string) (interface{}, error) {
+
+	d, err := o.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if key := o.findKey(kid); key != nil {
+		return key, nil
+	}
+
+	if time.Since(o.keysAt) < time.Minute {
+		return nil, fmt.Errorf("未知的签名密钥: %s", kid)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.JwksUri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var set jwks
+	if err = o.do(req, &set); err != nil {
+		return nil, fmt.Errorf("获取身份提供方公钥失败: %w", err)
+	}
+
+	o.keys = set.parse()
+	o.keysAt = time.Now()
+
+	if key := o.findKey(kid); key != nil {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("未知的签名密钥: %s", kid)
+}
+
+// findKey 查找公钥，kid 为空且只有一个公钥时使用该公钥
+func (o *oidc) findKey(kid string) interface{} {
+	if key, ok := o.keys[kid]; ok {
+		return key
+	}
+	if kid == "" && len(o.keys) == 1 {
+		for _, key := range o.keys {
+			return key
+		}
+	}
+	return nil
+}
+
+// do 发送请求并解析JSON响应，非 2xx 状态码时仍会尝试解析响应
+func (o *oidc) do(req *http.Request, out interface{}) error {
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	jsonErr := json.Unmarshal(body, out)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	return jsonErr
+}
+
+func (o *oidc) stateKey(state string) string {
+	return fmt.Sprintf("oidc:state:%s", state)
+}
+
+// CodeChallenge 计算 PKCE code_challenge (S256)
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// randomString 生成 URL 安全的随机字符串
+func randomString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func stringClaim(claims jwt.MapClaims, name string) string {
+	if name == "" {
+		return ""
+	}
+	value, _ := claims[name].(string)
+	return value
+}
+
+// stringsClaim 读取字符串数组 claim，兼容单个字符串
+func stringsClaim(claims jwt.MapClaims, name string) []string {
+	if name == "" {
+		return nil
+	}
+	switch value := claims[name].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var result []string
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"gf-ant-react/utility/oidc/oidctest"
+
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/util/guid"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 启动模拟的身份提供方，返回连接到它的客户端
+func newTestOidc(t *testing.T) (*oidc, *oidctest.Provider) {
+
+	provider, err := oidctest.NewProvider("", "gf-ant-react", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewTLSServer(provider)
+	t.Cleanup(srv.Close)
+	provider.Issuer = srv.URL
+
+	config := &Config{
+		Enabled:      true,
+		Issuer:       srv.URL,
+		ClientId:     "gf-ant-react",
+		ClientSecret: "secret",
+		RedirectUri:  "https://app.example.com/oidc/callback",
+		Scopes:       []string{"openid", "profile", "email"},
+		StateExpire:  60,
+	}
+	config.Claims.Username = "preferred_username"
+	config.Claims.Email = "email"
+	config.Claims.Groups = "groups"
+
+	o := &oidc{Config: config, cache: gcache.New()}
+	o.SetHttpClient(srv.Client())
+
+	return o, provider
+}
+
+// 开始登录流程并在身份提供方授权，返回授权码和 state
+func authorize(t *testing.T, o *oidc, groups string) (code string, state string) {
+
+	ctx := context.Background()
+
+	authorizeUrl, err := o.AuthorizeUrl(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 不跟随跳转，从回调地址中取出授权码
+	client := *o.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	resp, err := client.PostForm(authorizeUrl, url.Values{
+		"sub":      {"u-1"},
+		"username": {"alice"},
+		"email":    {"alice@example.com"},
+		"groups":   {groups},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	location, err := resp.Location()
+	if err != nil {
+		t.Fatalf("授权没有跳转回客户端: HTTP %d", resp.StatusCode)
+	}
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func TestExchange(t *testing.T) {
+
+	ctx := context.Background()
+	o, _ := newTestOidc(t)
+
+	code, state := authorize(t, o, "admins, ops")
+	identity, err := o.Exchange(ctx, code, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.Subject != "u-1" || identity.Username != "alice" || identity.Email != "alice@example.com" || !identity.EmailVerified {
+		t.Fatalf("用户信息不正确: %+v", identity)
+	}
+	if strings.Join(identity.Groups, ",") != "admins,ops" {
+		t.Fatalf("组不正确: %v", identity.Groups)
+	}
+
+	// state 只能使用一次
+	if _, err = o.Exchange(ctx, code, state); err == nil {
+		t.Fatal("重复使用 state 应当失败")
+	}
+}
+
+func TestExchangeWrongVerifier(t *testing.T) {
+
+	ctx := context.Background()
+	o, _ := newTestOidc(t)
+
+	// 用另一个登录流程的 state 换取授权码，发送的 code_verifier 与 code_challenge 不匹配
+	code, _ := authorize(t, o, "admins")
+	_, other := authorize(t, o, "admins")
+
+	_, err := o.Exchange(ctx, code, other)
+	if err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Fatalf("code_verifier 不匹配应当失败: %v", err)
+	}
+}
+
+func TestExchangeWrongNonce(t *testing.T) {
+
+	ctx := context.Background()
+	o, _ := newTestOidc(t)
+
+	code, state := authorize(t, o, "admins")
+
+	// 修改登录流程中保存的 nonce，模拟 ID Token 被替换
+	value, err := o.cache.Get(ctx, o.stateKey(state))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data *flow
+	if err = value.Scan(&data); err != nil {
+		t.Fatal(err)
+	}
+	data.Nonce = guid.S()
+	if err = o.cache.Set(ctx, o.stateKey(state), data, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = o.Exchange(ctx, code, state)
+	if err == nil || !strings.Contains(err.Error(), "nonce") {
+		t.Fatalf("nonce 不匹配应当失败: %v", err)
+	}
+}
+
+func TestUnknownKidRefreshesJwks(t *testing.T) {
+
+	ctx := context.Background()
+	o, provider := newTestOidc(t)
+
+	code, state := authorize(t, o, "admins")
+	if _, err := o.Exchange(ctx, code, state); err != nil {
+		t.Fatal(err)
+	}
+
+	// 身份提供方轮换密钥，一分钟内不会重新获取 JWKS
+	if err := provider.RotateKey(); err != nil {
+		t.Fatal(err)
+	}
+	code, state = authorize(t, o, "admins")
+	if _, err := o.Exchange(ctx, code, state); err == nil || !strings.Contains(err.Error(), "未知的签名密钥") {
+		t.Fatalf("一分钟内不应当重新获取 JWKS: %v", err)
+	}
+
+	// 超过一分钟后遇到未知的 kid 重新获取 JWKS
+	o.mu.Lock()
+	o.keysAt = time.Now().Add(-2 * time.Minute)
+	o.mu.Unlock()
+
+	code, state = authorize(t, o, "admins")
+	if _, err := o.Exchange(ctx, code, state); err != nil {
+		t.Fatalf("未知的 kid 应当重新获取 JWKS: %v", err)
+	}
+}
+
+func TestStringsClaim(t *testing.T) {
+
+	claims := jwt.MapClaims{
+		"groups": []interface{}{"admins", 1, "ops"},
+		"role":   "editors",
+		"number": 1,
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"groups", "admins,ops"}, // 忽略非字符串
+		{"role", "editors"},      // 单个字符串
+		{"number", ""},
+		{"missing", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(stringsClaim(claims, tt.name), ","); got != tt.want {
+			t.Errorf("stringsClaim(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
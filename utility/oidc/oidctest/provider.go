@@ -0,0 +1,267 @@
+// Package oidctest 模拟 OIDC 身份提供方，用于开发联调和测试，不要在生产环境使用
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"html/template"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/util/guid"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 授权码对应的登录信息
+type code struct {
+	RedirectUri   string `json:"redirectUri"`
+	Nonce         string `json:"nonce"`
+	CodeChallenge string `json:"codeChallenge"`
+	Subject       string `json:"subject"`
+	Username      string `json:"username"`
+	Email         string `json:"email"`
+	Groups        string `json:"groups"`
+}
+
+// Provider 模拟的身份提供方，实现 http.Handler，可以直接用于 httptest
+// 授权页面可以填写任意用户信息，签发的 ID Token 使用创建时生成的 RSA 密钥签名
+type Provider struct {
+	Issuer       string
+	ClientId     string
+	ClientSecret string
+
+	mux   *http.ServeMux
+	mu    sync.RWMutex
+	key   *rsa.PrivateKey
+	kid   string
+	codes *gcache.Cache
+}
+
+var authorizeTemplate = template.Must(template.New("authorize").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Mock OIDC</title></head>
+<body style="font-family:sans-serif;max-width:420px;margin:40px auto">
+<h3>Mock OIDC 登录</h3>
+<form method="post" action="/authorize?{{.Query}}">
+<p>sub<br><input name="sub" value="mock-admin" style="width:100%"></p>
+<p>preferred_username<br><input name="username" value="mock-admin" style="width:100%"></p>
+<p>email (email_verified=true)<br><input name="email" value="mock-admin@example.com" style="width:100%"></p>
+<p>groups（逗号分隔）<br><input name="groups" value="admins" style="width:100%"></p>
+<button type="submit">登录</button>
+</form>
+</body></html>`))
+
+// NewProvider 创建身份提供方，issuer 需要与 oidc.yaml 中的 issuer 一致
+func NewProvider(issuer, clientId, clientSecret string) (*Provider, error) {
+	p := &Provider{
+		Issuer:       strings.TrimRight(issuer, "/"),
+		ClientId:     clientId,
+		ClientSecret: clientSecret,
+		codes:        gcache.New(),
+		mux:          http.NewServeMux(),
+	}
+	p.mux.HandleFunc("GET /.well-known/openid-configuration", p.Discovery)
+	p.mux.HandleFunc("GET /jwks", p.Jwks)
+	p.mux.HandleFunc("GET /authorize", p.AuthorizeForm)
+	p.mux.HandleFunc("POST /authorize", p.Authorize)
+	p.mux.HandleFunc("POST /token", p.Token)
+
+	if err := p.RotateKey(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Provider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mux.ServeHTTP(w, r)
+}
+
+// RotateKey 更换签名密钥，JWKS 只公开新的公钥，用于模拟身份提供方轮换密钥
+func (p *Provider) RotateKey() error {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.key, p.kid = key, guid.S()
+	return nil
+}
+
+// Discovery 自动发现文档
+func (p *Provider) Discovery(w http.ResponseWriter, r *http.Request) {
+	writeJson(w, http.StatusOK, g.Map{
+		"issuer":                                p.Issuer,
+		"authorization_endpoint":                p.Issuer + "/authorize",
+		"token_endpoint":                        p.Issuer + "/token",
+		"jwks_uri":                              p.Issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+// Jwks 签名公钥
+func (p *Provider) Jwks(w http.ResponseWriter, r *http.Request) {
+
+	p.mu.RLock()
+	key, kid := p.key, p.kid
+	p.mu.RUnlock()
+
+	writeJson(w, http.StatusOK, g.Map{
+		"keys": []g.Map{{
+			"kty": "RSA",
+			"kid": kid,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+}
+
+// AuthorizeForm 授权页面，填写要模拟的用户信息
+func (p *Provider) AuthorizeForm(w http.ResponseWriter, r *http.Request) {
+	if msg := p.checkAuthorize(r); msg != "" {
+		http.Error(w, msg, http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = authorizeTemplate.Execute(w, g.Map{"Query": template.URL(r.URL.RawQuery)})
+}
+
+// Authorize 签发授权码并跳转回客户端
+func (p *Provider) Authorize(w http.ResponseWriter, r *http.Request) {
+
+	if msg := p.checkAuthorize(r); msg != "" {
+		http.Error(w, msg, http.StatusBadRequest)
+		return
+	}
+
+	params := r.URL.Query()
+	value := guid.S()
+	err := p.codes.Set(r.Context(), value, &code{
+		RedirectUri:   params.Get("redirect_uri"),
+		Nonce:         params.Get("nonce"),
+		CodeChallenge: params.Get("code_challenge"),
+		Subject:       r.PostFormValue("sub"),
+		Username:      r.PostFormValue("username"),
+		Email:         r.PostFormValue("email"),
+		Groups:        r.PostFormValue("groups"),
+	}, 5*time.Minute)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	query := url.Values{}
+	query.Set("code", value)
+	query.Set("state", params.Get("state"))
+	http.Redirect(w, r, params.Get("redirect_uri")+"?"+query.Encode(), http.StatusFound)
+}
+
+// checkAuthorize 校验授权请求参数，要求使用 PKCE (S256)
+func (p *Provider) checkAuthorize(r *http.Request) string {
+	params := r.URL.Query()
+	switch {
+	case params.Get("response_type") != "code":
+		return "unsupported response_type"
+	case params.Get("client_id") != p.ClientId:
+		return "invalid client_id"
+	case params.Get("redirect_uri") == "":
+		return "missing redirect_uri"
+	case params.Get("code_challenge_method") != "S256" || params.Get("code_challenge") == "":
+		return "PKCE (S256) is required"
+	}
+	return ""
+}
+
+// Token 使用授权码换取令牌
+func (p *Provider) Token(w http.ResponseWriter, r *http.Request) {
+
+	tokenError := func(code string) {
+		writeJson(w, http.StatusBadRequest, g.Map{"error": code})
+	}
+
+	if r.PostFormValue("grant_type") != "authorization_code" {
+		tokenError("unsupported_grant_type")
+		return
+	}
+	if r.PostFormValue("client_id") != p.ClientId || r.PostFormValue("client_secret") != p.ClientSecret {
+		tokenError("invalid_client")
+		return
+	}
+
+	// 授权码只能使用一次
+	value, err := p.codes.Remove(r.Context(), r.PostFormValue("code"))
+	if err != nil || value.IsNil() {
+		tokenError("invalid_grant")
+		return
+	}
+
+	var data *code
+	if err = value.Scan(&data); err != nil {
+		tokenError("invalid_grant")
+		return
+	}
+
+	verifier := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+	if data.RedirectUri != r.PostFormValue("redirect_uri") ||
+		base64.RawURLEncoding.EncodeToString(verifier[:]) != data.CodeChallenge {
+		tokenError("invalid_grant")
+		return
+	}
+
+	var groups []string
+	for _, group := range strings.Split(data.Groups, ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groups = append(groups, group)
+		}
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":                p.Issuer,
+		"aud":                p.ClientId,
+		"sub":                data.Subject,
+		"nonce":              data.Nonce,
+		"iat":                now.Unix(),
+		"exp":                now.Add(5 * time.Minute).Unix(),
+		"preferred_username": data.Username,
+		"email":              data.Email,
+		"email_verified":     data.Email != "",
+		"groups":             groups,
+	})
+
+	p.mu.RLock()
+	token.Header["kid"] = p.kid
+	idToken, err := token.SignedString(p.key)
+	p.mu.RUnlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJson(w, http.StatusOK, g.Map{
+		"access_token": guid.S(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func writeJson(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,29 @@
+package password
+
+import (
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcryptHasher bcrypt，格式: $2a$10$...
+type bcryptHasher struct {
+	Cost int
+}
+
+func (h *bcryptHasher) Hash(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+	return string(bytes), err
+}
+
+func (h *bcryptHasher) Verify(password, hash string) bool {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	return err == nil
+}
+
+func (h *bcryptHasher) Match(hash string) bool {
+	return hasPrefix(hash, "$2a$", "$2b$", "$2y$")
+}
+
+func (h *bcryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.Cost
+}
@@ -1,15 +1,97 @@
 package password
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"context"
+	"strings"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// 哈希算法
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+// Hasher 密码哈希算法
+// 哈希结果自带算法标识和参数，校验时据此选择算法，修改配置不影响已有密码
+type Hasher interface {
+	// Hash 计算密码哈希
+	Hash(password string) (string, error)
+	// Verify 校验密码，hash 必须是该算法生成的
+	Verify(password, hash string) bool
+	// Match 是否为该算法生成的哈希
+	Match(hash string) bool
+	// NeedsRehash 哈希参数是否与当前配置不同
+	NeedsRehash(hash string) bool
+}
+
+// 当前用于生成新哈希的算法
+var hasher = newHasher(context.Background())
+
+// 所有可用于校验的算法
+var hashers = []Hasher{
+	&argon2idHasher{},
+	&bcryptHasher{},
+}
+
+// newHasher 根据 auth.yaml 中的 passwordHash 创建哈希算法
+func newHasher(ctx context.Context) Hasher {
+
+	cfg := g.Cfg("auth")
+
+	switch algorithm := cfg.MustGet(ctx, "passwordHash.algorithm", AlgorithmArgon2id).String(); algorithm {
+	case AlgorithmArgon2id:
+		return &argon2idHasher{
+			Memory:      cfg.MustGet(ctx, "passwordHash.argon2id.memory", 19456).Uint32(),
+			Iterations:  cfg.MustGet(ctx, "passwordHash.argon2id.iterations", 2).Uint32(),
+			Parallelism: cfg.MustGet(ctx, "passwordHash.argon2id.parallelism", 1).Uint8(),
+			SaltLength:  cfg.MustGet(ctx, "passwordHash.argon2id.saltLength", 16).Uint32(),
+			KeyLength:   cfg.MustGet(ctx, "passwordHash.argon2id.keyLength", 32).Uint32(),
+		}
+	case AlgorithmBcrypt:
+		return &bcryptHasher{
+			Cost: cfg.MustGet(ctx, "passwordHash.bcrypt.cost", 10).Int(),
+		}
+	default:
+		panic("不支持的密码哈希算法: " + algorithm)
+	}
+}
+
+// SetHasher 设置生成新哈希的算法
+func SetHasher(h Hasher) {
+	hasher = h
+}
 
 // HashPassword 将明文密码哈希化
 func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	return string(bytes), err
+	return hasher.Hash(password)
 }
 
-// CheckPasswordHash 验证明文密码是否与哈希匹配
+// CheckPasswordHash 验证明文密码是否与哈希匹配，根据哈希格式自动选择算法
 func CheckPasswordHash(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+	for _, h := range hashers {
+		if h.Match(hash) {
+			return h.Verify(password, hash)
+		}
+	}
+	return false
+}
+
+// NeedsRehash 哈希的算法或参数是否与当前配置不同，需要在登录成功后重新计算
+func NeedsRehash(hash string) bool {
+	if !hasher.Match(hash) {
+		return true
+	}
+	return hasher.NeedsRehash(hash)
+}
+
+// hasPrefix 判断哈希格式
+func hasPrefix(hash string, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(hash, prefix) {
+			return true
+		}
+	}
+	return false
 }
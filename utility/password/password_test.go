@@ -0,0 +1,143 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 测试使用较小的参数，缩短计算时间
+func testArgon2id() *argon2idHasher {
+	return &argon2idHasher{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+}
+
+// 临时替换生成新哈希的算法
+func useHasher(t *testing.T, h Hasher) {
+	old := hasher
+	SetHasher(h)
+	t.Cleanup(func() { SetHasher(old) })
+}
+
+func TestHashRoundTrip(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		hasher Hasher
+		prefix string
+	}{
+		{"argon2id", testArgon2id(), "$argon2id$v=19$m=1024,t=1,p=1$"},
+		{"bcrypt", &bcryptHasher{Cost: bcrypt.MinCost}, "$2a$04$"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useHasher(t, tt.hasher)
+
+			hash, err := HashPassword("Secret#123")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(hash, tt.prefix) {
+				t.Fatalf("哈希格式不正确: %s", hash)
+			}
+			if !CheckPasswordHash("Secret#123", hash) {
+				t.Fatal("正确的密码应当通过")
+			}
+			if CheckPasswordHash("Secret#124", hash) {
+				t.Fatal("错误的密码不应当通过")
+			}
+
+			// 盐随机生成，同一个密码每次的哈希不同
+			other, err := HashPassword("Secret#123")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if other == hash {
+				t.Fatal("同一个密码的哈希不应当相同")
+			}
+		})
+	}
+}
+
+func TestCheckPasswordHashDispatch(t *testing.T) {
+
+	argon2Hash, err := testArgon2id().Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bcryptHash, err := (&bcryptHasher{Cost: bcrypt.MinCost}).Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 当前配置为 argon2id 时仍能校验 bcrypt 哈希，反之亦然
+	for _, h := range []Hasher{testArgon2id(), &bcryptHasher{Cost: bcrypt.MinCost}} {
+		useHasher(t, h)
+
+		tests := []struct {
+			name string
+			hash string
+			want bool
+		}{
+			{"argon2id", argon2Hash, true},
+			{"bcrypt $2a$", bcryptHash, true},
+			{"bcrypt $2y$", "$2y$" + strings.TrimPrefix(bcryptHash, "$2a$"), true},
+			{"argon2i", strings.Replace(argon2Hash, "$argon2id$", "$argon2i$", 1), false},
+			{"argon2id 格式错误", "$argon2id$v=19$m=1024", false},
+			{"argon2id 版本错误", strings.Replace(argon2Hash, "v=19", "v=16", 1), false},
+			{"明文", "secret", false},
+			{"空", "", false},
+		}
+		for _, tt := range tests {
+			if got := CheckPasswordHash("secret", tt.hash); got != tt.want {
+				t.Errorf("%T %s: CheckPasswordHash = %v, want %v", h, tt.name, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+
+	current := testArgon2id()
+	useHasher(t, current)
+
+	hash := func(h Hasher) string {
+		value, err := h.Hash("secret")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return value
+	}
+
+	tests := []struct {
+		name string
+		hash string
+		want bool
+	}{
+		{"参数相同", hash(current), false},
+		{"内存不同", hash(&argon2idHasher{Memory: 2048, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}), true},
+		{"迭代次数不同", hash(&argon2idHasher{Memory: 1024, Iterations: 2, Parallelism: 1, SaltLength: 16, KeyLength: 32}), true},
+		{"并行度不同", hash(&argon2idHasher{Memory: 1024, Iterations: 1, Parallelism: 2, SaltLength: 16, KeyLength: 32}), true},
+		{"盐长度不同", hash(&argon2idHasher{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 8, KeyLength: 32}), true},
+		{"哈希长度不同", hash(&argon2idHasher{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 16}), true},
+		{"算法不同", hash(&bcryptHasher{Cost: bcrypt.MinCost}), true},
+		{"格式错误", "$argon2id$v=19$m=1024", true},
+	}
+	for _, tt := range tests {
+		if got := NeedsRehash(tt.hash); got != tt.want {
+			t.Errorf("%s: NeedsRehash = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// bcrypt 按 cost 判断
+	useHasher(t, &bcryptHasher{Cost: bcrypt.MinCost})
+	if NeedsRehash(hash(&bcryptHasher{Cost: bcrypt.MinCost})) {
+		t.Error("cost 相同不需要重新计算")
+	}
+	if !NeedsRehash(hash(&bcryptHasher{Cost: bcrypt.MinCost + 1})) {
+		t.Error("cost 不同需要重新计算")
+	}
+	if !NeedsRehash(hash(current)) {
+		t.Error("算法不同需要重新计算")
+	}
+}
@@ -0,0 +1,92 @@
+package password
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// Policy 密码策略，对应 auth.yaml 中的 passwordPolicy
+type Policy struct {
+	MinLength        int  `json:"minLength"`        // 最小长度
+	RequireUpper     bool `json:"requireUpper"`     // 必须包含大写字母
+	RequireLower     bool `json:"requireLower"`     // 必须包含小写字母
+	RequireDigit     bool `json:"requireDigit"`     // 必须包含数字
+	RequireSymbol    bool `json:"requireSymbol"`    // 必须包含特殊字符
+	DisallowUsername bool `json:"disallowUsername"` // 不能包含用户名
+	History          int  `json:"history"`          // 不能与最近 N 次使用过的密码相同，0=不限制
+	MaxAgeDays       int  `json:"maxAgeDays"`       // 密码有效天数，0=永不过期
+}
+
+// GetPolicy 读取密码策略
+func GetPolicy(ctx context.Context) (*Policy, error) {
+	policy := &Policy{MinLength: 6}
+	if err := g.Cfg("auth").MustGet(ctx, "passwordPolicy").Scan(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// Validate 校验密码是否符合策略，不符合时返回所有未满足的要求
+func (p *Policy) Validate(password, username string) error {
+
+	var (
+		problems                     []string
+		hasUpper, hasLower, hasDigit bool
+		hasSymbol                    bool
+	)
+
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	if utf8.RuneCountInString(password) < p.MinLength {
+		problems = append(problems, fmt.Sprintf("长度不能少于%d位", p.MinLength))
+	}
+	if p.RequireUpper && !hasUpper {
+		problems = append(problems, "必须包含大写字母")
+	}
+	if p.RequireLower && !hasLower {
+		problems = append(problems, "必须包含小写字母")
+	}
+	if p.RequireDigit && !hasDigit {
+		problems = append(problems, "必须包含数字")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		problems = append(problems, "必须包含特殊字符")
+	}
+	if p.DisallowUsername && username != "" && strings.Contains(strings.ToLower(password), strings.ToLower(username)) {
+		problems = append(problems, "不能包含用户名")
+	}
+
+	if len(problems) > 0 {
+		return errors.New("密码" + strings.Join(problems, "，"))
+	}
+
+	return nil
+}
+
+// IsExpired 密码是否已过期
+// changedAt 为空表示没有修改记录，视为未过期
+func (p *Policy) IsExpired(changedAt *gtime.Time) bool {
+	if p.MaxAgeDays <= 0 || changedAt == nil || changedAt.IsZero() {
+		return false
+	}
+	return changedAt.Add(time.Duration(p.MaxAgeDays) * 24 * time.Hour).Before(gtime.Now())
+}
@@ -0,0 +1,80 @@
+package password
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+func TestPolicyValidate(t *testing.T) {
+
+	strict := &Policy{
+		MinLength:        8,
+		RequireUpper:     true,
+		RequireLower:     true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		DisallowUsername: true,
+	}
+
+	tests := []struct {
+		name     string
+		policy   *Policy
+		password string
+		username string
+		problems []string // 为空表示应当通过
+	}{
+		{"默认长度", &Policy{MinLength: 6}, "abcdef", "", nil},
+		{"长度不足", &Policy{MinLength: 6}, "abcde", "", []string{"长度不能少于6位"}},
+		{"按字符计算长度", &Policy{MinLength: 4}, "密码密码", "", nil},
+		{"满足全部要求", strict, "Abcdef1!", "admin", nil},
+		{"缺少大写字母", strict, "abcdef1!", "", []string{"必须包含大写字母"}},
+		{"缺少小写字母", strict, "ABCDEF1!", "", []string{"必须包含小写字母"}},
+		{"缺少数字", strict, "Abcdefg!", "", []string{"必须包含数字"}},
+		{"缺少特殊字符", strict, "Abcdefg1", "", []string{"必须包含特殊字符"}},
+		{"符号算作特殊字符", strict, "Abcdef1+", "", nil},
+		{"包含用户名", strict, "xAdmin1!", "admin", []string{"不能包含用户名"}},
+		{"允许包含用户名", &Policy{}, "admin123", "admin", nil},
+		{"返回所有问题", strict, "abc", "", []string{"长度不能少于8位", "必须包含大写字母", "必须包含数字", "必须包含特殊字符"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate(tt.password, tt.username)
+			if len(tt.problems) == 0 {
+				if err != nil {
+					t.Fatalf("应当通过: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("应当不通过")
+			}
+			if want := "密码" + strings.Join(tt.problems, "，"); err.Error() != want {
+				t.Fatalf("错误信息 = %q, want %q", err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestPolicyIsExpired(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		maxAgeDays int
+		changedAt  *gtime.Time
+		want       bool
+	}{
+		{"永不过期", 0, gtime.Now().Add(-1000 * 24 * time.Hour), false},
+		{"没有修改记录", 90, nil, false},
+		{"未过期", 90, gtime.Now().Add(-89 * 24 * time.Hour), false},
+		{"已过期", 90, gtime.Now().Add(-91 * 24 * time.Hour), true},
+	}
+	for _, tt := range tests {
+		p := &Policy{MaxAgeDays: tt.maxAgeDays}
+		if got := p.IsExpired(tt.changedAt); got != tt.want {
+			t.Errorf("%s: IsExpired = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
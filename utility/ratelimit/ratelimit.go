@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcache"
+)
+
+// 存储类型
+const (
+	StoreMemory = "memory" // 进程内缓存
+	StoreRedis  = "redis"  // Redis，多副本部署时使用
+)
+
+var RateLimitUtility = newRateLimit(context.Background())
+
+type rateLimit struct {
+	cache *gcache.Cache
+}
+
+func newRateLimit(ctx context.Context) *rateLimit {
+
+	r := &rateLimit{
+		cache: gcache.New(),
+	}
+
+	// 根据配置选择存储
+	if g.Cfg("auth").MustGet(ctx, "rateLimit.store", StoreMemory).String() == StoreRedis {
+		r.SetAdapter(gcache.NewAdapterRedis(g.Redis(g.Cfg("auth").MustGet(ctx, "rateLimit.redis", "default").String())))
+	}
+
+	return r
+}
+
+// SetAdapter 设置存储适配器
+func (r *rateLimit) SetAdapter(adapter gcache.Adapter) {
+	r.cache.SetAdapter(adapter)
+}
+
+// Limit 一个计数键及其限制次数
+type Limit struct {
+	Key   string
+	Limit int
+}
+
+// Allow 固定窗口计数，窗口内超过 limit 次返回 false，limit 为0时不限制
+// 计数不是原子操作，并发请求时可能略微超过限制
+func (r *rateLimit) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, error) {
+	return r.AllowAll(ctx, window, Limit{Key: key, Limit: limit})
+}
+
+// AllowAll 同时检查多个计数键，按顺序检查，任意一个超过限制返回 false
+// 全部允许时才计数，被拒绝的请求不占用其他键的次数
+func (r *rateLimit) AllowAll(ctx context.Context, window time.Duration, limits ...Limit) (bool, error) {
+
+	if window <= 0 {
+		return true, nil
+	}
+
+	bucket := time.Now().UnixNano() / int64(window)
+	counts := make([]int, len(limits))
+	for i, limit := range limits {
+		if limit.Limit <= 0 {
+			continue
+		}
+		value, err := r.cache.Get(ctx, r.cacheKey(limit.Key, bucket))
+		if err != nil {
+			return false, err
+		}
+		counts[i] = value.Int()
+		if counts[i] >= limit.Limit {
+			return false, nil
+		}
+	}
+
+	for i, limit := range limits {
+		if limit.Limit <= 0 {
+			continue
+		}
+		if err := r.cache.Set(ctx, r.cacheKey(limit.Key, bucket), counts[i]+1, window); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func (r *rateLimit) cacheKey(key string, bucket int64) string {
+	return fmt.Sprintf("ratelimit:%s:%d", key, bucket)
+}
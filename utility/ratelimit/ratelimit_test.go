@@ -0,0 +1,44 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gcache"
+)
+
+func TestAllowAll(t *testing.T) {
+
+	ctx := context.Background()
+	r := &rateLimit{cache: gcache.New()}
+
+	ip := Limit{Key: "ip", Limit: 3}
+	account := Limit{Key: "account", Limit: 1}
+
+	if ok, err := r.AllowAll(ctx, time.Hour, ip, account); err != nil || !ok {
+		t.Fatalf("第一次请求应当允许: %v %v", ok, err)
+	}
+
+	// 账号超过限制，被拒绝的请求不占用 IP 的次数
+	for i := 0; i < 5; i++ {
+		if ok, err := r.AllowAll(ctx, time.Hour, ip, account); err != nil || ok {
+			t.Fatalf("账号超过限制应当拒绝: %v %v", ok, err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if ok, err := r.Allow(ctx, ip.Key, ip.Limit, time.Hour); err != nil || !ok {
+			t.Fatalf("IP 还有剩余次数: %d %v %v", i, ok, err)
+		}
+	}
+	if ok, err := r.Allow(ctx, ip.Key, ip.Limit, time.Hour); err != nil || ok {
+		t.Fatalf("IP 超过限制应当拒绝: %v %v", ok, err)
+	}
+
+	// limit 为0时不限制
+	for i := 0; i < 5; i++ {
+		if ok, err := r.Allow(ctx, "unlimited", 0, time.Hour); err != nil || !ok {
+			t.Fatalf("limit 为0时应当允许: %v %v", ok, err)
+		}
+	}
+}
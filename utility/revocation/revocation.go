@@ -0,0 +1,135 @@
+package revocation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gf-ant-react/utility/jwt"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcache"
+)
+
+// 吊销存储类型
+const (
+	StoreMemory = "memory" // 进程内缓存
+	StoreRedis  = "redis"  // Redis，多副本部署时使用
+)
+
+var RevocationUtility = newRevocation(context.Background())
+
+type revocation struct {
+	cache *gcache.Cache
+}
+
+func newRevocation(ctx context.Context) *revocation {
+
+	r := &revocation{
+		cache: gcache.New(),
+	}
+
+	// 根据配置选择存储
+	if g.Cfg("jwt").MustGet(ctx, "revokeStore", StoreMemory).String() == StoreRedis {
+		r.SetAdapter(gcache.NewAdapterRedis(g.Redis(g.Cfg("jwt").MustGet(ctx, "revokeRedis", "default").String())))
+	}
+
+	return r
+}
+
+// SetAdapter 设置存储适配器，任何实现了 gcache.Adapter 的存储都可以接入
+func (r *revocation) SetAdapter(adapter gcache.Adapter) {
+	r.cache.SetAdapter(adapter)
+}
+
+// RevokeToken 吊销单个令牌
+// 记录保存到令牌过期为止，过期后令牌本身已无法通过校验
+func (r *revocation) RevokeToken(ctx context.Context, tokenId string, expiresAt time.Time) error {
+
+	ttl := time.Until(expiresAt)
+	if tokenId == "" || ttl <= 0 {
+		return nil
+	}
+
+	return r.cache.Set(ctx, r.tokenKey(tokenId), 1, ttl)
+}
+
+// RevokeUser 吊销用户在此刻之前签发的所有令牌
+// 记录吊销时刻的毫秒时间戳，保存一个访问令牌的最长有效期
+func (r *revocation) RevokeUser(ctx context.Context, userId uint64) error {
+	return r.cache.Set(ctx, r.userKey(userId), time.Now().UnixMilli(), time.Duration(jwt.JwtUtility.Expire)*time.Second)
+}
+
+// RevokeSession 吊销登录会话中签发的所有令牌
+// 记录保存一个访问令牌的最长有效期，之后会话也无法再刷新
+func (r *revocation) RevokeSession(ctx context.Context, sessionId string) error {
+	if sessionId == "" {
+		return nil
+	}
+	return r.cache.Set(ctx, r.sessionKey(sessionId), 1, time.Duration(jwt.JwtUtility.Expire)*time.Second)
+}
+
+// IsRevoked 检查令牌是否已被吊销
+func (r *revocation) IsRevoked(ctx context.Context, claims *jwt.Claims) (bool, error) {
+
+	// 单个令牌吊销
+	if claims.ID != "" {
+		ok, err := r.cache.Contains(ctx, r.tokenKey(claims.ID))
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+
+	// 会话吊销
+	if claims.SessionID != "" {
+		ok, err := r.cache.Contains(ctx, r.sessionKey(claims.SessionID))
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+
+	// 代登录时，操作人的会话或令牌被吊销后代登录同时失效
+	if claims.Impersonator != nil {
+		if claims.Impersonator.SessionID != "" {
+			ok, err := r.cache.Contains(ctx, r.sessionKey(claims.Impersonator.SessionID))
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		ok, err := r.userRevoked(ctx, claims.Impersonator.UserID, claims)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+
+	return r.userRevoked(ctx, claims.UserID, claims)
+}
+
+// userRevoked 用户级吊销，吊销时刻之前签发的令牌都失效
+func (r *revocation) userRevoked(ctx context.Context, userId uint64, claims *jwt.Claims) (bool, error) {
+
+	revokedAt, err := r.cache.Get(ctx, r.userKey(userId))
+	if err != nil {
+		return false, err
+	}
+	if revokedAt.IsNil() {
+		return false, nil
+	}
+	if claims.IssuedAt == nil {
+		return true, nil
+	}
+
+	return claims.IssuedAt.UnixMilli() < revokedAt.Int64(), nil
+}
+
+func (r *revocation) tokenKey(tokenId string) string {
+	return fmt.Sprintf("revoke:token:%s", tokenId)
+}
+
+func (r *revocation) userKey(userId uint64) string {
+	return fmt.Sprintf("revoke:user:%d", userId)
+}
+
+func (r *revocation) sessionKey(sessionId string) string {
+	return fmt.Sprintf("revoke:session:%s", sessionId)
+}
@@ -0,0 +1,59 @@
+package revocation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gf-ant-react/utility/jwt"
+
+	"github.com/gogf/gf/v2/os/gcache"
+	jwtlib "github.com/golang-jwt/jwt/v5"
+)
+
+// 模拟签发并解析令牌，签发时间经过 JSON 序列化
+func issuedAt(t *testing.T, userId uint64, at time.Time) *jwt.Claims {
+
+	data, err := json.Marshal(&jwt.Claims{
+		UserID:           userId,
+		RegisteredClaims: jwtlib.RegisteredClaims{IssuedAt: jwtlib.NewNumericDate(at)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims := &jwt.Claims{}
+	if err = json.Unmarshal(data, claims); err != nil {
+		t.Fatal(err)
+	}
+	return claims
+}
+
+func TestUserRevokedSameSecond(t *testing.T) {
+
+	ctx := context.Background()
+	r := &revocation{cache: gcache.New()}
+
+	before := issuedAt(t, 1, time.Now())
+	time.Sleep(5 * time.Millisecond)
+	if err := r.RevokeUser(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	after := issuedAt(t, 1, time.Now())
+
+	if ok, err := r.IsRevoked(ctx, before); err != nil || !ok {
+		t.Fatalf("吊销之前签发的令牌应当失效: %v %v", ok, err)
+	}
+
+	// 同一秒内重新登录签发的令牌仍然有效
+	if ok, err := r.IsRevoked(ctx, after); err != nil || ok {
+		t.Fatalf("吊销之后签发的令牌应当有效: %v %v", ok, err)
+	}
+
+	// 其他用户不受影响
+	if ok, err := r.IsRevoked(ctx, issuedAt(t, 2, time.Now().Add(-time.Hour))); err != nil || ok {
+		t.Fatalf("其他用户的令牌应当有效: %v %v", ok, err)
+	}
+}
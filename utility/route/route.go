@@ -0,0 +1,281 @@
+package route
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MethodAny 匹配所有请求方法
+const MethodAny = "ANY"
+
+// 路径段类型，数值越大越具体
+const (
+	kindRest    = 1 // /** 匹配剩余的零个或多个路径段，只能在末尾
+	kindParam   = 2 // :id、{id} 或 * 匹配一个路径段
+	kindLiteral = 3 // 普通路径段
+)
+
+type segment struct {
+	kind  int
+	value string
+}
+
+// Pattern 路由规则
+// 支持 :id、{id}、* 匹配一个路径段，末尾的 /** 匹配剩余的零个或多个路径段，方法 ANY 匹配所有请求方法
+type Pattern struct {
+	Method   string
+	Path     string
+	segments []segment
+}
+
+// Parse 解析路由规则
+func Parse(method, path string) (*Pattern, error) {
+
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if method == "" {
+		return nil, errors.New("请求方法不能为空")
+	}
+
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("路径必须以 / 开头: %s", path)
+	}
+
+	p := &Pattern{Method: method, Path: path}
+	parts := split(path)
+	for i, part := range parts {
+		switch {
+		case part == "**":
+			if i != len(parts)-1 {
+				return nil, fmt.Errorf("** 只能出现在路径末尾: %s", path)
+			}
+			p.segments = append(p.segments, segment{kind: kindRest})
+		case part == "*":
+			p.segments = append(p.segments, segment{kind: kindParam})
+		case strings.HasPrefix(part, ":"):
+			if len(part) == 1 {
+				return nil, fmt.Errorf("路径参数缺少名称: %s", path)
+			}
+			p.segments = append(p.segments, segment{kind: kindParam, value: part[1:]})
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}"):
+			if len(part) == 2 {
+				return nil, fmt.Errorf("路径参数缺少名称: %s", path)
+			}
+			p.segments = append(p.segments, segment{kind: kindParam, value: part[1 : len(part)-1]})
+		case strings.ContainsAny(part, "*{}"):
+			return nil, fmt.Errorf("不支持的路径段 %s: %s", part, path)
+		default:
+			p.segments = append(p.segments, segment{kind: kindLiteral, value: part})
+		}
+	}
+
+	return p, nil
+}
+
+// Match 检查请求是否匹配规则
+func (p *Pattern) Match(method, path string) bool {
+	if p.Method != MethodAny && p.Method != strings.ToUpper(method) {
+		return false
+	}
+	return p.matchPath(split(path))
+}
+
+func (p *Pattern) matchPath(parts []string) bool {
+	for i, seg := range p.segments {
+		if seg.kind == kindRest {
+			return true
+		}
+		if i >= len(parts) {
+			return false
+		}
+		if seg.kind == kindLiteral && seg.value != parts[i] {
+			return false
+		}
+	}
+	return len(parts) == len(p.segments)
+}
+
+// Key 规则的形状，参数名不同但形状相同的规则匹配同样的请求
+func (p *Pattern) Key() string {
+	var b strings.Builder
+	b.WriteString(p.Method)
+	b.WriteString(" ")
+	for _, seg := range p.segments {
+		b.WriteString("/")
+		switch seg.kind {
+		case kindRest:
+			b.WriteString("**")
+		case kindParam:
+			b.WriteString("*")
+		default:
+			b.WriteString(seg.value)
+		}
+	}
+	return b.String()
+}
+
+// 是否不含通配符
+func (p *Pattern) literal() bool {
+	for _, seg := range p.segments {
+		if seg.kind != kindLiteral {
+			return false
+		}
+	}
+	return true
+}
+
+// Compare 比较两个规则的具体程度，大于 0 表示 p 更具体
+// 从左到右逐段比较，普通路径段 > 路径参数 > /**；最后指定方法比 ANY 更具体
+func (p *Pattern) Compare(o *Pattern) int {
+	for i := 0; i < len(p.segments) && i < len(o.segments); i++ {
+		if d := p.segments[i].kind - o.segments[i].kind; d != 0 {
+			return d
+		}
+	}
+	// 前面的路径段相同时，多出的是 /** 的规则更宽泛，否则两条规则不会匹配同一个请求
+	if len(p.segments) > len(o.segments) {
+		if p.segments[len(o.segments)].kind == kindRest {
+			return -1
+		}
+		return 1
+	}
+	if len(p.segments) < len(o.segments) {
+		if o.segments[len(p.segments)].kind == kindRest {
+			return 1
+		}
+		return -1
+	}
+	if p.Method != o.Method {
+		if p.Method == MethodAny {
+			return -1
+		}
+		if o.Method == MethodAny {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Conflict 两条规则形状相同，同一个请求无法决定使用哪一条
+func (p *Pattern) Conflict(o *Pattern) bool {
+	return p.Key() == o.Key()
+}
+
+// 按 / 拆分路径，忽略首尾和重复的 /
+func split(path string) []string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	result := parts[:0]
+	for _, part := range parts {
+		if part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+type entry struct {
+	pattern *Pattern
+	value   string
+}
+
+// Table 路由表，查找时最具体的规则优先
+type Table struct {
+	exact    map[string]string // 不含通配符的规则，"METHOD /path"
+	patterns []*entry          // 含通配符的规则，按具体程度从高到低排序
+}
+
+// NewTable 创建路由表
+func NewTable() *Table {
+	return &Table{exact: make(map[string]string)}
+}
+
+// Add 添加规则，与已有规则冲突时返回错误
+func (t *Table) Add(method, path, value string) error {
+
+	p, err := Parse(method, path)
+	if err != nil {
+		return err
+	}
+
+	if p.literal() {
+		key := p.Key()
+		if _, ok := t.exact[key]; ok {
+			return fmt.Errorf("路由规则冲突: %s %s", p.Method, p.Path)
+		}
+		t.exact[key] = value
+		return nil
+	}
+
+	for _, e := range t.patterns {
+		if e.pattern.Conflict(p) {
+			return fmt.Errorf("路由规则冲突: %s %s 与 %s %s", p.Method, p.Path, e.pattern.Method, e.pattern.Path)
+		}
+	}
+
+	t.patterns = append(t.patterns, &entry{pattern: p, value: value})
+	sort.SliceStable(t.patterns, func(i, j int) bool {
+		return t.patterns[i].pattern.Compare(t.patterns[j].pattern) > 0
+	})
+
+	return nil
+}
+
+// Lookup 查找最具体的匹配规则
+func (t *Table) Lookup(method, path string) (string, bool) {
+
+	method = strings.ToUpper(method)
+	parts := split(path)
+
+	// 不含通配符的规则比同样路径的通配符规则更具体
+	key := ""
+	if len(parts) > 0 {
+		key = "/" + strings.Join(parts, "/")
+	}
+	if value, ok := t.exact[method+" "+key]; ok {
+		return value, true
+	}
+	if value, ok := t.exact[MethodAny+" "+key]; ok {
+		return value, true
+	}
+
+	for _, e := range t.patterns {
+		if (e.pattern.Method == MethodAny || e.pattern.Method == method) && e.pattern.matchPath(parts) {
+			return e.value, true
+		}
+	}
+
+	return "", false
+}
+
+// Len 规则数量
+func (t *Table) Len() int {
+	if t == nil {
+		return 0
+	}
+	return len(t.exact) + len(t.patterns)
+}
+
+// 已解析的规则，配置中的路由每次请求都要匹配
+var parsed sync.Map
+
+// MatchMap 检查请求是否匹配 路径 => 方法 形式的路由配置，如 auth.yaml 中的 ignoreRoutes
+func MatchMap(routes map[string]string, method, path string) bool {
+	for pattern, m := range routes {
+		key := m + " " + pattern
+		value, ok := parsed.Load(key)
+		if !ok {
+			p, err := Parse(m, pattern)
+			if err != nil {
+				continue
+			}
+			value, _ = parsed.LoadOrStore(key, p)
+		}
+		if value.(*Pattern).Match(method, path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,237 @@
+package route
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+
+	tests := []struct {
+		method, path string
+		key          string // 为空表示应当解析失败
+	}{
+		{"get", "/sys/user/list", "GET /sys/user/list"},
+		{"GET", "/sys/user/:id", "GET /sys/user/*"},
+		{"GET", "/sys/user/{id}", "GET /sys/user/*"},
+		{"ANY", "/sys/*/list", "ANY /sys/*/list"},
+		{"GET", "//sys//user/", "GET /sys/user"},
+		{"GET", "/sys/**", "GET /sys/**"},
+		{"GET", "/", "GET "},
+		{"", "/sys/user", ""},
+		{"GET", "sys/user", ""},
+		{"GET", "/sys/**/list", ""}, // ** 只能在末尾
+		{"GET", "/sys/:", ""},
+		{"GET", "/sys/{}", ""},
+		{"GET", "/sys/user*", ""},
+	}
+	for _, tt := range tests {
+		p, err := Parse(tt.method, tt.path)
+		if tt.key == "" {
+			if err == nil {
+				t.Errorf("Parse(%q, %q) 应当失败", tt.method, tt.path)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q, %q) error: %v", tt.method, tt.path, err)
+			continue
+		}
+		if got := p.Key(); got != tt.key {
+			t.Errorf("Parse(%q, %q).Key() = %q, want %q", tt.method, tt.path, got, tt.key)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+
+	tests := []struct {
+		method, pattern string
+		reqMethod, path string
+		want            bool
+	}{
+		{"GET", "/sys/user/:id", "GET", "/sys/user/1", true},
+		{"GET", "/sys/user/:id", "get", "/sys/user/1", true},
+		{"GET", "/sys/user/:id", "POST", "/sys/user/1", false},
+		{"GET", "/sys/user/:id", "GET", "/sys/user", false},
+		{"GET", "/sys/user/:id", "GET", "/sys/user/1/roles", false},
+		{"ANY", "/sys/user/:id", "DELETE", "/sys/user/1", true},
+		// 末尾的 /** 匹配零个或多个路径段
+		{"GET", "/sys/**", "GET", "/sys", true},
+		{"GET", "/sys/**", "GET", "/sys/user", true},
+		{"GET", "/sys/**", "GET", "/sys/user/1/roles", true},
+		{"GET", "/sys/**", "GET", "/cms/article", false},
+		{"GET", "/**", "GET", "/", true},
+	}
+	for _, tt := range tests {
+		p, err := Parse(tt.method, tt.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := p.Match(tt.reqMethod, tt.path); got != tt.want {
+			t.Errorf("%s %s Match(%s %s) = %v, want %v", tt.method, tt.pattern, tt.reqMethod, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestTableLookup(t *testing.T) {
+
+	table := NewTable()
+	for _, r := range []struct{ method, path, value string }{
+		{"GET", "/sys/user/list", "list"},
+		{"GET", "/sys/user/:id", "detail"},
+		{"ANY", "/sys/user/:id", "any-user"},
+		{"GET", "/sys/*/list", "any-list"},
+		{"GET", "/sys/**", "sys"},
+		{"ANY", "/**", "all"},
+		{"POST", "/sys/user", "create"},
+		{"ANY", "/sys/user", "any-create"},
+	} {
+		if err := table.Add(r.method, r.path, r.value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		method, path string
+		want         string
+	}{
+		// 不含通配符的规则优先
+		{"GET", "/sys/user/list", "list"},
+		// 从左到右逐段比较，前面的路径段更具体的优先
+		{"GET", "/sys/user/1", "detail"},
+		{"GET", "/sys/role/list", "any-list"},
+		// 指定方法比 ANY 更具体
+		{"DELETE", "/sys/user/1", "any-user"},
+		{"POST", "/sys/user", "create"},
+		{"PUT", "/sys/user", "any-create"},
+		// /** 最宽泛
+		{"GET", "/sys/role/1", "sys"},
+		{"GET", "/sys", "sys"},
+		{"POST", "/sys/role/1", "all"},
+		{"GET", "/cms/article/list", "all"},
+	}
+	for _, tt := range tests {
+		got, ok := table.Lookup(tt.method, tt.path)
+		if !ok || got != tt.want {
+			t.Errorf("Lookup(%s %s) = %q %v, want %q", tt.method, tt.path, got, ok, tt.want)
+		}
+	}
+
+	if table.Len() != 8 {
+		t.Errorf("Len() = %d, want 8", table.Len())
+	}
+}
+
+func TestTableLookupNotFound(t *testing.T) {
+
+	table := NewTable()
+	if err := table.Add("GET", "/sys/user/:id", "detail"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"/sys/user", "/sys/user/1/roles", "/cms/article/1"} {
+		if value, ok := table.Lookup("GET", path); ok {
+			t.Errorf("Lookup(GET %s) = %q, 应当没有匹配", path, value)
+		}
+	}
+	if value, ok := table.Lookup("POST", "/sys/user/1"); ok {
+		t.Errorf("Lookup(POST /sys/user/1) = %q, 应当没有匹配", value)
+	}
+}
+
+func TestConflict(t *testing.T) {
+
+	tests := []struct {
+		a, b     [2]string
+		conflict bool
+	}{
+		// 参数名不同但形状相同
+		{[2]string{"GET", "/sys/user/:id"}, [2]string{"GET", "/sys/user/{userId}"}, true},
+		{[2]string{"GET", "/sys/user/:id"}, [2]string{"get", "/sys/user/*"}, true},
+		{[2]string{"GET", "/sys/**"}, [2]string{"GET", "/sys/**/"}, true},
+		{[2]string{"GET", "/sys/user/:id"}, [2]string{"POST", "/sys/user/:id"}, false},
+		{[2]string{"GET", "/sys/user/:id"}, [2]string{"ANY", "/sys/user/:id"}, false},
+		{[2]string{"GET", "/sys/user/:id"}, [2]string{"GET", "/sys/user/list"}, false},
+		{[2]string{"GET", "/sys/*"}, [2]string{"GET", "/sys/**"}, false},
+	}
+	for _, tt := range tests {
+		a, err := Parse(tt.a[0], tt.a[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := Parse(tt.b[0], tt.b[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := a.Conflict(b); got != tt.conflict {
+			t.Errorf("%v Conflict(%v) = %v, want %v", tt.a, tt.b, got, tt.conflict)
+		}
+
+		// 冲突的规则不能加入同一个路由表
+		table := NewTable()
+		if err = table.Add(tt.a[0], tt.a[1], "a"); err != nil {
+			t.Fatal(err)
+		}
+		if err = table.Add(tt.b[0], tt.b[1], "b"); (err != nil) != tt.conflict {
+			t.Errorf("Add(%v) 后 Add(%v) error = %v, want conflict %v", tt.a, tt.b, err, tt.conflict)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+
+	tests := []struct {
+		a, b [2]string
+		want int // 1: a 更具体, -1: b 更具体, 0: 相同
+	}{
+		{[2]string{"GET", "/sys/user/list"}, [2]string{"GET", "/sys/user/:id"}, 1},
+		{[2]string{"GET", "/sys/user/:id"}, [2]string{"GET", "/sys/*/list"}, 1},
+		{[2]string{"GET", "/sys/user/:id"}, [2]string{"GET", "/sys/user/**"}, 1},
+		{[2]string{"GET", "/sys/user/**"}, [2]string{"GET", "/sys/**"}, 1},
+		{[2]string{"GET", "/sys/**"}, [2]string{"GET", "/sys"}, -1},
+		{[2]string{"GET", "/sys/user/:id"}, [2]string{"ANY", "/sys/user/:id"}, 1},
+		{[2]string{"ANY", "/sys/user/:id"}, [2]string{"GET", "/sys/user/:id"}, -1},
+		{[2]string{"GET", "/sys/user/:id"}, [2]string{"GET", "/sys/user/{id}"}, 0},
+	}
+	for _, tt := range tests {
+		a, err := Parse(tt.a[0], tt.a[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := Parse(tt.b[0], tt.b[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := a.Compare(b)
+		if (got > 0) != (tt.want > 0) || (got < 0) != (tt.want < 0) {
+			t.Errorf("%v Compare(%v) = %d, want sign %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestMatchMap(t *testing.T) {
+
+	routes := map[string]string{
+		"/auth/login":     "POST",
+		"/auth/oidc/**":   "ANY",
+		"/public/:fileId": "GET",
+	}
+
+	tests := []struct {
+		method, path string
+		want         bool
+	}{
+		{"POST", "/auth/login", true},
+		{"GET", "/auth/login", false},
+		{"GET", "/auth/oidc/config", true},
+		{"POST", "/auth/oidc/callback", true},
+		{"GET", "/public/1", true},
+		{"GET", "/public/1/download", false},
+		{"GET", "/sys/user/list", false},
+	}
+	for _, tt := range tests {
+		if got := MatchMap(routes, tt.method, tt.path); got != tt.want {
+			t.Errorf("MatchMap(%s %s) = %v, want %v", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 附录 B 的 SHA-1 密钥 "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateCodeRfc6238(t *testing.T) {
+
+	totp := &totpUtility{Digits: 8, Period: 30, Skew: 1}
+
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+	for _, tt := range tests {
+		code, err := totp.GenerateCode(rfcSecret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != tt.code {
+			t.Errorf("GenerateCode(%d) = %s, want %s", tt.unix, code, tt.code)
+		}
+
+		// 6 位验证码取后 6 位
+		code, err = TotpUtility.GenerateCode(rfcSecret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != tt.code[2:] {
+			t.Errorf("6 位 GenerateCode(%d) = %s, want %s", tt.unix, code, tt.code[2:])
+		}
+	}
+}
+
+func TestValidateSkew(t *testing.T) {
+
+	totp := &totpUtility{Digits: 8, Period: 30, Skew: 1}
+
+	// 1111111111 所在的时间步
+	at := time.Unix(1111111111, 0)
+	step := at.Unix() / 30
+
+	tests := []struct {
+		name   string
+		offset int64 // 验证码所在时间步相对当前时间步的偏移
+		ok     bool
+	}{
+		{"当前时间步", 0, true},
+		{"上一个时间步", -1, true},
+		{"下一个时间步", 1, true},
+		{"超出偏移", -2, false},
+		{"超出偏移", 2, false},
+	}
+	for _, tt := range tests {
+		code, err := totp.code(rfcSecret, step+tt.offset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		matched, ok := totp.Validate(rfcSecret, code, at)
+		if ok != tt.ok {
+			t.Errorf("%s (%d): Validate = %v, want %v", tt.name, tt.offset, ok, tt.ok)
+			continue
+		}
+		// 返回验证码所在的时间步，调用方据此拒绝重复使用
+		if ok && matched != step+tt.offset {
+			t.Errorf("%s (%d): 时间步 = %d, want %d", tt.name, tt.offset, matched, step+tt.offset)
+		}
+	}
+
+	// 不允许偏移时只接受当前时间步
+	strict := &totpUtility{Digits: 8, Period: 30, Skew: 0}
+	code, err := strict.code(rfcSecret, step-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := strict.Validate(rfcSecret, code, at); ok {
+		t.Error("Skew 为0时不应当接受上一个时间步的验证码")
+	}
+}
+
+func TestValidateInvalid(t *testing.T) {
+
+	at := time.Unix(1111111111, 0)
+	code, err := TotpUtility.GenerateCode(rfcSecret, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+		ok     bool
+	}{
+		{"正确", rfcSecret, code, true},
+		{"前后空格", rfcSecret, " " + code + " ", true},
+		{"小写密钥", strings.ToLower(rfcSecret), code, true},
+		{"位数不对", rfcSecret, code[:5], false},
+		{"验证码错误", rfcSecret, "000000", code == "000000"},
+		{"密钥错误", "JBSWY3DPEHPK3PXP", code, false},
+		{"密钥格式错误", "not base32!", code, false},
+	}
+	for _, tt := range tests {
+		if _, ok := TotpUtility.Validate(tt.secret, tt.code, at); ok != tt.ok {
+			t.Errorf("%s: Validate = %v, want %v", tt.name, ok, tt.ok)
+		}
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+
+	codes, err := TotpUtility.GenerateRecoveryCodes(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != 10 {
+		t.Fatalf("恢复码数量 = %d, want 10", len(codes))
+	}
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		if len(code) != 11 || code[5] != '-' {
+			t.Errorf("恢复码格式不正确: %s", code)
+		}
+		if seen[code] {
+			t.Errorf("恢复码重复: %s", code)
+		}
+		seen[code] = true
+	}
+
+	// 哈希忽略大小写、空格和连字符
+	hash := TotpUtility.HashRecoveryCode(codes[0])
+	if TotpUtility.HashRecoveryCode(" "+strings.ToUpper(strings.ReplaceAll(codes[0], "-", ""))) != hash {
+		t.Error("恢复码哈希应当忽略大小写、空格和连字符")
+	}
+	if TotpUtility.HashRecoveryCode(codes[1]) == hash {
+		t.Error("不同恢复码的哈希不应当相同")
+	}
+}
@@ -0,0 +1,50 @@
+package useragent
+
+import (
+	"strings"
+)
+
+// 按顺序匹配，靠前的优先（如 Edge 的 UA 中同时包含 Chrome）
+var (
+	browsers = [][2]string{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"MicroMessenger", "WeChat"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"curl/", "curl"},
+	}
+	systems = [][2]string{
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"Linux", "Linux"},
+	}
+)
+
+// Device 从 User-Agent 中识别浏览器和操作系统，如 "Chrome / Windows"
+// 只用于展示，无法识别时返回空字符串
+func Device(userAgent string) string {
+
+	var parts []string
+	if name := match(userAgent, browsers); name != "" {
+		parts = append(parts, name)
+	}
+	if name := match(userAgent, systems); name != "" {
+		parts = append(parts, name)
+	}
+
+	return strings.Join(parts, " / ")
+}
+
+func match(userAgent string, rules [][2]string) string {
+	for _, rule := range rules {
+		if strings.Contains(userAgent, rule[0]) {
+			return rule[1]
+		}
+	}
+	return ""
+}